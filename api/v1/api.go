@@ -14,12 +14,15 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,10 +37,14 @@ import (
 	"github.com/prometheus/alertmanager/cluster"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/inhibit"
+	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/pkg/parse"
+	"github.com/prometheus/alertmanager/pkg/web"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -80,6 +87,32 @@ type Alert struct {
 	Fingerprint string            `json:"fingerprint"`
 }
 
+// AlertGroup is the API representation of an aggregation group of the
+// dispatcher.
+type AlertGroup struct {
+	Labels   model.LabelSet `json:"labels"`
+	GroupKey string         `json:"groupKey"`
+	Receiver string         `json:"receiver"`
+	Alerts   []*Alert       `json:"alerts"`
+	// The time the next notification for the group is due.
+	NextNotification time.Time `json:"nextNotification"`
+}
+
+// Inhibition is the API representation of an inhibition rule and the firing
+// source alerts through which it inhibits an alert.
+type Inhibition struct {
+	Rule    *config.InhibitRule `json:"rule"`
+	Sources []*Alert            `json:"sources"`
+}
+
+// SilenceEdit is the API representation of a modification of a silence.
+type SilenceEdit struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Editor    string         `json:"editor,omitempty"`
+	Before    *types.Silence `json:"before"`
+	After     *types.Silence `json:"after"`
+}
+
 // Enables cross-site script calls.
 func setCORS(w http.ResponseWriter) {
 	for h, v := range corsHeaders {
@@ -93,13 +126,28 @@ type API struct {
 	silences       *silence.Silences
 	config         *config.Config
 	route          *dispatch.Route
+	inhibitor      *inhibit.Inhibitor
+	dispatcher     *dispatch.Dispatcher
+	deadLetters    *notify.DeadLetters
+	history        *notify.NotificationHistory
+	uiConfig       *web.UIConfig
+	tmpl           *template.Template
 	resolveTimeout time.Duration
 	uptime         time.Time
+	reloadStatus   configReloadStatus
 	peer           *cluster.Peer
 	logger         log.Logger
 
 	getAlertStatus getAlertStatusFn
 
+	// Whether silences can only be modified by their owner and the users
+	// allowed to modify any silence. The user is authenticated by the web
+	// server or, if it doesn't authenticate requests, by a proxy setting
+	// the user header.
+	enforceOwnership bool
+	userHeader       string
+	admins           map[string]bool
+
 	mtx sync.RWMutex
 }
 
@@ -127,6 +175,21 @@ func New(
 	}
 }
 
+// EnforceOwnership makes the API record the authenticated user as owner of
+// new silences. Silences can then only be modified by their owner or one of
+// the admins. The user authenticated by the web server takes precedence,
+// otherwise it is read from the given request header set by a proxy. The
+// header must be empty if the web server authenticates requests, so clients
+// can't set it themselves.
+func (api *API) EnforceOwnership(userHeader string, admins []string) {
+	api.enforceOwnership = true
+	api.userHeader = userHeader
+	api.admins = make(map[string]bool, len(admins))
+	for _, a := range admins {
+		api.admins[a] = true
+	}
+}
+
 // Register registers the API handlers under their correct routes
 // in the given router.
 func (api *API) Register(r *route.Router) {
@@ -141,14 +204,39 @@ func (api *API) Register(r *route.Router) {
 
 	r.Get("/status", wrap(api.status))
 	r.Get("/receivers", wrap(api.receivers))
+	r.Post("/receivers/:name/test", wrap(api.testReceiver))
+	r.Get("/routes", wrap(api.routes))
+	r.Post("/routes/match", wrap(api.matchRoutes))
 
 	r.Get("/alerts", wrap(api.listAlerts))
+	r.Get("/alerts/stream", wrap(api.streamAlerts))
 	r.Post("/alerts", wrap(api.addAlerts))
+	r.Get("/alerts/groups", wrap(api.alertGroups))
+	r.Post("/alerts/inhibitions", wrap(api.explainInhibitions))
+
+	r.Get("/labels", wrap(api.labelNames))
+	r.Get("/label/:name/values", wrap(api.labelValues))
 
 	r.Get("/silences", wrap(api.listSilences))
 	r.Post("/silences", wrap(api.setSilence))
+	r.Del("/silences", wrap(api.delSilences))
+	r.Post("/silences/bulk", wrap(api.setSilences))
+	r.Post("/silences/preview", wrap(api.previewSilence))
+	r.Post("/silences/affecting", wrap(api.affectingSilences))
+	r.Get("/silences/templates", wrap(api.listSilenceTemplates))
+	r.Post("/silences/templates/:name", wrap(api.createSilenceFromTemplate))
 	r.Get("/silence/:sid", wrap(api.getSilence))
+	r.Get("/silence/:sid/history", wrap(api.getSilenceHistory))
+	r.Post("/silence/:sid/extend", wrap(api.extendSilence))
+	r.Post("/silence/:sid/preview", wrap(api.previewSilenceUpdate))
 	r.Del("/silence/:sid", wrap(api.delSilence))
+	r.Del("/silence/:sid/purge", wrap(api.purgeSilence))
+
+	r.Get("/notifications", wrap(api.listNotifications))
+
+	r.Get("/deadletters", wrap(api.listDeadLetters))
+	r.Post("/deadletters/:id/replay", wrap(api.replayDeadLetter))
+	r.Del("/deadletters/:id", wrap(api.delDeadLetter))
 }
 
 // Update sets the configuration string to a new value.
@@ -162,12 +250,78 @@ func (api *API) Update(cfg *config.Config, resolveTimeout time.Duration) error {
 	return nil
 }
 
+// SetInhibitor sets the inhibitor used to explain inhibitions.
+func (api *API) SetInhibitor(ih *inhibit.Inhibitor) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+
+	api.inhibitor = ih
+}
+
+// SetDispatcher sets the dispatcher whose aggregation groups are exposed.
+func (api *API) SetDispatcher(d *dispatch.Dispatcher) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+
+	api.dispatcher = d
+}
+
+// SetTemplate sets the template used to render test notifications.
+func (api *API) SetTemplate(tmpl *template.Template) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+
+	api.tmpl = tmpl
+}
+
+// SetConfigReloadStatus records the result of a configuration reload. The
+// previous configuration stays in use if it failed.
+func (api *API) SetConfigReloadStatus(err error) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+
+	if err != nil {
+		api.reloadStatus.Success = false
+		api.reloadStatus.Error = err.Error()
+		return
+	}
+	api.reloadStatus = configReloadStatus{
+		Success:     true,
+		LastSuccess: time.Now(),
+	}
+}
+
+// SetDeadLetters sets the store of failed notifications exposed for replay.
+func (api *API) SetDeadLetters(d *notify.DeadLetters) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+
+	api.deadLetters = d
+}
+
+// SetNotificationHistory sets the history of sent notifications.
+func (api *API) SetNotificationHistory(h *notify.NotificationHistory) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+
+	api.history = h
+}
+
+// SetUIConfig sets the customization of the web UI reported in the status.
+func (api *API) SetUIConfig(c *web.UIConfig) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+
+	api.uiConfig = c
+}
+
 type errorType string
 
 const (
-	errorNone     errorType = ""
-	errorInternal errorType = "server_error"
-	errorBadData  errorType = "bad_data"
+	errorNone      errorType = ""
+	errorInternal  errorType = "server_error"
+	errorBadData   errorType = "bad_data"
+	errorForbidden errorType = "forbidden"
 )
 
 type apiError struct {
@@ -191,15 +345,164 @@ func (api *API) receivers(w http.ResponseWriter, req *http.Request) {
 	api.respond(w, receivers)
 }
 
+// testReceiver sends a test alert through all integrations of a receiver
+// and reports the result of each.
+func (api *API) testReceiver(w http.ResponseWriter, r *http.Request) {
+	name := route.Param(r.Context(), "name")
+
+	api.mtx.RLock()
+	var rc *config.Receiver
+	for _, c := range api.config.Receivers {
+		if c.Name == name {
+			rc = c
+			break
+		}
+	}
+	tmpl := api.tmpl
+	api.mtx.RUnlock()
+
+	if rc == nil {
+		http.Error(w, fmt.Sprintf("Error getting receiver: receiver %q not found", name), http.StatusNotFound)
+		return
+	}
+	if tmpl == nil {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: errors.New("templates not loaded"),
+		}, nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), notify.MinTimeout)
+	defer cancel()
+	results := notify.TestReceiver(ctx, rc, tmpl, notify.NewTestAlert(name, time.Now()), api.logger)
+
+	var failed int
+	for _, res := range results {
+		if res.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: fmt.Errorf("test notification failed for %d of %d integrations", failed, len(results)),
+		}, results)
+		return
+	}
+	api.respond(w, results)
+}
+
+// routeNode is the JSON representation of a node in the routing tree.
+type routeNode struct {
+	Matchers types.Matchers      `json:"matchers"`
+	Continue bool                `json:"continue"`
+	Options  *dispatch.RouteOpts `json:"options"`
+	Routes   []*routeNode        `json:"routes,omitempty"`
+}
+
+func newRouteNode(r *dispatch.Route) *routeNode {
+	n := &routeNode{
+		Matchers: r.Matchers,
+		Continue: r.Continue,
+		Options:  &r.RouteOpts,
+	}
+	if n.Matchers == nil {
+		n.Matchers = types.Matchers{}
+	}
+	for _, cr := range r.Routes {
+		n.Routes = append(n.Routes, newRouteNode(cr))
+	}
+	return n
+}
+
+func (api *API) routes(w http.ResponseWriter, req *http.Request) {
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+
+	api.respond(w, newRouteNode(api.route))
+}
+
+// routeMatch describes a route matching a label set and how alerts with
+// the label set are grouped on it.
+type routeMatch struct {
+	Matchers    types.Matchers      `json:"matchers"`
+	Options     *dispatch.RouteOpts `json:"options"`
+	GroupKey    string              `json:"groupKey"`
+	GroupLabels model.LabelSet      `json:"groupLabels"`
+	// The mute time intervals of the route that are currently active.
+	ActiveMuteTimeIntervals []string `json:"activeMuteTimeIntervals"`
+}
+
+func (api *API) matchRoutes(w http.ResponseWriter, r *http.Request) {
+	var lset model.LabelSet
+	if err := api.receive(r, &lset); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	if err := lset.Validate(); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+
+	muteTimes := map[string]*config.MuteTimeInterval{}
+	for _, mt := range api.config.MuteTimeIntervals {
+		muteTimes[mt.Name] = mt
+	}
+	now := time.Now()
+
+	res := []*routeMatch{}
+	for _, rt := range api.route.Match(lset) {
+		m := &routeMatch{
+			Matchers:                rt.Matchers,
+			Options:                 &rt.RouteOpts,
+			GroupKey:                rt.GroupKey(lset),
+			GroupLabels:             rt.GroupLabels(lset),
+			ActiveMuteTimeIntervals: []string{},
+		}
+		if m.Matchers == nil {
+			m.Matchers = types.Matchers{}
+		}
+		for _, name := range rt.RouteOpts.MuteTimeIntervals {
+			mt, ok := muteTimes[name]
+			if !ok {
+				continue
+			}
+			for _, ti := range mt.TimeIntervals {
+				if ti.ContainsTime(now) {
+					m.ActiveMuteTimeIntervals = append(m.ActiveMuteTimeIntervals, name)
+					break
+				}
+			}
+		}
+		res = append(res, m)
+	}
+
+	api.respond(w, res)
+}
+
 func (api *API) status(w http.ResponseWriter, req *http.Request) {
 	api.mtx.RLock()
 
 	var status = struct {
-		ConfigYAML    string            `json:"configYAML"`
-		ConfigJSON    *config.Config    `json:"configJSON"`
-		VersionInfo   map[string]string `json:"versionInfo"`
-		Uptime        time.Time         `json:"uptime"`
-		ClusterStatus *clusterStatus    `json:"clusterStatus"`
+		ConfigYAML    string             `json:"configYAML"`
+		ConfigJSON    *config.Config     `json:"configJSON"`
+		VersionInfo   map[string]string  `json:"versionInfo"`
+		Uptime        time.Time          `json:"uptime"`
+		ClusterStatus *clusterStatus     `json:"clusterStatus"`
+		ConfigReload  configReloadStatus `json:"configReload"`
+		AlertCounts   map[string]int     `json:"alertCounts,omitempty"`
+		SilenceCounts map[string]int     `json:"silenceCounts,omitempty"`
+		UI            *web.UIConfig      `json:"ui"`
 	}{
 		ConfigYAML: api.config.String(),
 		ConfigJSON: api.config,
@@ -213,13 +516,72 @@ func (api *API) status(w http.ResponseWriter, req *http.Request) {
 		},
 		Uptime:        api.uptime,
 		ClusterStatus: getClusterStatus(api.peer),
+		ConfigReload:  api.reloadStatus,
+		UI:            api.uiConfig,
+	}
+	if status.UI == nil {
+		status.UI = &web.UIConfig{Links: []web.UILink{}}
 	}
 
 	api.mtx.RUnlock()
 
+	var err error
+	if api.alerts != nil {
+		if status.AlertCounts, err = api.countAlerts(); err != nil {
+			api.respondError(w, apiError{
+				typ: errorInternal,
+				err: err,
+			}, nil)
+			return
+		}
+	}
+	if api.silences != nil {
+		status.SilenceCounts = map[string]int{}
+		for _, st := range []types.SilenceState{types.SilenceStateActive, types.SilenceStatePending, types.SilenceStateExpired} {
+			if status.SilenceCounts[string(st)], err = api.silences.CountState(st); err != nil {
+				api.respondError(w, apiError{
+					typ: errorInternal,
+					err: err,
+				}, nil)
+				return
+			}
+		}
+	}
+
 	api.respond(w, status)
 }
 
+// countAlerts returns the number of alerts which aren't resolved by state.
+func (api *API) countAlerts() (map[string]int, error) {
+	counts := map[string]int{
+		string(types.AlertStateUnprocessed): 0,
+		string(types.AlertStateActive):      0,
+		string(types.AlertStateSuppressed):  0,
+	}
+
+	alerts := api.alerts.GetPending()
+	defer alerts.Close()
+
+	now := time.Now()
+	for a := range alerts.Next() {
+		if err := alerts.Err(); err != nil {
+			return nil, err
+		}
+		if a.ResolvedAt(now) {
+			continue
+		}
+		counts[string(api.getAlertStatus(a.Fingerprint()).State)]++
+	}
+	return counts, alerts.Err()
+}
+
+// configReloadStatus is the result of the last configuration reload.
+type configReloadStatus struct {
+	Success     bool      `json:"success"`
+	LastSuccess time.Time `json:"lastSuccess"`
+	Error       string    `json:"error,omitempty"`
+}
+
 type peerStatus struct {
 	Name    string `json:"name"`
 	Address string `json:"address"`
@@ -412,6 +774,254 @@ func alertMatchesFilterLabels(a *model.Alert, matchers []*labels.Matcher) bool {
 	return matchFilterLabels(matchers, sms)
 }
 
+// alertStreamInterval is the interval in which the stream of alert state
+// changes checks for alerts becoming silenced, inhibited or resolved without
+// being updated.
+const alertStreamInterval = 5 * time.Second
+
+// alertStreamBuffer is the number of alert updates buffered for a client of
+// the alert stream. Clients falling further behind are disconnected, so they
+// don't block the alert provider.
+const alertStreamBuffer = 10000
+
+// bufferAlerts receives the alerts of the iterator into a channel buffering
+// up to size alerts. The iterator is closed and the channel is closed if the
+// buffer overflows, the iterator is exhausted or stop is closed.
+func bufferAlerts(it provider.AlertIterator, size int, stop <-chan struct{}, l log.Logger) <-chan *types.Alert {
+	ch := make(chan *types.Alert, size)
+	go func() {
+		defer close(ch)
+		defer it.Close()
+
+		for {
+			select {
+			case a, ok := <-it.Next():
+				if !ok {
+					return
+				}
+				if err := it.Err(); err != nil {
+					level.Error(l).Log("msg", "Streaming alerts failed", "err", err)
+					return
+				}
+				select {
+				case ch <- a:
+				default:
+					level.Warn(l).Log("msg", "Disconnecting slow client of the alert stream")
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// States of alerts in the stream of alert state changes.
+const (
+	alertStreamFiring    = "firing"
+	alertStreamSilenced  = "silenced"
+	alertStreamInhibited = "inhibited"
+	alertStreamResolved  = "resolved"
+)
+
+func alertStreamState(a *types.Alert, status types.AlertStatus, now time.Time) string {
+	switch {
+	case a.ResolvedAt(now):
+		return alertStreamResolved
+	case len(status.SilencedBy) > 0:
+		return alertStreamSilenced
+	case len(status.InhibitedBy) > 0:
+		return alertStreamInhibited
+	default:
+		return alertStreamFiring
+	}
+}
+
+// streamAlerts sends the state changes of alerts as server-sent events. The
+// event type is the new state of the alert: firing, silenced, inhibited or
+// resolved. All current alerts are sent when the stream starts.
+func (api *API) streamAlerts(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: errors.New("streaming not supported"),
+		}, nil)
+		return
+	}
+
+	matchers := []*labels.Matcher{}
+	if filter := r.FormValue("filter"); filter != "" {
+		var err error
+		matchers, err = parse.Matchers(filter)
+		if err != nil {
+			api.respondError(w, apiError{
+				typ: errorBadData,
+				err: err,
+			}, nil)
+			return
+		}
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	updates := bufferAlerts(api.alerts.Subscribe(), alertStreamBuffer, stop, api.logger)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var (
+		alerts = map[model.Fingerprint]*types.Alert{}
+		states = map[model.Fingerprint]string{}
+		ticker = time.NewTicker(alertStreamInterval)
+	)
+	defer ticker.Stop()
+
+	// send writes an event if the state of the alert changed.
+	send := func(a *types.Alert) error {
+		fp := a.Fingerprint()
+		status := api.getAlertStatus(fp)
+		state := alertStreamState(a, status, time.Now())
+		alerts[fp] = a
+		if states[fp] == state {
+			return nil
+		}
+		states[fp] = state
+
+		api.mtx.RLock()
+		routes := api.route.Match(a.Labels)
+		api.mtx.RUnlock()
+		receivers := make([]string, 0, len(routes))
+		for _, r := range routes {
+			receivers = append(receivers, r.RouteOpts.Receiver)
+		}
+
+		b, err := json.Marshal(&Alert{
+			Alert:       &a.Alert,
+			Status:      status,
+			Receivers:   receivers,
+			Fingerprint: fp.String(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", state, b); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	for {
+		select {
+		case a, ok := <-updates:
+			if !ok {
+				return
+			}
+			if !alertMatchesFilterLabels(&a.Alert, matchers) {
+				continue
+			}
+			if err := send(a); err != nil {
+				return
+			}
+		case <-ticker.C:
+			for fp, a := range alerts {
+				if err := send(a); err != nil {
+					return
+				}
+				// Forget resolved alerts once they are garbage collected.
+				if states[fp] == alertStreamResolved {
+					if _, err := api.alerts.Get(fp); err == provider.ErrNotFound {
+						delete(alerts, fp)
+						delete(states, fp)
+					}
+				}
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// alertLabels returns the values of all labels of the alerts which aren't
+// resolved.
+func (api *API) alertLabels() (map[model.LabelName]map[model.LabelValue]struct{}, error) {
+	alerts := api.alerts.GetPending()
+	defer alerts.Close()
+
+	res := map[model.LabelName]map[model.LabelValue]struct{}{}
+	now := time.Now()
+	for a := range alerts.Next() {
+		if err := alerts.Err(); err != nil {
+			return nil, err
+		}
+		if a.ResolvedAt(now) {
+			continue
+		}
+		for ln, lv := range a.Labels {
+			if res[ln] == nil {
+				res[ln] = map[model.LabelValue]struct{}{}
+			}
+			res[ln][lv] = struct{}{}
+		}
+	}
+	return res, alerts.Err()
+}
+
+// labelNames returns the sorted label names of the alerts which aren't
+// resolved, for autocompletion of matchers.
+func (api *API) labelNames(w http.ResponseWriter, r *http.Request) {
+	lvs, err := api.alertLabels()
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: err,
+		}, nil)
+		return
+	}
+
+	names := make([]string, 0, len(lvs))
+	for ln := range lvs {
+		names = append(names, string(ln))
+	}
+	sort.Strings(names)
+
+	api.respond(w, names)
+}
+
+// labelValues returns the sorted values of a label of the alerts which
+// aren't resolved, for autocompletion of matchers.
+func (api *API) labelValues(w http.ResponseWriter, r *http.Request) {
+	name := model.LabelName(route.Param(r.Context(), "name"))
+	if !name.IsValid() {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: fmt.Errorf("invalid label name %q", name),
+		}, nil)
+		return
+	}
+
+	lvs, err := api.alertLabels()
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: err,
+		}, nil)
+		return
+	}
+
+	values := make([]string, 0, len(lvs[name]))
+	for lv := range lvs[name] {
+		values = append(values, string(lv))
+	}
+	sort.Strings(values)
+
+	api.respond(w, values)
+}
+
 func (api *API) addAlerts(w http.ResponseWriter, r *http.Request) {
 	var alerts []*types.Alert
 	if err := api.receive(r, &alerts); err != nil {
@@ -490,17 +1100,592 @@ func (api *API) insertAlerts(w http.ResponseWriter, r *http.Request, alerts ...*
 	api.respond(w, nil)
 }
 
-func removeEmptyLabels(ls model.LabelSet) {
-	for k, v := range ls {
-		if string(v) == "" {
-			delete(ls, k)
-		}
+// alertGroups returns the alert groups of the dispatcher with the time of
+// their next notification.
+func (api *API) alertGroups(w http.ResponseWriter, r *http.Request) {
+	api.mtx.RLock()
+	d := api.dispatcher
+	api.mtx.RUnlock()
+
+	res := []*AlertGroup{}
+	if d != nil {
+		for _, g := range d.Groups() {
+			ag := &AlertGroup{
+				Labels:           g.Labels,
+				GroupKey:         g.GroupKey,
+				Receiver:         g.Receiver,
+				Alerts:           make([]*Alert, 0, len(g.Alerts)),
+				NextNotification: g.NextFlush,
+			}
+			for _, a := range g.Alerts {
+				ag.Alerts = append(ag.Alerts, &Alert{
+					Alert:       &a.Alert,
+					Status:      api.getAlertStatus(a.Fingerprint()),
+					Receivers:   []string{g.Receiver},
+					Fingerprint: a.Fingerprint().String(),
+				})
+			}
+			res = append(res, ag)
+		}
+	}
+
+	api.respond(w, res)
+}
+
+// explainInhibitions returns the inhibition rules and the firing source
+// alerts that inhibit the received label set.
+func (api *API) explainInhibitions(w http.ResponseWriter, r *http.Request) {
+	var lset model.LabelSet
+	if err := api.receive(r, &lset); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	if err := lset.Validate(); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	api.mtx.RLock()
+	ih := api.inhibitor
+	api.mtx.RUnlock()
+
+	res := []*Inhibition{}
+	if ih != nil {
+		for _, in := range ih.Explain(lset) {
+			sources := make([]*Alert, 0, len(in.Sources))
+			for _, a := range in.Sources {
+				sources = append(sources, &Alert{
+					Alert:       &a.Alert,
+					Status:      api.getAlertStatus(a.Fingerprint()),
+					Fingerprint: a.Fingerprint().String(),
+				})
+			}
+			res = append(res, &Inhibition{Rule: in.Rule, Sources: sources})
+		}
+	}
+	api.respond(w, res)
+}
+
+func removeEmptyLabels(ls model.LabelSet) {
+	for k, v := range ls {
+		if string(v) == "" {
+			delete(ls, k)
+		}
+	}
+}
+
+func (api *API) setSilence(w http.ResponseWriter, r *http.Request) {
+	var sil types.Silence
+	if err := api.receive(r, &sil); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	psil, err := receivedSilenceToProto(&sil)
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	if err := api.authorize(r, psil.Id); err != nil {
+		api.respondError(w, apiError{
+			typ: errorForbidden,
+			err: err,
+		}, nil)
+		return
+	}
+	psil.Owner = api.user(r)
+
+	sid, err := api.silences.Set(psil, api.editor(r, psil.CreatedBy))
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	api.respond(w, struct {
+		SilenceID string `json:"silenceId"`
+	}{
+		SilenceID: sid,
+	})
+}
+
+func (api *API) listSilenceTemplates(w http.ResponseWriter, r *http.Request) {
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+
+	tmpls := api.config.SilenceTemplates
+	if tmpls == nil {
+		tmpls = []*config.SilenceTemplate{}
+	}
+	api.respond(w, tmpls)
+}
+
+// createSilenceFromTemplate creates a silence from the configured silence template
+// with the given name. Fields set in the request body override the defaults
+// of the template; matchers override template matchers of the same name.
+func (api *API) createSilenceFromTemplate(w http.ResponseWriter, r *http.Request) {
+	name := route.Param(r.Context(), "name")
+
+	api.mtx.RLock()
+	var tmpl *config.SilenceTemplate
+	for _, t := range api.config.SilenceTemplates {
+		if t.Name == name {
+			tmpl = t
+			break
+		}
+	}
+	api.mtx.RUnlock()
+
+	if tmpl == nil {
+		http.Error(w, fmt.Sprintf("Unknown silence template %q", name), http.StatusNotFound)
+		return
+	}
+
+	var overrides types.Silence
+	if err := api.receive(r, &overrides); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	psil, err := receivedSilenceToProto(applySilenceTemplate(tmpl, &overrides, time.Now()))
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	psil.Owner = api.user(r)
+
+	sid, err := api.silences.Set(psil, api.editor(r, psil.CreatedBy))
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	api.respond(w, struct {
+		SilenceID string `json:"silenceId"`
+	}{
+		SilenceID: sid,
+	})
+}
+
+// applySilenceTemplate returns a new silence based on the template with the
+// fields set in overrides taking precedence.
+func applySilenceTemplate(tmpl *config.SilenceTemplate, overrides *types.Silence, now time.Time) *types.Silence {
+	matchers := map[string]*types.Matcher{}
+	for n, v := range tmpl.Match {
+		matchers[n] = &types.Matcher{Name: n, Value: v}
+	}
+	for n, v := range tmpl.MatchRE {
+		matchers[n] = &types.Matcher{Name: n, Value: v, IsRegex: true}
+	}
+	for _, m := range overrides.Matchers {
+		matchers[m.Name] = m
+	}
+
+	sil := &types.Silence{
+		StartsAt:   overrides.StartsAt,
+		EndsAt:     overrides.EndsAt,
+		CreatedBy:  overrides.CreatedBy,
+		Comment:    overrides.Comment,
+		TicketURL:  overrides.TicketURL,
+		Recurrence: overrides.Recurrence,
+	}
+	for _, m := range matchers {
+		sil.Matchers = append(sil.Matchers, m)
+	}
+	sort.Sort(sil.Matchers)
+
+	if sil.StartsAt.IsZero() {
+		sil.StartsAt = now
+	}
+	if sil.EndsAt.IsZero() {
+		sil.EndsAt = sil.StartsAt.Add(time.Duration(tmpl.Duration))
+	}
+	if sil.Comment == "" {
+		sil.Comment = tmpl.Comment
+	}
+	return sil
+}
+
+// previewSilence returns the currently firing alerts that the given
+// silence would match.
+func (api *API) previewSilence(w http.ResponseWriter, r *http.Request) {
+	var sil types.Silence
+	if err := api.receive(r, &sil); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	if err := initMatchers(sil.Matchers); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	var (
+		err error
+		res = []*Alert{}
+	)
+	alerts := api.alerts.GetPending()
+	defer alerts.Close()
+
+	api.mtx.RLock()
+	for a := range alerts.Next() {
+		if err = alerts.Err(); err != nil {
+			break
+		}
+		if a.Resolved() || !sil.Matchers.Match(a.Labels) {
+			continue
+		}
+
+		routes := api.route.Match(a.Labels)
+		receivers := make([]string, 0, len(routes))
+		for _, r := range routes {
+			receivers = append(receivers, r.RouteOpts.Receiver)
+		}
+
+		res = append(res, &Alert{
+			Alert:       &a.Alert,
+			Status:      api.getAlertStatus(a.Fingerprint()),
+			Receivers:   receivers,
+			Fingerprint: a.Fingerprint().String(),
+		})
+	}
+	api.mtx.RUnlock()
+
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: err,
+		}, nil)
+		return
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Fingerprint < res[j].Fingerprint
+	})
+	api.respond(w, res)
+}
+
+// initMatchers validates and compiles the matchers of a silence that isn't
+// stored.
+func initMatchers(ms types.Matchers) error {
+	if len(ms) == 0 {
+		return errors.New("at least one matcher required")
+	}
+	for _, m := range ms {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+		if err := m.Init(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// silenceUpdatePreview lists the alerts which start and stop being silenced
+// by an edit of a silence, and those which stay silenced.
+type silenceUpdatePreview struct {
+	Added     []*Alert `json:"added"`
+	Removed   []*Alert `json:"removed"`
+	Unchanged []*Alert `json:"unchanged"`
+}
+
+// previewSilenceUpdate returns the alerts whose silencing changes if the
+// silence is replaced by the one in the request body.
+func (api *API) previewSilenceUpdate(w http.ResponseWriter, r *http.Request) {
+	var sil types.Silence
+	if err := api.receive(r, &sil); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	sil.ID = route.Param(r.Context(), "sid")
+	if err := initMatchers(sil.Matchers); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	psil, err := silenceToProto(&sil)
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	silenced, err := api.silences.PreviewUpdate(psil, time.Now())
+	if err == silence.ErrNotFound {
+		http.Error(w, fmt.Sprint("Error getting silence: ", err), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: err,
+		}, nil)
+		return
+	}
+
+	res := silenceUpdatePreview{
+		Added:     []*Alert{},
+		Removed:   []*Alert{},
+		Unchanged: []*Alert{},
+	}
+	alerts := api.alerts.GetPending()
+	defer alerts.Close()
+
+	api.mtx.RLock()
+	for a := range alerts.Next() {
+		if err = alerts.Err(); err != nil {
+			break
+		}
+		if a.Resolved() {
+			continue
+		}
+		before, after := silenced(a.Labels)
+		if !before && !after {
+			continue
+		}
+
+		routes := api.route.Match(a.Labels)
+		receivers := make([]string, 0, len(routes))
+		for _, r := range routes {
+			receivers = append(receivers, r.RouteOpts.Receiver)
+		}
+		alert := &Alert{
+			Alert:       &a.Alert,
+			Status:      api.getAlertStatus(a.Fingerprint()),
+			Receivers:   receivers,
+			Fingerprint: a.Fingerprint().String(),
+		}
+		switch {
+		case before && after:
+			res.Unchanged = append(res.Unchanged, alert)
+		case after:
+			res.Added = append(res.Added, alert)
+		default:
+			res.Removed = append(res.Removed, alert)
+		}
+	}
+	api.mtx.RUnlock()
+
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: err,
+		}, nil)
+		return
+	}
+	for _, as := range [][]*Alert{res.Added, res.Removed, res.Unchanged} {
+		sort.Slice(as, func(i, j int) bool {
+			return as[i].Fingerprint < as[j].Fingerprint
+		})
+	}
+	api.respond(w, res)
+}
+
+// affectingSilences returns the active and pending silences whose matchers
+// match the given label set.
+func (api *API) affectingSilences(w http.ResponseWriter, r *http.Request) {
+	var lset model.LabelSet
+	if err := api.receive(r, &lset); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	if err := lset.Validate(); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	psils, err := api.silences.Query(
+		silence.QMatches(lset),
+		silence.QState(types.SilenceStateActive, types.SilenceStatePending),
+	)
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: err,
+		}, nil)
+		return
+	}
+
+	sils := make([]*types.Silence, 0, len(psils))
+	for _, ps := range psils {
+		s, err := silenceFromProto(ps)
+		if err != nil {
+			api.respondError(w, apiError{
+				typ: errorInternal,
+				err: err,
+			}, nil)
+			return
+		}
+		sils = append(sils, s)
+	}
+	sort.Slice(sils, func(i, j int) bool {
+		return sils[i].ID < sils[j].ID
+	})
+	api.respond(w, sils)
+}
+
+// receivedSilenceToProto validates a silence received through the API and
+// converts it to its protobuf representation.
+func receivedSilenceToProto(sil *types.Silence) (*silencepb.Silence, error) {
+	// This is an API only validation, it cannot be done internally
+	// because the expired silence is semantically important.
+	// But one should not be able to create expired silences, that
+	// won't have any use.
+	// Silences without end time get the default duration, if configured.
+	if sil.EndsAt.IsZero() {
+		return silenceToProto(sil)
+	}
+	if sil.Expired() {
+		return nil, errors.New("start time must not be equal to end time")
+	}
+	if sil.EndsAt.Before(time.Now()) {
+		return nil, errors.New("end time can't be in the past")
+	}
+	return silenceToProto(sil)
+}
+
+// setSilences creates or updates all given silences at once.
+func (api *API) setSilences(w http.ResponseWriter, r *http.Request) {
+	var sils []*types.Silence
+	if err := api.receive(r, &sils); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	psils := make([]*silencepb.Silence, 0, len(sils))
+	for i, sil := range sils {
+		psil, err := receivedSilenceToProto(sil)
+		if err != nil {
+			api.respondError(w, apiError{
+				typ: errorBadData,
+				err: fmt.Errorf("silence %d: %s", i, err),
+			}, nil)
+			return
+		}
+		if err := api.authorize(r, psil.Id); err != nil {
+			api.respondError(w, apiError{
+				typ: errorForbidden,
+				err: fmt.Errorf("silence %d: %s", i, err),
+			}, nil)
+			return
+		}
+		psil.Owner = api.user(r)
+		psils = append(psils, psil)
+	}
+
+	sids, err := api.silences.SetAll(psils, api.editor(r, ""))
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	api.respond(w, struct {
+		SilenceIDs []string `json:"silenceIds"`
+	}{
+		SilenceIDs: sids,
+	})
+}
+
+// delSilences expires all active and pending silences matching the
+// required filter at once.
+func (api *API) delSilences(w http.ResponseWriter, r *http.Request) {
+	filter := r.FormValue("filter")
+	if filter == "" {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: errors.New("filter required"),
+		}, nil)
+		return
+	}
+	matchers, err := parse.Matchers(filter)
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	psils, err := api.silences.Query(silence.QState(types.SilenceStateActive, types.SilenceStatePending))
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: err,
+		}, nil)
+		return
+	}
+
+	sids := []string{}
+	for _, ps := range psils {
+		s, err := silenceFromProto(ps)
+		if err != nil {
+			api.respondError(w, apiError{
+				typ: errorInternal,
+				err: err,
+			}, nil)
+			return
+		}
+		if !silenceMatchesFilterLabels(s, matchers) {
+			continue
+		}
+		if err := api.authorize(r, s.ID); err != nil {
+			api.respondError(w, apiError{
+				typ: errorForbidden,
+				err: err,
+			}, nil)
+			return
+		}
+		sids = append(sids, s.ID)
 	}
-}
 
-func (api *API) setSilence(w http.ResponseWriter, r *http.Request) {
-	var sil types.Silence
-	if err := api.receive(r, &sil); err != nil {
+	if err := api.silences.ExpireAll(sids, api.user(r)); err != nil {
 		api.respondError(w, apiError{
 			typ: errorBadData,
 			err: err,
@@ -508,57 +1693,176 @@ func (api *API) setSilence(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// This is an API only validation, it cannot be done internally
-	// because the expired silence is semantically important.
-	// But one should not be able to create expired silences, that
-	// won't have any use.
-	if sil.Expired() {
+	api.respond(w, struct {
+		SilenceIDs []string `json:"silenceIds"`
+	}{
+		SilenceIDs: sids,
+	})
+}
+
+func (api *API) getSilence(w http.ResponseWriter, r *http.Request) {
+	sid := route.Param(r.Context(), "sid")
+
+	sils, err := api.silences.Query(silence.QIDs(sid))
+	if err != nil || len(sils) == 0 {
+		http.Error(w, fmt.Sprint("Error getting silence: ", err), http.StatusNotFound)
+		return
+	}
+	sil, err := silenceFromProto(sils[0])
+	if err != nil {
 		api.respondError(w, apiError{
-			typ: errorBadData,
-			err: errors.New("start time must not be equal to end time"),
+			typ: errorInternal,
+			err: err,
 		}, nil)
 		return
 	}
 
-	if sil.EndsAt.Before(time.Now()) {
+	api.respond(w, sil)
+}
+
+func (api *API) getSilenceHistory(w http.ResponseWriter, r *http.Request) {
+	sid := route.Param(r.Context(), "sid")
+
+	sils, err := api.silences.Query(silence.QIDs(sid))
+	if err != nil || len(sils) == 0 {
+		http.Error(w, fmt.Sprint("Error getting silence: ", err), http.StatusNotFound)
+		return
+	}
+	history, err := silenceHistoryFromProto(sils[0])
+	if err != nil {
 		api.respondError(w, apiError{
-			typ: errorBadData,
-			err: errors.New("end time can't be in the past"),
+			typ: errorInternal,
+			err: err,
 		}, nil)
 		return
 	}
 
-	psil, err := silenceToProto(&sil)
-	if err != nil {
+	api.respond(w, history)
+}
+
+// silenceHistoryFromProto returns the edits of a silence. The state after
+// each edit is the state before the next one or the current silence.
+func silenceHistoryFromProto(s *silencepb.Silence) ([]*SilenceEdit, error) {
+	edits := make([]*SilenceEdit, 0, len(s.History))
+
+	for i, e := range s.History {
+		before, err := silenceFromProto(e.Previous)
+		if err != nil {
+			return nil, err
+		}
+		next := s
+		if i+1 < len(s.History) {
+			next = s.History[i+1].Previous
+		}
+		after, err := silenceFromProto(next)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, &SilenceEdit{
+			Timestamp: e.Timestamp,
+			Editor:    e.Editor,
+			Before:    before,
+			After:     after,
+		})
+	}
+	return edits, nil
+}
+
+func (api *API) delSilence(w http.ResponseWriter, r *http.Request) {
+	sid := route.Param(r.Context(), "sid")
+
+	if err := api.authorize(r, sid); err != nil {
+		api.respondError(w, apiError{
+			typ: errorForbidden,
+			err: err,
+		}, nil)
+		return
+	}
+	if err := api.silences.Expire(sid, api.user(r)); err != nil {
 		api.respondError(w, apiError{
 			typ: errorBadData,
 			err: err,
 		}, nil)
 		return
 	}
+	api.respond(w, nil)
+}
 
-	sid, err := api.silences.Set(psil)
-	if err != nil {
+// purgeSilence removes an expired silence entirely. Regular deletion only
+// expires silences, so they remain auditable.
+func (api *API) purgeSilence(w http.ResponseWriter, r *http.Request) {
+	sid := route.Param(r.Context(), "sid")
+
+	if err := api.authorizeAdmin(r); err != nil {
+		api.respondError(w, apiError{
+			typ: errorForbidden,
+			err: err,
+		}, nil)
+		return
+	}
+	if err := api.silences.Delete(sid); err != nil {
+		if err == silence.ErrNotFound {
+			http.Error(w, fmt.Sprint("Error getting silence: ", err), http.StatusNotFound)
+			return
+		}
 		api.respondError(w, apiError{
 			typ: errorBadData,
 			err: err,
 		}, nil)
 		return
 	}
-
-	api.respond(w, struct {
-		SilenceID string `json:"silenceId"`
-	}{
-		SilenceID: sid,
-	})
+	api.respond(w, nil)
 }
 
-func (api *API) getSilence(w http.ResponseWriter, r *http.Request) {
+// extendSilence moves the end of a silence by the received duration, e.g. "+2h".
+func (api *API) extendSilence(w http.ResponseWriter, r *http.Request) {
 	sid := route.Param(r.Context(), "sid")
 
+	var ext struct {
+		Duration string `json:"duration"`
+		Editor   string `json:"editor"`
+	}
+	if err := api.receive(r, &ext); err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	d, err := model.ParseDuration(strings.TrimPrefix(ext.Duration, "+"))
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: fmt.Errorf("invalid duration %q: %s", ext.Duration, err),
+		}, nil)
+		return
+	}
+
+	if err := api.authorize(r, sid); err != nil {
+		api.respondError(w, apiError{
+			typ: errorForbidden,
+			err: err,
+		}, nil)
+		return
+	}
+	if err := api.silences.Extend(sid, time.Duration(d), api.editor(r, ext.Editor)); err != nil {
+		if err == silence.ErrNotFound {
+			http.Error(w, fmt.Sprint("Error getting silence: ", err), http.StatusNotFound)
+			return
+		}
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
 	sils, err := api.silences.Query(silence.QIDs(sid))
 	if err != nil || len(sils) == 0 {
-		http.Error(w, fmt.Sprint("Error getting silence: ", err), http.StatusNotFound)
+		api.respondError(w, apiError{
+			typ: errorInternal,
+			err: fmt.Errorf("getting extended silence: %v", err),
+		}, nil)
 		return
 	}
 	sil, err := silenceFromProto(sils[0])
@@ -573,19 +1877,159 @@ func (api *API) getSilence(w http.ResponseWriter, r *http.Request) {
 	api.respond(w, sil)
 }
 
-func (api *API) delSilence(w http.ResponseWriter, r *http.Request) {
-	sid := route.Param(r.Context(), "sid")
+// listNotifications returns the notifications sent by this Alertmanager,
+// optionally only those of a receiver or including an alert.
+func (api *API) listNotifications(w http.ResponseWriter, r *http.Request) {
+	var (
+		receiver    = r.FormValue("receiver")
+		fingerprint = r.FormValue("fingerprint")
+	)
+	if fingerprint != "" {
+		fp, err := model.ParseFingerprint(fingerprint)
+		if err != nil {
+			api.respondError(w, apiError{
+				typ: errorBadData,
+				err: fmt.Errorf("invalid fingerprint %q: %s", fingerprint, err),
+			}, nil)
+			return
+		}
+		fingerprint = fp.String()
+	}
 
-	if err := api.silences.Expire(sid); err != nil {
+	api.mtx.RLock()
+	h := api.history
+	api.mtx.RUnlock()
+	if h == nil {
+		api.respond(w, []*notify.Notification{})
+		return
+	}
+	api.respond(w, h.Query(receiver, fingerprint))
+}
+
+func (api *API) getDeadLetters() *notify.DeadLetters {
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+
+	return api.deadLetters
+}
+
+func (api *API) listDeadLetters(w http.ResponseWriter, r *http.Request) {
+	d := api.getDeadLetters()
+	if d == nil {
+		api.respond(w, []*notify.DeadLetter{})
+		return
+	}
+	api.respond(w, d.List())
+}
+
+// replayDeadLetter sends a failed notification again. It is removed from
+// the dead letters if it succeeds.
+func (api *API) replayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id := route.Param(r.Context(), "id")
+
+	d := api.getDeadLetters()
+	if d == nil {
+		http.Error(w, fmt.Sprint("Error getting dead letter: ", notify.ErrDeadLetterNotFound), http.StatusNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), notify.MinTimeout)
+	defer cancel()
+	if err := d.Replay(ctx, id); err != nil {
+		if err == notify.ErrDeadLetterNotFound {
+			http.Error(w, fmt.Sprint("Error getting dead letter: ", err), http.StatusNotFound)
+			return
+		}
 		api.respondError(w, apiError{
-			typ: errorBadData,
-			err: err,
+			typ: errorInternal,
+			err: fmt.Errorf("replaying notification: %s", err),
 		}, nil)
 		return
 	}
 	api.respond(w, nil)
 }
 
+func (api *API) delDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id := route.Param(r.Context(), "id")
+
+	d := api.getDeadLetters()
+	if d == nil {
+		http.Error(w, fmt.Sprint("Error getting dead letter: ", notify.ErrDeadLetterNotFound), http.StatusNotFound)
+		return
+	}
+	if err := d.Delete(id); err != nil {
+		http.Error(w, fmt.Sprint("Error getting dead letter: ", err), http.StatusNotFound)
+		return
+	}
+	api.respond(w, nil)
+}
+
+// user returns the authenticated user of the request, if ownership is enforced.
+func (api *API) user(r *http.Request) string {
+	if !api.enforceOwnership {
+		return ""
+	}
+	if user, ok := web.UserFromContext(r.Context()); ok {
+		return user
+	}
+	if api.userHeader == "" {
+		return ""
+	}
+	return r.Header.Get(api.userHeader)
+}
+
+// editor returns the editor recorded for a modification of silences. It is
+// the authenticated user if known, as the one given in the request can't be
+// trusted then.
+func (api *API) editor(r *http.Request, given string) string {
+	if user := api.user(r); user != "" {
+		return user
+	}
+	return given
+}
+
+// authorize returns an error if ownership is enforced and the requesting
+// user may not modify the silence with the given ID. An empty ID refers to
+// a new silence, which any authenticated user may create.
+func (api *API) authorize(r *http.Request, sid string) error {
+	if !api.enforceOwnership {
+		return nil
+	}
+	user := api.user(r)
+	if user == "" {
+		return errors.New("request is not authenticated")
+	}
+	if sid == "" || api.admins[user] {
+		return nil
+	}
+	sils, err := api.silences.Query(silence.QIDs(sid))
+	if err != nil || len(sils) == 0 {
+		// Let the silences report unknown IDs.
+		return nil
+	}
+	if owner := sils[0].Owner; owner != "" && owner != user {
+		return fmt.Errorf("silence %s is owned by %s", sid, owner)
+	}
+	return nil
+}
+
+// authorizeAdmin returns an error unless ownership is enforced and the
+// requesting user is a silence admin. Without enforced ownership there are
+// no admins, so nobody is allowed.
+func (api *API) authorizeAdmin(r *http.Request) error {
+	if !api.enforceOwnership {
+		return errors.New("silence ownership is not enforced")
+	}
+	user := api.user(r)
+	if user == "" {
+		return errors.New("request is not authenticated")
+	}
+	if !api.admins[user] {
+		return fmt.Errorf("user %s is not a silence admin", user)
+	}
+	return nil
+}
+
 func (api *API) listSilences(w http.ResponseWriter, r *http.Request) {
 	psils, err := api.silences.Query()
 	if err != nil {
@@ -608,6 +2052,43 @@ func (api *API) listSilences(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	states, err := parseSilenceStates(r.Form["state"])
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	less, err := parseSilenceSort(r.FormValue("sort"))
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	limit, err := parseNonNegativeInt(r.FormValue("limit"), "limit")
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+	offset, err := parseNonNegativeInt(r.FormValue("offset"), "offset")
+	if err != nil {
+		api.respondError(w, apiError{
+			typ: errorBadData,
+			err: err,
+		}, nil)
+		return
+	}
+
+	createdBy := r.FormValue("createdBy")
+
 	sils := []*types.Silence{}
 	for _, ps := range psils {
 		s, err := silenceFromProto(ps)
@@ -622,9 +2103,23 @@ func (api *API) listSilences(w http.ResponseWriter, r *http.Request) {
 		if !silenceMatchesFilterLabels(s, matchers) {
 			continue
 		}
+		if len(states) > 0 && !states[s.Status.State] {
+			continue
+		}
+		if createdBy != "" && s.CreatedBy != createdBy {
+			continue
+		}
 		sils = append(sils, s)
 	}
 
+	if less != nil {
+		sort.SliceStable(sils, func(i, j int) bool {
+			return less(sils[i], sils[j])
+		})
+		api.respond(w, paginateSilences(sils, offset, limit))
+		return
+	}
+
 	var active, pending, expired []*types.Silence
 
 	for _, s := range sils {
@@ -655,7 +2150,74 @@ func (api *API) listSilences(w http.ResponseWriter, r *http.Request) {
 	silences = append(silences, pending...)
 	silences = append(silences, expired...)
 
-	api.respond(w, silences)
+	api.respond(w, paginateSilences(silences, offset, limit))
+}
+
+// parseSilenceSort returns the ordering of silences for the given sort key.
+// Keys prefixed with "-" sort in descending order. If no key is given, nil
+// is returned and silences are listed in the default order.
+func parseSilenceSort(key string) (func(a, b *types.Silence) bool, error) {
+	if key == "" {
+		return nil, nil
+	}
+	desc := strings.HasPrefix(key, "-")
+
+	var ts func(*types.Silence) time.Time
+	switch strings.TrimPrefix(key, "-") {
+	case "startsAt":
+		ts = func(s *types.Silence) time.Time { return s.StartsAt }
+	case "endsAt":
+		ts = func(s *types.Silence) time.Time { return s.EndsAt }
+	case "updatedAt":
+		ts = func(s *types.Silence) time.Time { return s.UpdatedAt }
+	case "createdAt":
+		ts = func(s *types.Silence) time.Time { return s.CreatedAt }
+	default:
+		return nil, fmt.Errorf("unknown sort key %q", key)
+	}
+	if desc {
+		return func(a, b *types.Silence) bool { return ts(a).After(ts(b)) }, nil
+	}
+	return func(a, b *types.Silence) bool { return ts(a).Before(ts(b)) }, nil
+}
+
+func parseNonNegativeInt(s, name string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, s)
+	}
+	return v, nil
+}
+
+// paginateSilences returns the page of silences starting at offset. A limit
+// of zero means that all remaining silences are returned.
+func paginateSilences(sils []*types.Silence, offset, limit int) []*types.Silence {
+	if offset >= len(sils) {
+		return []*types.Silence{}
+	}
+	sils = sils[offset:]
+	if limit > 0 && limit < len(sils) {
+		sils = sils[:limit]
+	}
+	return sils
+}
+
+// parseSilenceStates parses the given silence state names. An empty result
+// means that silences should not be filtered by state.
+func parseSilenceStates(names []string) (map[types.SilenceState]bool, error) {
+	states := map[types.SilenceState]bool{}
+	for _, name := range names {
+		switch st := types.SilenceState(name); st {
+		case types.SilenceStateActive, types.SilenceStatePending, types.SilenceStateExpired:
+			states[st] = true
+		default:
+			return nil, fmt.Errorf("unknown silence state %q", name)
+		}
+	}
+	return states, nil
 }
 
 func silenceMatchesFilterLabels(s *types.Silence, matchers []*labels.Matcher) bool {
@@ -699,6 +2261,7 @@ func silenceToProto(s *types.Silence) (*silencepb.Silence, error) {
 		UpdatedAt: s.UpdatedAt,
 		Comment:   s.Comment,
 		CreatedBy: s.CreatedBy,
+		TicketUrl: s.TicketURL,
 	}
 	for _, m := range s.Matchers {
 		matcher := &silencepb.Matcher{
@@ -706,25 +2269,54 @@ func silenceToProto(s *types.Silence) (*silencepb.Silence, error) {
 			Pattern: m.Value,
 			Type:    silencepb.Matcher_EQUAL,
 		}
-		if m.IsRegex {
+		switch {
+		case m.IsRegex && m.IsNegative:
+			matcher.Type = silencepb.Matcher_NOT_REGEXP
+		case m.IsRegex:
 			matcher.Type = silencepb.Matcher_REGEXP
+		case m.IsNegative:
+			matcher.Type = silencepb.Matcher_NOT_EQUAL
 		}
 		sil.Matchers = append(sil.Matchers, matcher)
 	}
+	if s.Recurrence != nil {
+		d, err := model.ParseDuration(s.Recurrence.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence duration: %s", err)
+		}
+		sil.Recurrence = &silencepb.Recurrence{
+			Schedule: s.Recurrence.Schedule,
+			Duration: time.Duration(d),
+			Timezone: s.Recurrence.Timezone,
+		}
+	}
 	return sil, nil
 }
 
+// silenceCreatedAt returns the time the silence was created, which is the
+// update time of the version before the first edit. Silences without edits
+// were last updated when they were created.
+func silenceCreatedAt(s *silencepb.Silence) time.Time {
+	if len(s.History) > 0 && s.History[0].Previous != nil {
+		return s.History[0].Previous.UpdatedAt
+	}
+	return s.UpdatedAt
+}
+
 func silenceFromProto(s *silencepb.Silence) (*types.Silence, error) {
 	sil := &types.Silence{
 		ID:        s.Id,
 		StartsAt:  s.StartsAt,
 		EndsAt:    s.EndsAt,
 		UpdatedAt: s.UpdatedAt,
+		CreatedAt: silenceCreatedAt(s),
 		Status: types.SilenceStatus{
-			State: types.CalcSilenceState(s.StartsAt, s.EndsAt),
+			State: silence.State(s, time.Now()),
 		},
 		Comment:   s.Comment,
 		CreatedBy: s.CreatedBy,
+		TicketURL: s.TicketUrl,
+		Owner:     s.Owner,
 	}
 	for _, m := range s.Matchers {
 		matcher := &types.Matcher{
@@ -735,11 +2327,23 @@ func silenceFromProto(s *silencepb.Silence) (*types.Silence, error) {
 		case silencepb.Matcher_EQUAL:
 		case silencepb.Matcher_REGEXP:
 			matcher.IsRegex = true
+		case silencepb.Matcher_NOT_EQUAL:
+			matcher.IsNegative = true
+		case silencepb.Matcher_NOT_REGEXP:
+			matcher.IsRegex = true
+			matcher.IsNegative = true
 		default:
 			return nil, fmt.Errorf("unknown matcher type")
 		}
 		sil.Matchers = append(sil.Matchers, matcher)
 	}
+	if s.Recurrence != nil {
+		sil.Recurrence = &types.SilenceRecurrence{
+			Schedule: s.Recurrence.Schedule,
+			Duration: model.Duration(s.Recurrence.Duration).String(),
+			Timezone: s.Recurrence.Timezone,
+		}
+	}
 
 	return sil, nil
 }
@@ -784,6 +2388,8 @@ func (api *API) respondError(w http.ResponseWriter, apiErr apiError, data interf
 		w.WriteHeader(http.StatusBadRequest)
 	case errorInternal:
 		w.WriteHeader(http.StatusInternalServerError)
+	case errorForbidden:
+		w.WriteHeader(http.StatusForbidden)
 	default:
 		panic(fmt.Sprintf("unknown error type %q", apiErr.Error()))
 	}
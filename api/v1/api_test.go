@@ -14,22 +14,35 @@
 package v1
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"regexp"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/log"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/inhibit"
+	"github.com/prometheus/alertmanager/pkg/web"
 	"github.com/prometheus/alertmanager/provider"
+	"github.com/prometheus/alertmanager/provider/mem"
+	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/types"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/route"
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/stretchr/testify/require"
 )
@@ -517,6 +530,213 @@ func TestSilenceFiltering(t *testing.T) {
 	}
 }
 
+func TestListSilences(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	for _, name := range []string{"active", "expired"} {
+		id, err := silences.Set(&silencepb.Silence{
+			Matchers: []*silencepb.Matcher{{Name: "name", Pattern: name}},
+			StartsAt: now,
+			EndsAt:   now.Add(time.Hour),
+		}, "")
+		require.NoError(t, err)
+		if name == "expired" {
+			require.NoError(t, silences.Expire(id, ""))
+		}
+	}
+
+	for i, tc := range []struct {
+		states []string
+		code   int
+		names  []string
+	}{
+		{
+			nil,
+			200,
+			[]string{"active", "expired"},
+		},
+		{
+			[]string{"expired"},
+			200,
+			[]string{"expired"},
+		},
+		{
+			[]string{"active", "pending"},
+			200,
+			[]string{"active"},
+		},
+		{
+			[]string{"unknown"},
+			400,
+			nil,
+		},
+	} {
+		api := New(nil, silences, nil, nil, nil)
+
+		r, err := http.NewRequest("GET", "/api/v1/silences", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		q := r.URL.Query()
+		for _, st := range tc.states {
+			q.Add("state", st)
+		}
+		r.URL.RawQuery = q.Encode()
+		w := httptest.NewRecorder()
+
+		api.listSilences(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+
+		require.Equal(t, tc.code, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+		if w.Code != 200 {
+			continue
+		}
+
+		var res struct {
+			Data []*types.Silence `json:"data"`
+		}
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		names := []string{}
+		for _, s := range res.Data {
+			names = append(names, s.Matchers[0].Value)
+		}
+		require.Equal(t, tc.names, names, fmt.Sprintf("test case: %d, silences are not equal", i))
+	}
+}
+
+func TestListSilencesPagination(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	for i, name := range []string{"a", "b", "c", "d"} {
+		_, err := silences.Set(&silencepb.Silence{
+			Matchers:  []*silencepb.Matcher{{Name: "name", Pattern: name}},
+			StartsAt:  now,
+			EndsAt:    now.Add(time.Duration(4-i) * time.Hour),
+			CreatedBy: fmt.Sprintf("user%d", i%2),
+		}, "")
+		require.NoError(t, err)
+	}
+
+	for i, tc := range []struct {
+		params map[string]string
+		code   int
+		names  []string
+	}{
+		{
+			nil,
+			200,
+			[]string{"d", "c", "b", "a"},
+		},
+		{
+			map[string]string{"sort": "-endsAt"},
+			200,
+			[]string{"a", "b", "c", "d"},
+		},
+		{
+			map[string]string{"sort": "endsAt", "limit": "2"},
+			200,
+			[]string{"d", "c"},
+		},
+		{
+			map[string]string{"limit": "2", "offset": "1"},
+			200,
+			[]string{"c", "b"},
+		},
+		{
+			map[string]string{"offset": "10"},
+			200,
+			[]string{},
+		},
+		{
+			map[string]string{"createdBy": "user1"},
+			200,
+			[]string{"d", "b"},
+		},
+		{
+			map[string]string{"sort": "name"},
+			400,
+			nil,
+		},
+		{
+			map[string]string{"limit": "-1"},
+			400,
+			nil,
+		},
+	} {
+		api := New(nil, silences, nil, nil, nil)
+
+		r, err := http.NewRequest("GET", "/api/v1/silences", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		q := r.URL.Query()
+		for k, v := range tc.params {
+			q.Set(k, v)
+		}
+		r.URL.RawQuery = q.Encode()
+		w := httptest.NewRecorder()
+
+		api.listSilences(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+
+		require.Equal(t, tc.code, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+		if w.Code != 200 {
+			continue
+		}
+
+		var res struct {
+			Data []*types.Silence `json:"data"`
+		}
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		names := []string{}
+		for _, s := range res.Data {
+			names = append(names, s.Matchers[0].Value)
+		}
+		require.Equal(t, tc.names, names, fmt.Sprintf("test case: %d, silences are not equal", i))
+	}
+}
+
+func TestSilenceSortCreatedAt(t *testing.T) {
+	now := time.Now()
+	created := &silencepb.Silence{Id: "created", UpdatedAt: now}
+	edited := &silencepb.Silence{
+		Id:        "edited",
+		UpdatedAt: now.Add(2 * time.Hour),
+		History: []*silencepb.Edit{
+			{Timestamp: now.Add(time.Hour), Previous: &silencepb.Silence{UpdatedAt: now.Add(-time.Hour)}},
+			{Timestamp: now.Add(2 * time.Hour), Previous: &silencepb.Silence{UpdatedAt: now.Add(time.Hour)}},
+		},
+	}
+	require.Equal(t, now, silenceCreatedAt(created))
+	require.Equal(t, now.Add(-time.Hour), silenceCreatedAt(edited))
+
+	var sils []*types.Silence
+	for _, ps := range []*silencepb.Silence{created, edited} {
+		s, err := silenceFromProto(ps)
+		require.NoError(t, err)
+		sils = append(sils, s)
+	}
+
+	// The edited silence was updated last but created first.
+	less, err := parseSilenceSort("createdAt")
+	require.NoError(t, err)
+	require.True(t, less(sils[1], sils[0]))
+	less, err = parseSilenceSort("-createdAt")
+	require.NoError(t, err)
+	require.True(t, less(sils[0], sils[1]))
+	less, err = parseSilenceSort("updatedAt")
+	require.NoError(t, err)
+	require.True(t, less(sils[0], sils[1]))
+}
+
 func TestReceiversMatchFilter(t *testing.T) {
 	receivers := []string{"pagerduty", "slack", "hipchat"}
 
@@ -572,3 +792,1239 @@ func newMatcher(labelSet model.LabelSet) types.Matchers {
 	}
 	return matchers
 }
+
+func TestSilenceHistoryFromProto(t *testing.T) {
+	now := time.Now()
+	m := []*silencepb.Matcher{{Name: "a", Pattern: "b"}}
+
+	v1 := &silencepb.Silence{Id: "1", Matchers: m, StartsAt: now, EndsAt: now.Add(time.Hour)}
+	v2 := &silencepb.Silence{Id: "1", Matchers: m, StartsAt: now, EndsAt: now.Add(2 * time.Hour)}
+	v3 := &silencepb.Silence{
+		Id:       "1",
+		Matchers: m,
+		StartsAt: now,
+		EndsAt:   now.Add(3 * time.Hour),
+		History: []*silencepb.Edit{
+			{Timestamp: now.Add(time.Minute), Editor: "alice", Previous: v1},
+			{Timestamp: now.Add(2 * time.Minute), Editor: "bob", Previous: v2},
+		},
+	}
+
+	edits, err := silenceHistoryFromProto(v3)
+	require.NoError(t, err)
+	require.Len(t, edits, 2)
+
+	require.Equal(t, "alice", edits[0].Editor)
+	require.Equal(t, v1.EndsAt, edits[0].Before.EndsAt)
+	require.Equal(t, v2.EndsAt, edits[0].After.EndsAt)
+
+	require.Equal(t, "bob", edits[1].Editor)
+	require.Equal(t, v2.EndsAt, edits[1].Before.EndsAt)
+	require.Equal(t, v3.EndsAt, edits[1].After.EndsAt)
+}
+
+func TestSilenceFromProtoState(t *testing.T) {
+	now := time.Now()
+	m := []*silencepb.Matcher{{Name: "a", Pattern: "b"}}
+
+	sil := &silencepb.Silence{Id: "1", Matchers: m, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour)}
+	s, err := silenceFromProto(sil)
+	require.NoError(t, err)
+	require.Equal(t, types.SilenceStateActive, s.Status.State)
+
+	// Outside of its recurring windows an active silence is pending.
+	sil.Recurrence = &silencepb.Recurrence{
+		Schedule: fmt.Sprintf("%d * * * *", (now.UTC().Minute()+30)%60),
+		Duration: time.Minute,
+	}
+	s, err = silenceFromProto(sil)
+	require.NoError(t, err)
+	require.Equal(t, types.SilenceStatePending, s.Status.State)
+
+	sil.EndsAt = now.Add(-time.Second)
+	s, err = silenceFromProto(sil)
+	require.NoError(t, err)
+	require.Equal(t, types.SilenceStateExpired, s.Status.State)
+}
+
+func TestPreviewSilence(t *testing.T) {
+	now := time.Now()
+	alerts := []*types.Alert{
+		&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "alert1", "env": "prod"},
+				StartsAt: now.Add(-time.Minute),
+			},
+		},
+		&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "alert2", "env": "staging"},
+				StartsAt: now.Add(-time.Minute),
+			},
+		},
+		&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "alert3", "env": "prod"},
+				StartsAt: now.Add(-2 * time.Minute),
+				EndsAt:   now.Add(-time.Minute),
+			},
+		},
+	}
+
+	for i, tc := range []struct {
+		body   string
+		code   int
+		anames []string
+	}{
+		{
+			`{"matchers":[{"name":"env","value":"prod"}]}`,
+			200,
+			[]string{"alert1"},
+		},
+		{
+			`{"matchers":[{"name":"env","value":"prod","isNegative":true}]}`,
+			200,
+			[]string{"alert2"},
+		},
+		{
+			`{"matchers":[{"name":"alertname","value":"alert.*","isRegex":true}]}`,
+			200,
+			[]string{"alert1", "alert2"},
+		},
+		{
+			`{"matchers":[]}`,
+			400,
+			nil,
+		},
+		{
+			`{"matchers":[{"name":"env","value":"((","isRegex":true}]}`,
+			400,
+			nil,
+		},
+	} {
+		alertsProvider := newFakeAlerts(alerts, false)
+		api := New(alertsProvider, nil, newGetAlertStatus(alertsProvider), nil, nil)
+		api.route = dispatch.NewRoute(&config.Route{Receiver: "def-receiver"}, nil)
+
+		r, err := http.NewRequest("POST", "/api/v1/silences/preview", bytes.NewBufferString(tc.body))
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		w := httptest.NewRecorder()
+
+		api.previewSilence(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+
+		require.Equal(t, tc.code, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+		if w.Code != 200 {
+			continue
+		}
+
+		var res struct {
+			Data []*Alert `json:"data"`
+		}
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		anames := []string{}
+		for _, a := range res.Data {
+			anames = append(anames, string(a.Labels["alertname"]))
+		}
+		sort.Strings(anames)
+		require.Equal(t, tc.anames, anames, fmt.Sprintf("test case: %d, alert names are not equal", i))
+	}
+}
+
+func TestPreviewSilenceUpdate(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	sid, err := silences.Set(&silencepb.Silence{
+		Matchers: []*silencepb.Matcher{{Name: "env", Pattern: "prod"}},
+		StartsAt: now.Add(-time.Minute),
+		EndsAt:   now.Add(time.Hour),
+	}, "")
+	require.NoError(t, err)
+
+	alerts := []*types.Alert{
+		&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "alert1", "env": "prod"},
+				StartsAt: now.Add(-time.Minute),
+			},
+		},
+		&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "alert2", "env": "prod", "team": "db"},
+				StartsAt: now.Add(-time.Minute),
+			},
+		},
+		&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "alert3", "env": "staging", "team": "db"},
+				StartsAt: now.Add(-time.Minute),
+			},
+		},
+		&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "alert4", "env": "dev"},
+				StartsAt: now.Add(-time.Minute),
+			},
+		},
+	}
+	alertsProvider := newFakeAlerts(alerts, false)
+	api := New(alertsProvider, silences, newGetAlertStatus(alertsProvider), nil, nil)
+	api.route = dispatch.NewRoute(&config.Route{Receiver: "def-receiver"}, nil)
+
+	newSilence := func(matchers string) string {
+		return fmt.Sprintf(`{"matchers": %s, "startsAt": %q, "endsAt": %q, "createdBy": "x", "comment": "y"}`,
+			matchers, now.Add(-time.Minute).Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339))
+	}
+
+	for i, tc := range []struct {
+		sid                       string
+		body                      string
+		code                      int
+		added, removed, unchanged []string
+	}{
+		{
+			sid:       sid,
+			body:      newSilence(`[{"name": "team", "value": "db"}]`),
+			code:      200,
+			added:     []string{"alert3"},
+			removed:   []string{"alert1"},
+			unchanged: []string{"alert2"},
+		},
+		{
+			sid:       sid,
+			body:      newSilence(`[{"name": "env", "value": "prod|dev", "isRegex": true}]`),
+			code:      200,
+			added:     []string{"alert4"},
+			removed:   []string{},
+			unchanged: []string{"alert1", "alert2"},
+		},
+		{
+			sid:  sid,
+			body: newSilence(`[]`),
+			code: 400,
+		},
+		{
+			sid:  "unknown",
+			body: newSilence(`[{"name": "env", "value": "prod"}]`),
+			code: 404,
+		},
+	} {
+		r, err := http.NewRequest("POST", "/api/v1/silence/"+tc.sid+"/preview", bytes.NewBufferString(tc.body))
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		r = r.WithContext(route.WithParam(r.Context(), "sid", tc.sid))
+		w := httptest.NewRecorder()
+
+		api.previewSilenceUpdate(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+
+		require.Equal(t, tc.code, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+		if w.Code != 200 {
+			continue
+		}
+
+		var res struct {
+			Data silenceUpdatePreview `json:"data"`
+		}
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		anames := func(as []*Alert) []string {
+			names := []string{}
+			for _, a := range as {
+				names = append(names, string(a.Labels["alertname"]))
+			}
+			sort.Strings(names)
+			return names
+		}
+		require.Equal(t, tc.added, anames(res.Data.Added), fmt.Sprintf("test case: %d, added alerts are not equal", i))
+		require.Equal(t, tc.removed, anames(res.Data.Removed), fmt.Sprintf("test case: %d, removed alerts are not equal", i))
+		require.Equal(t, tc.unchanged, anames(res.Data.Unchanged), fmt.Sprintf("test case: %d, unchanged alerts are not equal", i))
+	}
+}
+
+func TestAffectingSilences(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	set := func(name, value string, start time.Time) string {
+		id, err := silences.Set(&silencepb.Silence{
+			Matchers: []*silencepb.Matcher{{Name: name, Pattern: value}},
+			StartsAt: start,
+			EndsAt:   start.Add(time.Hour),
+		}, "")
+		require.NoError(t, err)
+		return id
+	}
+	active := set("env", "prod", now)
+	pending := set("alertname", "alert1", now.Add(time.Hour))
+	set("env", "staging", now)
+	expired := set("env", "prod", now)
+	require.NoError(t, silences.Expire(expired, ""))
+
+	api := New(nil, silences, nil, nil, nil)
+
+	for i, tc := range []struct {
+		body string
+		code int
+		ids  []string
+	}{
+		{`{"alertname":"alert1","env":"prod"}`, 200, []string{active, pending}},
+		{`{"alertname":"alert2","env":"dev"}`, 200, []string{}},
+		{`{"alertname":"alert1","0env":"prod"}`, 400, nil},
+	} {
+		r, err := http.NewRequest("POST", "/api/v1/silences/affecting", bytes.NewBufferString(tc.body))
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		w := httptest.NewRecorder()
+
+		api.affectingSilences(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+
+		require.Equal(t, tc.code, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+		if w.Code != 200 {
+			continue
+		}
+
+		var res struct {
+			Data []*types.Silence `json:"data"`
+		}
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		ids := []string{}
+		for _, s := range res.Data {
+			ids = append(ids, s.ID)
+		}
+		sort.Strings(tc.ids)
+		require.Equal(t, tc.ids, ids, fmt.Sprintf("test case: %d", i))
+	}
+}
+
+func TestDelSilences(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	for _, env := range []string{"prod", "prod", "dev"} {
+		_, err := silences.Set(&silencepb.Silence{
+			Matchers: []*silencepb.Matcher{{Name: "env", Pattern: env}},
+			StartsAt: now,
+			EndsAt:   now.Add(time.Hour),
+		}, "")
+		require.NoError(t, err)
+	}
+	api := New(nil, silences, nil, nil, nil)
+
+	for i, tc := range []struct {
+		filter  string
+		code    int
+		expired int
+	}{
+		{"", 400, 0},
+		{`{env="prod"}`, 200, 2},
+		{`{env="prod"}`, 200, 0},
+	} {
+		r, err := http.NewRequest("DELETE", "/api/v1/silences", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		q := r.URL.Query()
+		if tc.filter != "" {
+			q.Add("filter", tc.filter)
+		}
+		r.URL.RawQuery = q.Encode()
+		w := httptest.NewRecorder()
+
+		api.delSilences(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+
+		require.Equal(t, tc.code, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+		if w.Code != 200 {
+			continue
+		}
+
+		var res struct {
+			Data struct {
+				SilenceIDs []string `json:"silenceIds"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		require.Len(t, res.Data.SilenceIDs, tc.expired, fmt.Sprintf("test case: %d", i))
+	}
+
+	count, err := silences.CountState(types.SilenceStateActive)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestExtendSilence(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	sid, err := silences.Set(&silencepb.Silence{
+		Matchers: []*silencepb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+	}, "")
+	require.NoError(t, err)
+	api := New(nil, silences, nil, nil, nil)
+
+	for i, tc := range []struct {
+		sid    string
+		body   string
+		code   int
+		endsAt time.Time
+	}{
+		{sid, `{"duration": "+2h", "editor": "alice"}`, 200, now.Add(3 * time.Hour)},
+		{sid, `{"duration": "30m"}`, 200, now.Add(3*time.Hour + 30*time.Minute)},
+		{sid, `{"duration": "soon"}`, 400, time.Time{}},
+		{sid, `{"duration": "0s"}`, 400, time.Time{}},
+		{"unknown", `{"duration": "1h"}`, 404, time.Time{}},
+	} {
+		r, err := http.NewRequest("POST", "/api/v1/silence/"+tc.sid+"/extend", bytes.NewBufferString(tc.body))
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		r = r.WithContext(route.WithParam(r.Context(), "sid", tc.sid))
+		w := httptest.NewRecorder()
+
+		api.extendSilence(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+
+		require.Equal(t, tc.code, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+		if w.Code != 200 {
+			continue
+		}
+
+		var res struct {
+			Data *types.Silence `json:"data"`
+		}
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		require.Equal(t, sid, res.Data.ID)
+		require.True(t, tc.endsAt.Equal(res.Data.EndsAt), fmt.Sprintf("test case: %d, unexpected end %s", i, res.Data.EndsAt))
+	}
+
+	sils, err := silences.Query(silence.QIDs(sid))
+	require.NoError(t, err)
+	require.Len(t, sils[0].History, 2)
+	require.Equal(t, "alice", sils[0].History[0].Editor)
+}
+
+func TestSilenceOwnership(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	api := New(nil, silences, nil, nil, nil)
+	api.EnforceOwnership("X-User", []string{"admin"})
+
+	now := time.Now()
+	newSilence := func(v string) string {
+		return fmt.Sprintf(`{"matchers": [{"name": "a", "value": %q}], "startsAt": %q, "endsAt": %q, "createdBy": "x", "comment": "y"}`,
+			v, now.Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339))
+	}
+	request := func(method, path, body, user, sid string) *httptest.ResponseRecorder {
+		r, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		if user != "" {
+			r.Header.Set("X-User", user)
+		}
+		if sid != "" {
+			r = r.WithContext(route.WithParam(r.Context(), "sid", sid))
+		}
+		w := httptest.NewRecorder()
+
+		switch {
+		case method == "POST" && sid != "":
+			api.extendSilence(w, r)
+		case method == "POST":
+			api.setSilence(w, r)
+		case method == "DELETE":
+			api.delSilence(w, r)
+		}
+		return w
+	}
+
+	// Unauthenticated requests cannot create silences.
+	require.Equal(t, http.StatusForbidden, request("POST", "/api/v1/silences", newSilence("b"), "", "").Code)
+
+	w := request("POST", "/api/v1/silences", newSilence("b"), "alice", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Data struct {
+			SilenceID string `json:"silenceId"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	sid := res.Data.SilenceID
+
+	sils, err := silences.Query(silence.QIDs(sid))
+	require.NoError(t, err)
+	require.Equal(t, "alice", sils[0].Owner)
+
+	s, err := silenceFromProto(sils[0])
+	require.NoError(t, err)
+	require.Equal(t, "alice", s.Owner)
+
+	ext := `{"duration": "1h", "editor": "mallory"}`
+	require.Equal(t, http.StatusForbidden, request("POST", "/api/v1/silence/"+sid+"/extend", ext, "bob", sid).Code)
+	require.Equal(t, http.StatusForbidden, request("DELETE", "/api/v1/silence/"+sid, "", "bob", sid).Code)
+
+	require.Equal(t, http.StatusOK, request("POST", "/api/v1/silence/"+sid+"/extend", ext, "alice", sid).Code)
+	require.Equal(t, http.StatusOK, request("POST", "/api/v1/silence/"+sid+"/extend", ext, "admin", sid).Code)
+
+	// The owner remains unchanged when an admin modifies the silence.
+	sils, err = silences.Query(silence.QIDs(sid))
+	require.NoError(t, err)
+	require.Equal(t, "alice", sils[0].Owner)
+
+	// The authenticated users are recorded as editors instead of the one
+	// given in the request.
+	require.Len(t, sils[0].History, 2)
+	require.Equal(t, "alice", sils[0].History[0].Editor)
+	require.Equal(t, "admin", sils[0].History[1].Editor)
+
+	require.Equal(t, http.StatusOK, request("DELETE", "/api/v1/silence/"+sid, "", "alice", sid).Code)
+
+	sils, err = silences.Query(silence.QIDs(sid))
+	require.NoError(t, err)
+	require.Len(t, sils[0].History, 3)
+	require.Equal(t, "alice", sils[0].History[2].Editor)
+}
+
+func TestSilenceOwnershipWebAuth(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	api := New(nil, silences, nil, nil, nil)
+	api.EnforceOwnership("", []string{"admin"})
+
+	// Bcrypt hash of "secret".
+	hash := "$2a$04$8oCa.qESilQ5i9Oy8zINbuNSdwnFpUzWAKxJdv.P5VM86zEjzVCbu"
+	wc := &web.Config{BasicAuthUsers: map[string]string{"alice": hash, "bob": hash}}
+
+	now := time.Now()
+	body := fmt.Sprintf(`{"matchers": [{"name": "a", "value": "b"}], "startsAt": %q, "endsAt": %q, "createdBy": "x", "comment": "y"}`,
+		now.Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339))
+	request := func(h http.HandlerFunc, method, body, user, sid string) *httptest.ResponseRecorder {
+		r, err := http.NewRequest(method, "/", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		r.SetBasicAuth(user, "secret")
+		// The user header is ignored as the web server authenticates
+		// requests.
+		r.Header.Set("X-User", "alice")
+		if sid != "" {
+			r = r.WithContext(route.WithParam(r.Context(), "sid", sid))
+		}
+		w := httptest.NewRecorder()
+		wc.Handler(h).ServeHTTP(w, r)
+		return w
+	}
+
+	w := request(api.setSilence, "POST", body, "alice", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var res struct {
+		Data struct {
+			SilenceID string `json:"silenceId"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	sid := res.Data.SilenceID
+
+	sils, err := silences.Query(silence.QIDs(sid))
+	require.NoError(t, err)
+	require.Equal(t, "alice", sils[0].Owner)
+
+	require.Equal(t, http.StatusForbidden, request(api.delSilence, "DELETE", "", "bob", sid).Code)
+	require.Equal(t, http.StatusOK, request(api.delSilence, "DELETE", "", "alice", sid).Code)
+}
+
+func TestApplySilenceTemplate(t *testing.T) {
+	tmpl := &config.SilenceTemplate{
+		Name:     "deploy",
+		Match:    map[string]string{"job": "api", "env": "prod"},
+		MatchRE:  map[string]string{"instance": "web-.*"},
+		Duration: model.Duration(time.Hour),
+		Comment:  "Deployment",
+	}
+	now := time.Now()
+
+	sil := applySilenceTemplate(tmpl, &types.Silence{CreatedBy: "alice"}, now)
+	require.Equal(t, types.Matchers{
+		{Name: "env", Value: "prod"},
+		{Name: "instance", Value: "web-.*", IsRegex: true},
+		{Name: "job", Value: "api"},
+	}, sil.Matchers)
+	require.Equal(t, now, sil.StartsAt)
+	require.Equal(t, now.Add(time.Hour), sil.EndsAt)
+	require.Equal(t, "alice", sil.CreatedBy)
+	require.Equal(t, "Deployment", sil.Comment)
+
+	sil = applySilenceTemplate(tmpl, &types.Silence{
+		Matchers: types.Matchers{{Name: "env", Value: "staging"}},
+		StartsAt: now.Add(time.Hour),
+		EndsAt:   now.Add(4 * time.Hour),
+		Comment:  "Long deployment",
+	}, now)
+	require.Equal(t, types.Matchers{
+		{Name: "env", Value: "staging"},
+		{Name: "instance", Value: "web-.*", IsRegex: true},
+		{Name: "job", Value: "api"},
+	}, sil.Matchers)
+	require.Equal(t, now.Add(time.Hour), sil.StartsAt)
+	require.Equal(t, now.Add(4*time.Hour), sil.EndsAt)
+	require.Equal(t, "Long deployment", sil.Comment)
+}
+
+func TestPurgeSilence(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	sid, err := silences.Set(&silencepb.Silence{
+		Matchers: []*silencepb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+	}, "")
+	require.NoError(t, err)
+
+	api := New(nil, silences, nil, nil, nil)
+	api.EnforceOwnership("X-User", []string{"admin"})
+
+	purge := func(sid, user string) int {
+		r, err := http.NewRequest("DELETE", "/api/v1/silence/"+sid+"/purge", nil)
+		require.NoError(t, err)
+		r.Header.Set("X-User", user)
+		r = r.WithContext(route.WithParam(r.Context(), "sid", sid))
+		w := httptest.NewRecorder()
+
+		api.purgeSilence(w, r)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusForbidden, purge(sid, "alice"))
+	// Active silences must be expired first.
+	require.Equal(t, http.StatusBadRequest, purge(sid, "admin"))
+
+	require.NoError(t, silences.Expire(sid, ""))
+	require.Equal(t, http.StatusOK, purge(sid, "admin"))
+	require.Equal(t, http.StatusNotFound, purge(sid, "admin"))
+
+	sils, err := silences.Query(silence.QIDs(sid))
+	require.NoError(t, err)
+	require.Len(t, sils, 0)
+}
+
+func TestPurgeSilenceWithoutOwnership(t *testing.T) {
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	sid, err := silences.Set(&silencepb.Silence{
+		Matchers: []*silencepb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+	}, "")
+	require.NoError(t, err)
+	require.NoError(t, silences.Expire(sid, ""))
+
+	api := New(nil, silences, nil, nil, nil)
+
+	r, err := http.NewRequest("DELETE", "/api/v1/silence/"+sid+"/purge", nil)
+	require.NoError(t, err)
+	r = r.WithContext(route.WithParam(r.Context(), "sid", sid))
+	w := httptest.NewRecorder()
+
+	api.purgeSilence(w, r)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	// The silence is left untouched.
+	sils, err := silences.Query(silence.QIDs(sid))
+	require.NoError(t, err)
+	require.Len(t, sils, 1)
+}
+
+func TestExplainInhibitions(t *testing.T) {
+	api := New(nil, nil, nil, nil, nil)
+	api.SetInhibitor(inhibit.NewInhibitor(nil, nil, types.NewMarker(), nil))
+
+	for i, tc := range []struct {
+		body string
+		code int
+	}{
+		{`{"alertname":"alert1"}`, 200},
+		{`{"0alertname":"alert1"}`, 400},
+		{`{"alertname":`, 400},
+	} {
+		r, err := http.NewRequest("POST", "/api/v1/alerts/inhibitions", bytes.NewBufferString(tc.body))
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+
+		api.explainInhibitions(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+
+		require.Equal(t, tc.code, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+		if w.Code != 200 {
+			continue
+		}
+		var res struct {
+			Data []*Inhibition `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &res))
+		require.Len(t, res.Data, 0)
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+  group_by: [alertname]
+  routes:
+  - match:
+      service: database
+    receiver: team-Y
+    group_wait: 1m
+    continue: true
+  - match_re:
+      service: ^(foo|bar)$
+    receiver: team-Z
+receivers:
+- name: team-X
+- name: team-Y
+- name: team-Z
+`
+	cfg, err := config.Load(in)
+	require.NoError(t, err)
+
+	api := New(nil, nil, nil, nil, nil)
+	require.NoError(t, api.Update(cfg, time.Minute))
+
+	r, err := http.NewRequest("GET", "/api/v1/routes", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+
+	api.routes(w, r)
+	body, _ := ioutil.ReadAll(w.Result().Body)
+	require.Equal(t, http.StatusOK, w.Code, string(body))
+
+	var res struct {
+		Data struct {
+			Matchers []*types.Matcher `json:"matchers"`
+			Options  struct {
+				Receiver string `json:"receiver"`
+			} `json:"options"`
+			Routes []struct {
+				Matchers []*types.Matcher `json:"matchers"`
+				Continue bool             `json:"continue"`
+				Options  struct {
+					Receiver  string        `json:"receiver"`
+					GroupBy   []string      `json:"groupBy"`
+					GroupWait time.Duration `json:"groupWait"`
+				} `json:"options"`
+			} `json:"routes"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &res))
+
+	root := res.Data
+	require.Empty(t, root.Matchers)
+	require.Equal(t, "team-X", root.Options.Receiver)
+	require.Len(t, root.Routes, 2)
+
+	require.Equal(t, "team-Y", root.Routes[0].Options.Receiver)
+	require.Equal(t, []string{"alertname"}, root.Routes[0].Options.GroupBy)
+	require.Equal(t, time.Minute, root.Routes[0].Options.GroupWait)
+	require.True(t, root.Routes[0].Continue)
+	require.Equal(t, []*types.Matcher{{Name: "service", Value: "database"}}, root.Routes[0].Matchers)
+
+	require.Equal(t, "team-Z", root.Routes[1].Options.Receiver)
+	require.False(t, root.Routes[1].Continue)
+	require.Len(t, root.Routes[1].Matchers, 1)
+	require.True(t, root.Routes[1].Matchers[0].IsRegex)
+}
+
+func TestMatchRoutes(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+  group_by: [alertname]
+  routes:
+  - match:
+      service: database
+    receiver: team-Y
+    group_by: [alertname, cluster]
+    mute_time_intervals: [always, never]
+    continue: true
+  - match:
+      service: database
+    receiver: team-Z
+receivers:
+- name: team-X
+- name: team-Y
+- name: team-Z
+mute_time_intervals:
+- name: always
+  time_intervals:
+  - {}
+- name: never
+  time_intervals:
+  - months: ['february']
+    days_of_month: ['30']
+`
+	cfg, err := config.Load(in)
+	require.NoError(t, err)
+
+	api := New(nil, nil, nil, nil, nil)
+	require.NoError(t, api.Update(cfg, time.Minute))
+
+	type result struct {
+		Options struct {
+			Receiver string `json:"receiver"`
+		} `json:"options"`
+		GroupLabels             model.LabelSet `json:"groupLabels"`
+		ActiveMuteTimeIntervals []string       `json:"activeMuteTimeIntervals"`
+	}
+	newResult := func(receiver string, groupLabels model.LabelSet, muted ...string) result {
+		r := result{GroupLabels: groupLabels, ActiveMuteTimeIntervals: []string{}}
+		r.Options.Receiver = receiver
+		r.ActiveMuteTimeIntervals = append(r.ActiveMuteTimeIntervals, muted...)
+		return r
+	}
+
+	for i, tc := range []struct {
+		body string
+		code int
+		res  []result
+	}{
+		{
+			body: `{"alertname":"foo","service":"database","cluster":"eu"}`,
+			code: 200,
+			res: []result{
+				newResult("team-Y", model.LabelSet{"alertname": "foo", "cluster": "eu"}, "always"),
+				newResult("team-Z", model.LabelSet{"alertname": "foo"}),
+			},
+		},
+		{
+			body: `{"alertname":"foo","service":"web"}`,
+			code: 200,
+			res: []result{
+				newResult("team-X", model.LabelSet{"alertname": "foo"}),
+			},
+		},
+		{body: `{"0alertname":"foo"}`, code: 400},
+		{body: `{"alertname":`, code: 400},
+	} {
+		r, err := http.NewRequest("POST", "/api/v1/routes/match", bytes.NewBufferString(tc.body))
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+
+		api.matchRoutes(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+
+		require.Equal(t, tc.code, w.Code, fmt.Sprintf("test case: %d, response: %s", i, string(body)))
+		if w.Code != 200 {
+			continue
+		}
+		var res struct {
+			Data []result `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &res))
+		require.Equal(t, tc.res, res.Data, fmt.Sprintf("test case: %d", i))
+	}
+}
+
+func TestTestReceiver(t *testing.T) {
+	var alerts []*types.Alert
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var msg struct {
+			Alerts []*types.Alert `json:"alerts"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		alerts = msg.Alerts
+	}))
+	defer srv.Close()
+
+	cfg, err := config.Load(fmt.Sprintf(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+  webhook_configs:
+  - url: %[1]s/ok
+- name: team-Y
+  webhook_configs:
+  - url: %[1]s/ok
+  - url: %[1]s/fail
+`, srv.URL))
+	require.NoError(t, err)
+
+	tmpl, err := template.FromGlobs()
+	require.NoError(t, err)
+	tmpl.ExternalURL, _ = url.Parse("http://am")
+
+	api := New(nil, nil, nil, nil, nil)
+	require.NoError(t, api.Update(cfg, time.Minute))
+	api.SetTemplate(tmpl)
+
+	test := func(receiver string) (int, []byte) {
+		r, err := http.NewRequest("POST", "/api/v1/receivers/"+receiver+"/test", nil)
+		require.NoError(t, err)
+		r = r.WithContext(route.WithParam(r.Context(), "name", receiver))
+		w := httptest.NewRecorder()
+
+		api.testReceiver(w, r)
+		body, _ := ioutil.ReadAll(w.Result().Body)
+		return w.Code, body
+	}
+
+	var res struct {
+		Error string `json:"error"`
+		Data  []struct {
+			Integration string `json:"integration"`
+			Index       int    `json:"index"`
+			Error       string `json:"error"`
+		} `json:"data"`
+	}
+
+	code, body := test("team-X")
+	require.Equal(t, http.StatusOK, code, string(body))
+	require.NoError(t, json.Unmarshal(body, &res))
+	require.Len(t, res.Data, 1)
+	require.Equal(t, "webhook", res.Data[0].Integration)
+	require.Empty(t, res.Data[0].Error)
+	require.Len(t, alerts, 1)
+	require.Equal(t, model.LabelValue("TestAlert"), alerts[0].Labels["alertname"])
+	require.Equal(t, model.LabelValue("team-X"), alerts[0].Labels["receiver"])
+
+	code, body = test("team-Y")
+	require.Equal(t, http.StatusInternalServerError, code, string(body))
+	res.Data = nil
+	require.NoError(t, json.Unmarshal(body, &res))
+	require.Equal(t, "test notification failed for 1 of 2 integrations", res.Error)
+	require.Len(t, res.Data, 2)
+	require.Empty(t, res.Data[0].Error)
+	require.Equal(t, 1, res.Data[1].Index)
+	require.Contains(t, res.Data[1].Error, "unexpected status code 400")
+
+	code, _ = test("team-Z")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestStatusUI(t *testing.T) {
+	cfg, err := config.Load(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+`)
+	require.NoError(t, err)
+
+	api := New(nil, nil, nil, nil, nil)
+	require.NoError(t, api.Update(cfg, time.Minute))
+
+	status := func() (res struct {
+		Data struct {
+			UI *web.UIConfig `json:"ui"`
+		} `json:"data"`
+	}) {
+		r, err := http.NewRequest("GET", "/api/v1/status", nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+
+		api.status(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.NewDecoder(w.Result().Body).Decode(&res))
+		return res
+	}
+
+	require.Equal(t, &web.UIConfig{Links: []web.UILink{}}, status().Data.UI)
+
+	ui := &web.UIConfig{
+		OrganizationName: "Example",
+		LogoURL:          "https://example.com/logo.svg",
+		Links:            []web.UILink{{Name: "Runbooks", URL: "https://runbooks.example.com"}},
+	}
+	api.SetUIConfig(ui)
+	require.Equal(t, ui, status().Data.UI)
+}
+
+func TestStatusConfigReload(t *testing.T) {
+	cfg, err := config.Load(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+`)
+	require.NoError(t, err)
+
+	api := New(nil, nil, nil, nil, nil)
+	require.NoError(t, api.Update(cfg, time.Minute))
+
+	status := func() (res struct {
+		Data struct {
+			ConfigReload struct {
+				Success     bool      `json:"success"`
+				LastSuccess time.Time `json:"lastSuccess"`
+				Error       string    `json:"error"`
+			} `json:"configReload"`
+		} `json:"data"`
+	}) {
+		r, err := http.NewRequest("GET", "/api/v1/status", nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+
+		api.status(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.NewDecoder(w.Result().Body).Decode(&res))
+		return res
+	}
+
+	api.SetConfigReloadStatus(nil)
+	res := status()
+	require.True(t, res.Data.ConfigReload.Success)
+	require.Empty(t, res.Data.ConfigReload.Error)
+	lastSuccess := res.Data.ConfigReload.LastSuccess
+	require.False(t, lastSuccess.IsZero())
+
+	// A failed reload keeps the time of the last successful one.
+	api.SetConfigReloadStatus(errors.New("bad config"))
+	res = status()
+	require.False(t, res.Data.ConfigReload.Success)
+	require.Equal(t, "bad config", res.Data.ConfigReload.Error)
+	require.True(t, lastSuccess.Equal(res.Data.ConfigReload.LastSuccess))
+}
+
+func TestStreamAlerts(t *testing.T) {
+	marker := types.NewMarker()
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, log.NewNopLogger())
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	cfg, err := config.Load(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+`)
+	require.NoError(t, err)
+
+	api := New(alerts, nil, marker.Status, nil, nil)
+	require.NoError(t, api.Update(cfg, time.Minute))
+
+	now := time.Now()
+	newAlert := func(name string) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": model.LabelValue(name)},
+				StartsAt: now.Add(-time.Minute),
+				EndsAt:   now.Add(time.Hour),
+			},
+			UpdatedAt: now,
+			Timeout:   true,
+		}
+	}
+	a1 := newAlert("A1")
+	require.NoError(t, alerts.Put(a1, newAlert("A2")))
+
+	srv := httptest.NewServer(http.HandlerFunc(api.streamAlerts))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?filter=" + url.QueryEscape(`{alertname="A1"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	r := bufio.NewReader(resp.Body)
+	next := func() (string, *Alert) {
+		var (
+			event string
+			alert Alert
+		)
+		for {
+			line, err := r.ReadString('\n')
+			require.NoError(t, err)
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimSpace(strings.TrimPrefix(line, "event: "))
+			case strings.HasPrefix(line, "data: "):
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &alert))
+			case line == "\n":
+				return event, &alert
+			}
+		}
+	}
+
+	event, alert := next()
+	require.Equal(t, "firing", event)
+	require.Equal(t, a1.Fingerprint().String(), alert.Fingerprint)
+	require.Equal(t, []string{"team-X"}, alert.Receivers)
+
+	resolved := *a1
+	resolved.EndsAt = now.Add(-time.Second)
+	resolved.UpdatedAt = now.Add(time.Second)
+	require.NoError(t, alerts.Put(&resolved))
+
+	event, alert = next()
+	require.Equal(t, "resolved", event)
+	require.Equal(t, a1.Fingerprint().String(), alert.Fingerprint)
+}
+
+func TestBufferAlerts(t *testing.T) {
+	var (
+		ch   = make(chan *types.Alert)
+		done = make(chan struct{})
+		stop = make(chan struct{})
+	)
+	defer close(stop)
+	updates := bufferAlerts(provider.NewAlertIterator(ch, done, nil), 2, stop, log.NewNopLogger())
+
+	// Sending must not block while the buffer isn't read.
+	for i := 0; i < 3; i++ {
+		select {
+		case ch <- &types.Alert{}:
+		case <-time.After(time.Second):
+			t.Fatalf("sending alert %d blocked", i)
+		}
+	}
+
+	// The subscription is closed after the buffer overflowed.
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("iterator wasn't closed")
+	}
+	n := 0
+	for range updates {
+		n++
+	}
+	require.Equal(t, 2, n)
+}
+
+func TestStatusCounts(t *testing.T) {
+	cfg, err := config.Load(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+`)
+	require.NoError(t, err)
+
+	silences, err := silence.New(silence.Options{})
+	require.NoError(t, err)
+	now := time.Now()
+	for _, name := range []string{"active", "expired"} {
+		id, err := silences.Set(&silencepb.Silence{
+			Matchers: []*silencepb.Matcher{{Name: "name", Pattern: name}},
+			StartsAt: now,
+			EndsAt:   now.Add(time.Hour),
+		}, "")
+		require.NoError(t, err)
+		if name == "expired" {
+			require.NoError(t, silences.Expire(id, ""))
+		}
+	}
+
+	newAlert := func(state string, endsAt time.Time) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": model.LabelValue(state), "state": model.LabelValue(state)},
+				StartsAt: now.Add(-time.Minute),
+				EndsAt:   endsAt,
+			},
+		}
+	}
+	alerts := newFakeAlerts([]*types.Alert{
+		newAlert("active", now.Add(time.Hour)),
+		newAlert("suppressed", now.Add(time.Hour)),
+		newAlert("resolved", now.Add(-time.Second)),
+	}, false)
+
+	api := New(alerts, silences, newGetAlertStatus(alerts), nil, nil)
+	require.NoError(t, api.Update(cfg, time.Minute))
+
+	r, err := http.NewRequest("GET", "/api/v1/status", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	api.status(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Data struct {
+			AlertCounts   map[string]int `json:"alertCounts"`
+			SilenceCounts map[string]int `json:"silenceCounts"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Result().Body).Decode(&res))
+	require.Equal(t, map[string]int{"unprocessed": 0, "active": 1, "suppressed": 1}, res.Data.AlertCounts)
+	require.Equal(t, map[string]int{"active": 1, "pending": 0, "expired": 1}, res.Data.SilenceCounts)
+}
+
+func TestLabels(t *testing.T) {
+	now := time.Now()
+	newAlert := func(endsAt time.Time, ls model.LabelSet) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels:   ls,
+				StartsAt: now.Add(-time.Minute),
+				EndsAt:   endsAt,
+			},
+		}
+	}
+	alerts := newFakeAlerts([]*types.Alert{
+		newAlert(now.Add(time.Hour), model.LabelSet{"alertname": "HighLatency", "service": "api"}),
+		newAlert(now.Add(time.Hour), model.LabelSet{"alertname": "HighLatency", "service": "db"}),
+		newAlert(now.Add(time.Hour), model.LabelSet{"alertname": "DiskFull", "instance": "db-1"}),
+		newAlert(now.Add(-time.Second), model.LabelSet{"alertname": "Resolved", "cluster": "eu"}),
+	}, false)
+	api := New(alerts, nil, newGetAlertStatus(alerts), nil, nil)
+
+	get := func(h http.HandlerFunc, name string) (int, []string) {
+		r, err := http.NewRequest("GET", "/", nil)
+		require.NoError(t, err)
+		r = r.WithContext(route.WithParam(r.Context(), "name", name))
+		w := httptest.NewRecorder()
+		h(w, r)
+
+		var res struct {
+			Data []string `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Result().Body).Decode(&res))
+		return w.Code, res.Data
+	}
+
+	code, data := get(api.labelNames, "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []string{"alertname", "instance", "service"}, data)
+
+	code, data = get(api.labelValues, "alertname")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []string{"DiskFull", "HighLatency"}, data)
+
+	code, data = get(api.labelValues, "unknown")
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, data)
+
+	code, _ = get(api.labelValues, "in-valid")
+	require.Equal(t, http.StatusBadRequest, code)
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
 	"github.com/prometheus/alertmanager/pkg/parse"
+	"github.com/prometheus/alertmanager/pkg/web"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
@@ -65,6 +66,14 @@ type API struct {
 
 	logger log.Logger
 
+	// Whether silences can only be modified by their owner and the users
+	// allowed to modify any silence. The user is authenticated by the web
+	// server or, if it doesn't authenticate requests, by a proxy setting
+	// the user header.
+	enforceOwnership bool
+	userHeader       string
+	admins           map[string]bool
+
 	Handler http.Handler
 }
 
@@ -105,6 +114,21 @@ func NewAPI(alerts provider.Alerts, sf getAlertStatusFn, silences *silence.Silen
 	return &api, nil
 }
 
+// EnforceOwnership makes the API record the authenticated user as owner of
+// new silences. Silences can then only be modified by their owner or one of
+// the admins. The user authenticated by the web server takes precedence,
+// otherwise it is read from the given request header set by a proxy. The
+// header must be empty if the web server authenticates requests, so clients
+// can't set it themselves.
+func (api *API) EnforceOwnership(userHeader string, admins []string) {
+	api.enforceOwnership = true
+	api.userHeader = userHeader
+	api.admins = make(map[string]bool, len(admins))
+	for _, a := range admins {
+		api.admins[a] = true
+	}
+}
+
 // Update sets the configuration string to a new value.
 func (api *API) Update(cfg *config.Config, resolveTimeout time.Duration) error {
 	api.mtx.Lock()
@@ -144,7 +168,10 @@ func (api *API) getReceiversHandler(params receiver_ops.GetReceiversParams) midd
 
 	receivers := make([]*open_api_models.Receiver, 0, len(api.alertmanagerConfig.Receivers))
 	for _, r := range api.alertmanagerConfig.Receivers {
-		receivers = append(receivers, &open_api_models.Receiver{Name: r.Name})
+		receivers = append(receivers, &open_api_models.Receiver{
+			Name:         r.Name,
+			Integrations: r.Integrations(),
+		})
 	}
 
 	return receiver_ops.NewGetReceiversOK().WithPayload(receivers)
@@ -477,7 +504,11 @@ func (api *API) getSilenceHandler(params silence_ops.GetSilenceParams) middlewar
 func (api *API) deleteSilenceHandler(params silence_ops.DeleteSilenceParams) middleware.Responder {
 	sid := params.SilenceID.String()
 
-	if err := api.silences.Expire(sid); err != nil {
+	if err := api.authorize(params.HTTPRequest, sid); err != nil {
+		level.Error(api.logger).Log("msg", "not allowed to expire silence", "err", err)
+		return silence_ops.NewDeleteSilenceForbidden().WithPayload(err.Error())
+	}
+	if err := api.silences.Expire(sid, api.user(params.HTTPRequest)); err != nil {
 		level.Error(api.logger).Log("msg", "failed to expire silence", "err", err)
 		return silence_ops.NewDeleteSilenceInternalServerError().WithPayload(err.Error())
 	}
@@ -491,10 +522,12 @@ func silenceFromProto(s *silencepb.Silence) (open_api_models.Silence, error) {
 		EndsAt:    strfmt.DateTime(s.EndsAt),
 		UpdatedAt: strfmt.DateTime(s.UpdatedAt),
 		Status: &open_api_models.SilenceStatus{
-			State: string(types.CalcSilenceState(s.StartsAt, s.EndsAt)),
+			State: string(silence.State(s, time.Now())),
 		},
 		Comment:   s.Comment,
 		CreatedBy: s.CreatedBy,
+		TicketURL: s.TicketUrl,
+		Owner:     s.Owner,
 	}
 
 	for _, m := range s.Matchers {
@@ -507,6 +540,11 @@ func silenceFromProto(s *silencepb.Silence) (open_api_models.Silence, error) {
 		case silencepb.Matcher_EQUAL:
 		case silencepb.Matcher_REGEXP:
 			matcher.IsRegex = true
+		case silencepb.Matcher_NOT_EQUAL:
+			matcher.IsNegative = true
+		case silencepb.Matcher_NOT_REGEXP:
+			matcher.IsRegex = true
+			matcher.IsNegative = true
 		default:
 			return sil, fmt.Errorf(
 				"unknown matcher type for matcher '%v' in silence '%v'",
@@ -516,6 +554,13 @@ func silenceFromProto(s *silencepb.Silence) (open_api_models.Silence, error) {
 		}
 		sil.Matchers = append(sil.Matchers, matcher)
 	}
+	if s.Recurrence != nil {
+		sil.Recurrence = &open_api_models.SilenceRecurrence{
+			Schedule: s.Recurrence.Schedule,
+			Duration: prometheus_model.Duration(s.Recurrence.Duration).String(),
+			Timezone: s.Recurrence.Timezone,
+		}
+	}
 
 	return sil, nil
 }
@@ -530,19 +575,28 @@ func (api *API) postSilencesHandler(params silence_ops.PostSilencesParams) middl
 		)
 	}
 
-	if sil.StartsAt.After(sil.EndsAt) || sil.StartsAt.Equal(sil.EndsAt) {
-		msg := "failed to create silence: start time must be equal or after end time"
-		level.Error(api.logger).Log("msg", msg, "err", err)
-		return silence_ops.NewPostSilencesBadRequest().WithPayload(msg)
+	// Silences without end time get the default duration, if configured.
+	if !sil.EndsAt.IsZero() {
+		if sil.StartsAt.After(sil.EndsAt) || sil.StartsAt.Equal(sil.EndsAt) {
+			msg := "failed to create silence: start time must be equal or after end time"
+			level.Error(api.logger).Log("msg", msg, "err", err)
+			return silence_ops.NewPostSilencesBadRequest().WithPayload(msg)
+		}
+
+		if sil.EndsAt.Before(time.Now()) {
+			msg := "failed to create silence: end time can't be in the past"
+			level.Error(api.logger).Log("msg", msg, "err", err)
+			return silence_ops.NewPostSilencesBadRequest().WithPayload(msg)
+		}
 	}
 
-	if sil.EndsAt.Before(time.Now()) {
-		msg := "failed to create silence: end time can't be in the past"
-		level.Error(api.logger).Log("msg", msg, "err", err)
-		return silence_ops.NewPostSilencesBadRequest().WithPayload(msg)
+	if err := api.authorize(params.HTTPRequest, sil.Id); err != nil {
+		level.Error(api.logger).Log("msg", "not allowed to modify silence", "err", err)
+		return silence_ops.NewPostSilencesForbidden().WithPayload(err.Error())
 	}
+	sil.Owner = api.user(params.HTTPRequest)
 
-	sid, err := api.silences.Set(sil)
+	sid, err := api.silences.Set(sil, api.editor(params.HTTPRequest, sil.CreatedBy))
 	if err != nil {
 		level.Error(api.logger).Log("msg", "failed to create silence", "err", err)
 		return silence_ops.NewPostSilencesBadRequest().WithPayload(err.Error())
@@ -553,6 +607,55 @@ func (api *API) postSilencesHandler(params silence_ops.PostSilencesParams) middl
 	})
 }
 
+// user returns the authenticated user of the request, if ownership is enforced.
+func (api *API) user(r *http.Request) string {
+	if !api.enforceOwnership || r == nil {
+		return ""
+	}
+	if user, ok := web.UserFromContext(r.Context()); ok {
+		return user
+	}
+	if api.userHeader == "" {
+		return ""
+	}
+	return r.Header.Get(api.userHeader)
+}
+
+// editor returns the editor recorded for a modification of silences. It is
+// the authenticated user if known, as the one given in the request can't be
+// trusted then.
+func (api *API) editor(r *http.Request, given string) string {
+	if user := api.user(r); user != "" {
+		return user
+	}
+	return given
+}
+
+// authorize returns an error if ownership is enforced and the requesting
+// user may not modify the silence with the given ID. An empty ID refers to
+// a new silence, which any authenticated user may create.
+func (api *API) authorize(r *http.Request, sid string) error {
+	if !api.enforceOwnership {
+		return nil
+	}
+	user := api.user(r)
+	if user == "" {
+		return fmt.Errorf("request is not authenticated")
+	}
+	if sid == "" || api.admins[user] {
+		return nil
+	}
+	sils, err := api.silences.Query(silence.QIDs(sid))
+	if err != nil || len(sils) == 0 {
+		// Let the silences report unknown IDs.
+		return nil
+	}
+	if owner := sils[0].Owner; owner != "" && owner != user {
+		return fmt.Errorf("silence %s is owned by %s", sid, owner)
+	}
+	return nil
+}
+
 func silenceToProto(s *open_api_models.Silence) (*silencepb.Silence, error) {
 	sil := &silencepb.Silence{
 		Id:        s.ID,
@@ -561,6 +664,7 @@ func silenceToProto(s *open_api_models.Silence) (*silencepb.Silence, error) {
 		UpdatedAt: time.Time(s.UpdatedAt),
 		Comment:   s.Comment,
 		CreatedBy: s.CreatedBy,
+		TicketUrl: s.TicketURL,
 	}
 	for _, m := range s.Matchers {
 		matcher := &silencepb.Matcher{
@@ -568,10 +672,26 @@ func silenceToProto(s *open_api_models.Silence) (*silencepb.Silence, error) {
 			Pattern: m.Value,
 			Type:    silencepb.Matcher_EQUAL,
 		}
-		if m.IsRegex {
+		switch {
+		case m.IsRegex && m.IsNegative:
+			matcher.Type = silencepb.Matcher_NOT_REGEXP
+		case m.IsRegex:
 			matcher.Type = silencepb.Matcher_REGEXP
+		case m.IsNegative:
+			matcher.Type = silencepb.Matcher_NOT_EQUAL
 		}
 		sil.Matchers = append(sil.Matchers, matcher)
 	}
+	if s.Recurrence != nil {
+		d, err := prometheus_model.ParseDuration(s.Recurrence.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence duration: %s", err)
+		}
+		sil.Recurrence = &silencepb.Recurrence{
+			Schedule: s.Recurrence.Schedule,
+			Duration: time.Duration(d),
+			Timezone: s.Recurrence.Timezone,
+		}
+	}
 	return sil, nil
 }
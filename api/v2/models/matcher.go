@@ -15,6 +15,9 @@ import (
 // swagger:model matcher
 type Matcher struct {
 
+	// is negative
+	IsNegative bool `json:"isNegative,omitempty"`
+
 	// is regex
 	IsRegex bool `json:"isRegex,omitempty"`
 
@@ -197,6 +197,9 @@ func init() {
           "200": {
             "description": "Delete silence response"
           },
+          "403": {
+            "$ref": "#/responses/Forbidden"
+          },
           "500": {
             "$ref": "#/responses/InternalServerError"
           }
@@ -274,6 +277,9 @@ func init() {
           },
           "400": {
             "$ref": "#/responses/BadRequest"
+          },
+          "403": {
+            "$ref": "#/responses/Forbidden"
           }
         }
       }
@@ -396,6 +402,9 @@ func init() {
     "matcher": {
       "type": "object",
       "properties": {
+        "isNegative": {
+          "type": "boolean"
+        },
         "isRegex": {
           "type": "boolean"
         },
@@ -431,6 +440,12 @@ func init() {
     "receiver": {
       "type": "object",
       "properties": {
+        "integrations": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "name": {
           "type": "string"
         }
@@ -458,6 +473,24 @@ func init() {
         "matchers": {
           "$ref": "#/definitions/matchers"
         },
+        "owner": {
+          "type": "string",
+          "readOnly": true
+        },
+        "recurrence": {
+          "type": "object",
+          "properties": {
+            "duration": {
+              "type": "string"
+            },
+            "schedule": {
+              "type": "string"
+            },
+            "timezone": {
+              "type": "string"
+            }
+          }
+        },
         "startsAt": {
           "type": "string",
           "format": "date-time"
@@ -475,6 +508,9 @@ func init() {
             }
           }
         },
+        "ticketURL": {
+          "type": "string"
+        },
         "updatedAt": {
           "type": "string",
           "format": "date-time"
@@ -495,6 +531,12 @@ func init() {
         "type": "string"
       }
     },
+    "Forbidden": {
+      "description": "Not allowed to modify the silence",
+      "schema": {
+        "type": "string"
+      }
+    },
     "InternalServerError": {
       "description": "Internal server error",
       "schema": {
@@ -716,6 +758,12 @@ func init() {
           "200": {
             "description": "Delete silence response"
           },
+          "403": {
+            "description": "Not allowed to modify the silence",
+            "schema": {
+              "type": "string"
+            }
+          },
           "500": {
             "description": "Internal server error",
             "schema": {
@@ -802,6 +850,12 @@ func init() {
             "schema": {
               "type": "string"
             }
+          },
+          "403": {
+            "description": "Not allowed to modify the silence",
+            "schema": {
+              "type": "string"
+            }
           }
         }
       }
@@ -924,6 +978,9 @@ func init() {
     "matcher": {
       "type": "object",
       "properties": {
+        "isNegative": {
+          "type": "boolean"
+        },
         "isRegex": {
           "type": "boolean"
         },
@@ -959,6 +1016,12 @@ func init() {
     "receiver": {
       "type": "object",
       "properties": {
+        "integrations": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "name": {
           "type": "string"
         }
@@ -986,6 +1049,24 @@ func init() {
         "matchers": {
           "$ref": "#/definitions/matchers"
         },
+        "owner": {
+          "type": "string",
+          "readOnly": true
+        },
+        "recurrence": {
+          "type": "object",
+          "properties": {
+            "duration": {
+              "type": "string"
+            },
+            "schedule": {
+              "type": "string"
+            },
+            "timezone": {
+              "type": "string"
+            }
+          }
+        },
         "startsAt": {
           "type": "string",
           "format": "date-time"
@@ -1003,6 +1084,9 @@ func init() {
             }
           }
         },
+        "ticketURL": {
+          "type": "string"
+        },
         "updatedAt": {
           "type": "string",
           "format": "date-time"
@@ -1023,6 +1107,12 @@ func init() {
         "type": "string"
       }
     },
+    "Forbidden": {
+      "description": "Not allowed to modify the silence",
+      "schema": {
+        "type": "string"
+      }
+    },
     "InternalServerError": {
       "description": "Internal server error",
       "schema": {
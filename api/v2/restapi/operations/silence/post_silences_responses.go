@@ -97,3 +97,46 @@ func (o *PostSilencesBadRequest) WriteResponse(rw http.ResponseWriter, producer
 	}
 
 }
+
+// PostSilencesForbiddenCode is the HTTP code returned for type PostSilencesForbidden
+const PostSilencesForbiddenCode int = 403
+
+/*PostSilencesForbidden Not allowed to modify the silence
+
+swagger:response postSilencesForbidden
+*/
+type PostSilencesForbidden struct {
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewPostSilencesForbidden creates PostSilencesForbidden with default headers values
+func NewPostSilencesForbidden() *PostSilencesForbidden {
+
+	return &PostSilencesForbidden{}
+}
+
+// WithPayload adds the payload to the post silences forbidden response
+func (o *PostSilencesForbidden) WithPayload(payload string) *PostSilencesForbidden {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post silences forbidden response
+func (o *PostSilencesForbidden) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostSilencesForbidden) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(403)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
@@ -40,11 +40,11 @@ func (formatter *ExtendedFormatter) SetOutput(writer io.Writer) {
 func (formatter *ExtendedFormatter) FormatSilences(silences []types.Silence) error {
 	w := tabwriter.NewWriter(formatter.writer, 0, 0, 2, ' ', 0)
 	sort.Sort(ByEndAt(silences))
-	fmt.Fprintln(w, "ID\tMatchers\tStarts At\tEnds At\tUpdated At\tCreated By\tComment\t")
+	fmt.Fprintln(w, "ID\tMatchers\tStarts At\tEnds At\tUpdated At\tCreated By\tComment\tTicket URL\t")
 	for _, silence := range silences {
 		fmt.Fprintf(
 			w,
-			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
 			silence.ID,
 			extendedFormatMatchers(silence.Matchers),
 			FormatDate(silence.StartsAt),
@@ -52,6 +52,7 @@ func (formatter *ExtendedFormatter) FormatSilences(silences []types.Silence) err
 			FormatDate(silence.UpdatedAt),
 			silence.CreatedBy,
 			silence.Comment,
+			silence.TicketURL,
 		)
 	}
 	return w.Flush()
@@ -114,8 +115,13 @@ func extendedFormatMatchers(matchers types.Matchers) string {
 }
 
 func extendedFormatMatcher(matcher types.Matcher) string {
-	if matcher.IsRegex {
+	switch {
+	case matcher.IsRegex && matcher.IsNegative:
+		return fmt.Sprintf("%s!~%s", matcher.Name, matcher.Value)
+	case matcher.IsRegex:
 		return fmt.Sprintf("%s~=%s", matcher.Name, matcher.Value)
+	case matcher.IsNegative:
+		return fmt.Sprintf("%s!=%s", matcher.Name, matcher.Value)
 	}
 	return fmt.Sprintf("%s=%s", matcher.Name, matcher.Value)
 }
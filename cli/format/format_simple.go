@@ -85,8 +85,13 @@ func simpleFormatMatchers(matchers types.Matchers) string {
 }
 
 func simpleFormatMatcher(matcher types.Matcher) string {
-	if matcher.IsRegex {
+	switch {
+	case matcher.IsRegex && matcher.IsNegative:
+		return fmt.Sprintf("%s!~%s", matcher.Name, matcher.Value)
+	case matcher.IsRegex:
 		return fmt.Sprintf("%s=~%s", matcher.Name, matcher.Value)
+	case matcher.IsNegative:
+		return fmt.Sprintf("%s!=%s", matcher.Name, matcher.Value)
 	}
 	return fmt.Sprintf("%s=%s", matcher.Name, matcher.Value)
 }
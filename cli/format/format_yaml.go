@@ -0,0 +1,69 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/client"
+	"github.com/prometheus/alertmanager/types"
+)
+
+type YAMLFormatter struct {
+	writer io.Writer
+}
+
+func init() {
+	Formatters["yaml"] = &YAMLFormatter{writer: os.Stdout}
+}
+
+func (formatter *YAMLFormatter) SetOutput(writer io.Writer) {
+	formatter.writer = writer
+}
+
+func (formatter *YAMLFormatter) FormatSilences(silences []types.Silence) error {
+	return formatter.encode(silences)
+}
+
+func (formatter *YAMLFormatter) FormatAlerts(alerts []*client.ExtendedAlert) error {
+	return formatter.encode(alerts)
+}
+
+func (formatter *YAMLFormatter) FormatConfig(status *client.ServerStatus) error {
+	return formatter.encode(status)
+}
+
+// encode writes v as YAML with the same field names and value formats as
+// its JSON encoding, so the output can be read back like JSON output.
+func (formatter *YAMLFormatter) encode(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// JSON is valid YAML. Decoding it generically keeps the JSON field names.
+	var generic interface{}
+	if err := yaml.Unmarshal(b, &generic); err != nil {
+		return err
+	}
+	b, err = yaml.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	_, err = formatter.writer.Write(b)
+	return err
+}
@@ -62,7 +62,7 @@ func Execute() {
 
 	app.Flag("verbose", "Verbose running information").Short('v').BoolVar(&verbose)
 	app.Flag("alertmanager.url", "Alertmanager to talk to").URLVar(&alertmanagerURL)
-	app.Flag("output", "Output formatter (simple, extended, json)").Short('o').Default("simple").EnumVar(&output, "simple", "extended", "json")
+	app.Flag("output", "Output formatter (simple, extended, json, yaml)").Short('o').Default("simple").EnumVar(&output, "simple", "extended", "json", "yaml")
 	app.Flag("timeout", "Timeout for the executed command").Default("30s").DurationVar(&timeout)
 
 	app.Version(version.Print("amtool"))
@@ -112,7 +112,7 @@ static configuration:
 		Bool, whether to require a comment on silence creation. Defaults to true
 
 	output
-		Set a default output type. Options are (simple, extended, json)
+		Set a default output type. Options are (simple, extended, json, yaml)
 
 	date.format
 		Sets the output format for dates. Defaults to "2006-01-02 15:04:05 MST"
@@ -43,6 +43,10 @@ type silenceAddCmd struct {
 	start          string
 	end            string
 	comment        string
+	ticketURL      string
+	schedule       string
+	window         string
+	timezone       string
 	matchers       []string
 }
 
@@ -68,6 +72,16 @@ const silenceAddHelp = `Add a new alertmanager silence
 	As well as direct equality, regex matching is also supported. The '=~' syntax
 	(similar to Prometheus) is used to represent a regex match. Regex matching
 	can be used in combination with a direct match.
+
+  amtool silence add --duration=30d --schedule='0 2 * * *' --window=30m job=batch
+
+	A recurring silence only takes effect in the windows starting at each
+	occurrence of the cron schedule within its overall time range.
+
+  amtool silence add --duration=90d --schedule='0 2 * * sat' --timezone=Europe/Berlin job=backup
+
+	The schedule is evaluated in UTC unless a timezone is given. Windows in a
+	timezone follow its daylight saving time changes.
 `
 
 func configureSilenceAddCmd(cc *kingpin.CmdClause) {
@@ -81,6 +95,10 @@ func configureSilenceAddCmd(cc *kingpin.CmdClause) {
 	addCmd.Flag("start", "Set when the silence should start. RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&c.start)
 	addCmd.Flag("end", "Set when the silence should end (overwrites duration). RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&c.end)
 	addCmd.Flag("comment", "A comment to help describe the silence").Short('c').StringVar(&c.comment)
+	addCmd.Flag("ticket-url", "A link to the incident or change ticket justifying the silence").StringVar(&c.ticketURL)
+	addCmd.Flag("schedule", "Cron expression at which a recurring silence takes effect").StringVar(&c.schedule)
+	addCmd.Flag("window", "Duration of each window of a recurring silence").Default("1h").StringVar(&c.window)
+	addCmd.Flag("timezone", "IANA time zone the schedule of a recurring silence is evaluated in, defaults to UTC").StringVar(&c.timezone)
 	addCmd.Arg("matcher-groups", "Query filter").StringsVar(&c.matchers)
 	addCmd.Action(execWithTimeout(c.add))
 
@@ -145,6 +163,14 @@ func (c *silenceAddCmd) add(ctx context.Context, _ *kingpin.ParseContext) error
 		EndsAt:    endsAt,
 		CreatedBy: c.author,
 		Comment:   c.comment,
+		TicketURL: c.ticketURL,
+	}
+	if c.schedule != "" {
+		silence.Recurrence = &types.SilenceRecurrence{
+			Schedule: c.schedule,
+			Duration: c.window,
+			Timezone: c.timezone,
+		}
 	}
 
 	apiClient, err := api.NewClient(api.Config{Address: alertmanagerURL.String()})
@@ -17,13 +17,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/api"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"gopkg.in/yaml.v2"
 
 	"github.com/prometheus/alertmanager/client"
 	"github.com/prometheus/alertmanager/types"
@@ -33,18 +37,24 @@ type silenceImportCmd struct {
 	force   bool
 	workers int
 	file    string
+	format  string
 }
 
-const silenceImportHelp = `Import alertmanager silences from JSON file or stdin
+const silenceImportHelp = `Import alertmanager silences from JSON or YAML file or stdin
 
-This command can be used to bulk import silences from a JSON file
+This command can be used to bulk import silences from a JSON or YAML file
 created by query command. For example:
 
 amtool silence query -o json foo > foo.json
 
 amtool silence import foo.json
 
-JSON data can also come from stdin if no param is specified.
+amtool silence query -o yaml foo > foo.yml
+
+amtool silence import foo.yml
+
+Files ending in .yml or .yaml are read as YAML, all others as JSON unless
+--format is given. Data can also come from stdin if no param is specified.
 `
 
 func configureSilenceImportCmd(cc *kingpin.CmdClause) {
@@ -55,7 +65,8 @@ func configureSilenceImportCmd(cc *kingpin.CmdClause) {
 
 	importCmd.Flag("force", "Force adding new silences even if it already exists").Short('f').BoolVar(&c.force)
 	importCmd.Flag("worker", "Number of concurrent workers to use for import").Short('w').Default("8").IntVar(&c.workers)
-	importCmd.Arg("input-file", "JSON file with silences").ExistingFileVar(&c.file)
+	importCmd.Flag("format", "Format of the input (json, yaml)").EnumVar(&c.format, "json", "yaml")
+	importCmd.Arg("input-file", "JSON or YAML file with silences").ExistingFileVar(&c.file)
 	importCmd.Action(execWithTimeout(c.bulkImport))
 }
 
@@ -89,11 +100,32 @@ func (c *silenceImportCmd) bulkImport(ctx context.Context, _ *kingpin.ParseConte
 		defer input.Close()
 	}
 
-	dec := json.NewDecoder(input)
-	// read open square bracket
-	_, err = dec.Token()
-	if err != nil {
-		return errors.Wrap(err, "couldn't unmarshal input data, is it JSON?")
+	format := c.format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(c.file)) {
+		case ".yml", ".yaml":
+			format = "yaml"
+		default:
+			format = "json"
+		}
+	}
+
+	var (
+		dec          *json.Decoder
+		yamlSilences []types.Silence
+	)
+	if format == "yaml" {
+		yamlSilences, err = parseYAMLSilences(input)
+		if err != nil {
+			return errors.Wrap(err, "couldn't unmarshal input data, is it YAML?")
+		}
+	} else {
+		dec = json.NewDecoder(input)
+		// read open square bracket
+		_, err = dec.Token()
+		if err != nil {
+			return errors.Wrap(err, "couldn't unmarshal input data, is it JSON?")
+		}
 	}
 
 	apiClient, err := api.NewClient(api.Config{Address: alertmanagerURL.String()})
@@ -122,13 +154,7 @@ func (c *silenceImportCmd) bulkImport(ctx context.Context, _ *kingpin.ParseConte
 	}()
 
 	count := 0
-	for dec.More() {
-		var s types.Silence
-		err := dec.Decode(&s)
-		if err != nil {
-			return errors.Wrap(err, "couldn't unmarshal input data, is it JSON?")
-		}
-
+	submit := func(s types.Silence) {
 		if c.force {
 			// reset the silence ID so Alertmanager will always create new silence
 			s.ID = ""
@@ -137,6 +163,17 @@ func (c *silenceImportCmd) bulkImport(ctx context.Context, _ *kingpin.ParseConte
 		silencec <- &s
 		count++
 	}
+	for _, s := range yamlSilences {
+		submit(s)
+	}
+	for dec != nil && dec.More() {
+		var s types.Silence
+		err := dec.Decode(&s)
+		if err != nil {
+			return errors.Wrap(err, "couldn't unmarshal input data, is it JSON?")
+		}
+		submit(s)
+	}
 
 	close(silencec)
 	wg.Wait()
@@ -147,3 +184,43 @@ func (c *silenceImportCmd) bulkImport(ctx context.Context, _ *kingpin.ParseConte
 	}
 	return nil
 }
+
+// parseYAMLSilences reads a YAML list of silences. Fields are named like in
+// the JSON representation, as written by the YAML output format.
+func parseYAMLSilences(r io.Reader) ([]types.Silence, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	if b, err = json.Marshal(jsonCompatible(generic)); err != nil {
+		return nil, err
+	}
+	var silences []types.Silence
+	if err := json.Unmarshal(b, &silences); err != nil {
+		return nil, err
+	}
+	return silences, nil
+}
+
+// jsonCompatible converts the maps of a generically decoded YAML document,
+// which may have keys of any type, into maps that can be encoded as JSON.
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = jsonCompatible(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = jsonCompatible(e)
+		}
+		return v
+	}
+	return v
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/cli/format"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestYAMLSilencesRoundTrip(t *testing.T) {
+	now := time.Date(2018, 6, 4, 10, 30, 0, 0, time.UTC)
+	silences := []types.Silence{
+		{
+			ID: "1",
+			Matchers: types.Matchers{
+				{Name: "alertname", Value: "foo"},
+				{Name: "instance", Value: "web-.*", IsRegex: true},
+				{Name: "env", Value: "prod", IsNegative: true},
+				{Name: "job", Value: "batch|cron", IsRegex: true, IsNegative: true},
+			},
+			StartsAt:  now,
+			EndsAt:    now.Add(time.Hour),
+			UpdatedAt: now,
+			CreatedBy: "alice",
+			Comment:   "maintenance: db",
+			Recurrence: &types.SilenceRecurrence{
+				Schedule: "0 2 * * sat",
+				Duration: "1h",
+				Timezone: "Europe/Berlin",
+			},
+			Status: types.SilenceStatus{State: types.SilenceStateActive},
+		},
+		{
+			ID:        "2",
+			Matchers:  types.Matchers{{Name: "a", Value: "true"}},
+			StartsAt:  now,
+			EndsAt:    now.Add(time.Hour),
+			UpdatedAt: now,
+			Status:    types.SilenceStatus{State: types.SilenceStatePending},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := format.Formatters["yaml"]
+	formatter.SetOutput(&buf)
+	if err := formatter.FormatSilences(silences); err != nil {
+		t.Fatalf("formatting silences failed: %v", err)
+	}
+
+	got, err := parseYAMLSilences(&buf)
+	if err != nil {
+		t.Fatalf("parsing silences failed: %v", err)
+	}
+	if !reflect.DeepEqual(silences, got) {
+		t.Fatalf("silences changed on round-trip\nwant: %v\ngot:  %v", silences, got)
+	}
+}
+
+func TestParseYAMLSilences(t *testing.T) {
+	input := `
+- matchers:
+  - name: alertname
+    value: foo
+  - name: env
+    value: dev
+    isNegative: true
+  startsAt: 2018-06-04T10:30:00+02:00
+  endsAt: 2018-06-04T12:30:00+02:00
+  createdBy: bob
+  comment: deploy
+`
+	got, err := parseYAMLSilences(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parsing silences failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 silence but got %d", len(got))
+	}
+	s := got[0]
+	if len(s.Matchers) != 2 || !s.Matchers[1].IsNegative {
+		t.Fatalf("unexpected matchers %v", s.Matchers)
+	}
+	if want := time.Date(2018, 6, 4, 8, 30, 0, 0, time.UTC); !s.StartsAt.Equal(want) {
+		t.Fatalf("expected start %s but got %s", want, s.StartsAt)
+	}
+
+	if _, err := parseYAMLSilences(strings.NewReader("matchers: [")); err == nil {
+		t.Fatalf("expected error for invalid YAML")
+	}
+}
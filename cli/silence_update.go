@@ -29,12 +29,13 @@ import (
 )
 
 type silenceUpdateCmd struct {
-	quiet    bool
-	duration string
-	start    string
-	end      string
-	comment  string
-	ids      []string
+	quiet     bool
+	duration  string
+	start     string
+	end       string
+	comment   string
+	ticketURL string
+	ids       []string
 }
 
 func configureSilenceUpdateCmd(cc *kingpin.CmdClause) {
@@ -47,6 +48,7 @@ func configureSilenceUpdateCmd(cc *kingpin.CmdClause) {
 	updateCmd.Flag("start", "Set when the silence should start. RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&c.start)
 	updateCmd.Flag("end", "Set when the silence should end (overwrites duration). RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&c.end)
 	updateCmd.Flag("comment", "A comment to help describe the silence").Short('c').StringVar(&c.comment)
+	updateCmd.Flag("ticket-url", "A link to the incident or change ticket justifying the silence").StringVar(&c.ticketURL)
 	updateCmd.Arg("update-ids", "Silence IDs to update").StringsVar(&c.ids)
 
 	updateCmd.Action(execWithTimeout(c.update))
@@ -99,6 +101,9 @@ func (c *silenceUpdateCmd) update(ctx context.Context, _ *kingpin.ParseContext)
 		if c.comment != "" {
 			silence.Comment = c.comment
 		}
+		if c.ticketURL != "" {
+			silence.TicketURL = c.ticketURL
+		}
 
 		newID, err := silenceAPI.Set(ctx, *silence)
 		if err != nil {
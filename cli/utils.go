@@ -157,7 +157,6 @@ func TypeMatchers(matchers []labels.Matcher) (types.Matchers, error) {
 }
 
 // Only valid for when you are going to add a silence
-// Doesn't allow negative operators
 func TypeMatcher(matcher labels.Matcher) (types.Matcher, error) {
 	typeMatcher := types.NewMatcher(model.LabelName(matcher.Name), matcher.Value)
 
@@ -166,6 +165,11 @@ func TypeMatcher(matcher labels.Matcher) (types.Matcher, error) {
 		typeMatcher.IsRegex = false
 	case labels.MatchRegexp:
 		typeMatcher.IsRegex = true
+	case labels.MatchNotEqual:
+		typeMatcher.IsNegative = true
+	case labels.MatchNotRegexp:
+		typeMatcher.IsRegex = true
+		typeMatcher.IsNegative = true
 	default:
 		return types.Matcher{}, fmt.Errorf("invalid match type for creation operation: %s", matcher.Type)
 	}
@@ -40,13 +40,17 @@ import (
 	"github.com/prometheus/alertmanager/inhibit"
 	"github.com/prometheus/alertmanager/nflog"
 	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/pkg/timeinterval"
+	"github.com/prometheus/alertmanager/pkg/web"
 	"github.com/prometheus/alertmanager/provider/mem"
 	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/silence/silencepb"
 	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/types"
 	"github.com/prometheus/alertmanager/ui"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/promlog"
 	"github.com/prometheus/common/route"
 	"github.com/prometheus/common/version"
@@ -143,11 +147,26 @@ func main() {
 		dataDir         = kingpin.Flag("storage.path", "Base path for data storage.").Default("data/").String()
 		retention       = kingpin.Flag("data.retention", "How long to keep data for.").Default("120h").Duration()
 		alertGCInterval = kingpin.Flag("alerts.gc-interval", "Interval between alert GC.").Default("30m").Duration()
+		maxRetries      = kingpin.Flag("notify.max-retries", "Maximum number of retries of a failed notification. 0 retries until the notification times out.").Default("0").Int()
+		deadLetterMax   = kingpin.Flag("notify.dead-letter-max", "Maximum number of notifications kept for replay after all retries failed. Notifications that failed first are removed first. 0 disables the dead letter store.").Default("1000").Int()
+		historyMax      = kingpin.Flag("notify.history-max", "Maximum number of sent and failed notifications kept in memory for the notification history. 0 disables the history.").Default("10000").Int()
+		drainTimeout    = kingpin.Flag("dispatch.drain-timeout", "Maximum time to wait on shutdown for the notifications of pending alert groups to be sent. 0 drops pending notifications.").Default("0").Duration()
 		logLevelString  = kingpin.Flag("log.level", "Only log messages with the given severity or above.").Default("info").Enum("debug", "info", "warn", "error")
 
+		silenceRetention  = kingpin.Flag("silences.retention", "How long to keep silences after they ended. Defaults to --data.retention.").Duration()
+		silenceMaxExpired = kingpin.Flag("silences.max-expired", "Maximum number of expired silences to keep. Silences that ended first are removed first. 0 means no limit.").Default("0").Int()
+		silenceGCInterval = kingpin.Flag("silences.gc-interval", "Interval between silence GC.").Default("15m").Duration()
+
+		silenceDefaultDuration = kingpin.Flag("silences.default-duration", "Duration of silences created without end time. 0 requires an end time.").Default("0").Duration()
+		silenceMaxDuration     = kingpin.Flag("silences.max-duration", "Maximum duration of silences. Longer silences are rejected. 0 means no limit.").Default("0").Duration()
+		silenceExpiryNotify    = kingpin.Flag("silences.expiry-notification", "Time before the end of a silence at which a SilenceExpiring alert is raised for it, so its creator can be notified through the routing tree. 0 disables the alerts.").Default("0").Duration()
+
 		externalURL   = kingpin.Flag("web.external-url", "The URL under which Alertmanager is externally reachable (for example, if Alertmanager is served via a reverse proxy). Used for generating relative and absolute links back to Alertmanager itself. If the URL has a path portion, it will be used to prefix all HTTP endpoints served by Alertmanager. If omitted, relevant URL components will be derived automatically.").String()
 		routePrefix   = kingpin.Flag("web.route-prefix", "Prefix for the internal routes of web endpoints. Defaults to path of --web.external-url.").String()
 		listenAddress = kingpin.Flag("web.listen-address", "Address to listen on for the web interface and API.").Default(":9093").String()
+		webConfigFile = kingpin.Flag("web.config.file", "Path to the configuration file of the web server, which enables TLS and authentication of all endpoints and customizes the web UI. It is only read at startup.").String()
+		userHeader    = kingpin.Flag("web.user-header", "Request header holding the user authenticated by a proxy in front of Alertmanager. If set, silences can only be modified by the user who created them and the silence admins. Ignored if the web server authenticates requests itself, which enforces silence ownership the same way.").String()
+		silenceAdmins = kingpin.Flag("silences.admin", "User allowed to modify all silences and to purge expired ones if silence ownership is enforced (may be repeated).").Strings()
 
 		clusterBindAddr = kingpin.Flag("cluster.listen-address", "Listen address for cluster.").
 				Default(defaultClusterAddr).String()
@@ -180,6 +199,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	var webConfig *web.Config
+	if *webConfigFile != "" {
+		webConfig, err = web.LoadConfigFile(*webConfigFile)
+		if err != nil {
+			level.Error(logger).Log("msg", "Loading web configuration file failed", "file", *webConfigFile, "err", err)
+			os.Exit(1)
+		}
+	}
+
 	var peer *cluster.Peer
 	if *clusterBindAddr != "" {
 		peer, err = cluster.Create(
@@ -208,6 +236,7 @@ func main() {
 	notificationLogOpts := []nflog.Option{
 		nflog.WithRetention(*retention),
 		nflog.WithSnapshot(filepath.Join(*dataDir, "nflog")),
+		nflog.WithWAL(filepath.Join(*dataDir, "nflog.wal")),
 		nflog.WithMaintenance(15*time.Minute, stopc, wg.Done),
 		nflog.WithMetrics(prometheus.DefaultRegisterer),
 		nflog.WithLogger(log.With(logger, "component", "nflog")),
@@ -223,14 +252,38 @@ func main() {
 		notificationLog.SetBroadcast(c.Broadcast)
 	}
 
+	var deadLetters *notify.DeadLetters
+	if *deadLetterMax > 0 {
+		deadLetters, err = notify.NewDeadLetters(*deadLetterMax, filepath.Join(*dataDir, "deadletters"), log.With(logger, "component", "deadletters"))
+		if err != nil {
+			level.Error(logger).Log("err", err)
+			os.Exit(1)
+		}
+	}
+
+	var history *notify.NotificationHistory
+	if *historyMax > 0 {
+		history = notify.NewNotificationHistory(*historyMax)
+	}
+
+	rateLimits := notify.NewRateLimits()
+
 	marker := types.NewMarker()
 	newMarkerMetrics(marker)
 
+	if *silenceRetention == 0 {
+		*silenceRetention = *retention
+	}
 	silenceOpts := silence.Options{
 		SnapshotFile: filepath.Join(*dataDir, "silences"),
-		Retention:    *retention,
+		WALFile:      filepath.Join(*dataDir, "silences.wal"),
+		Retention:    *silenceRetention,
+		MaxExpired:   *silenceMaxExpired,
 		Logger:       log.With(logger, "component", "silences"),
 		Metrics:      prometheus.DefaultRegisterer,
+
+		DefaultDuration: *silenceDefaultDuration,
+		MaxDuration:     *silenceMaxDuration,
 	}
 
 	silences, err := silence.New(silenceOpts)
@@ -246,7 +299,7 @@ func main() {
 	// Start providers before router potentially sends updates.
 	wg.Add(1)
 	go func() {
-		silences.Maintenance(15*time.Minute, filepath.Join(*dataDir, "silences"), stopc)
+		silences.Maintenance(*silenceGCInterval, filepath.Join(*dataDir, "silences"), stopc)
 		wg.Done()
 	}()
 
@@ -286,6 +339,8 @@ func main() {
 		tmpl      *template.Template
 		pipeline  notify.Stage
 		disp      *dispatch.Dispatcher
+		// Guards the reloads, which replace the dispatcher.
+		reloadMtx sync.Mutex
 	)
 	defer disp.Stop()
 
@@ -309,12 +364,47 @@ func main() {
 		os.Exit(1)
 	}
 
+	if deadLetters != nil {
+		apiV1.SetDeadLetters(deadLetters)
+	}
+	if history != nil {
+		apiV1.SetNotificationHistory(history)
+	}
+	if webConfig != nil && webConfig.UI != nil {
+		apiV1.SetUIConfig(webConfig.UI)
+	}
+
+	if webConfig.AuthEnabled() {
+		// Clients could set the header themselves, the user authenticated
+		// by the web server is used instead.
+		if *userHeader != "" {
+			level.Warn(logger).Log("msg", "Ignoring --web.user-header as the web server authenticates requests")
+		}
+		apiV1.EnforceOwnership("", *silenceAdmins)
+		apiV2.EnforceOwnership("", *silenceAdmins)
+	} else if *userHeader != "" {
+		apiV1.EnforceOwnership(*userHeader, *silenceAdmins)
+		apiV2.EnforceOwnership(*userHeader, *silenceAdmins)
+	}
+
 	amURL, err := extURL(*listenAddress, *externalURL)
 	if err != nil {
 		level.Error(logger).Log("err", err)
 		os.Exit(1)
 	}
 
+	if *silenceExpiryNotify > 0 {
+		wg.Add(1)
+		go func() {
+			silences.NotifyExpiring(time.Minute, *silenceExpiryNotify, func(expiring, forgotten []*silencepb.Silence) {
+				if err := alerts.Put(expiringSilenceAlerts(expiring, forgotten, amURL)...); err != nil {
+					level.Error(logger).Log("msg", "failed to add silence expiry alerts", "err", err)
+				}
+			}, stopc)
+			wg.Done()
+		}()
+	}
+
 	waitFunc := func() time.Duration { return 0 }
 	if peer != nil {
 		waitFunc = clusterWait(peer, *peerTimeout)
@@ -328,8 +418,12 @@ func main() {
 
 	var hash float64
 	reload := func() (err error) {
+		reloadMtx.Lock()
+		defer reloadMtx.Unlock()
+
 		level.Info(logger).Log("msg", "Loading configuration file", "file", *configFile)
 		defer func() {
+			apiV1.SetConfigReloadStatus(err)
 			if err != nil {
 				level.Error(logger).Log("msg", "Loading configuration file failed", "file", *configFile, "err", err)
 				configSuccess.Set(0)
@@ -345,6 +439,14 @@ func main() {
 			return err
 		}
 
+		// Parse the templates before applying anything so the previous
+		// configuration stays in use if they are broken.
+		newTmpl, err := template.FromGlobs(conf.Templates...)
+		if err != nil {
+			return err
+		}
+		newTmpl.ExternalURL = amURL
+
 		hash = md5HashAsMetricValue(plainCfg)
 
 		err = apiV1.Update(conf, time.Duration(conf.Global.ResolveTimeout))
@@ -357,18 +459,22 @@ func main() {
 			return err
 		}
 
-		tmpl, err = template.FromGlobs(conf.Templates...)
-		if err != nil {
-			return err
-		}
-		tmpl.ExternalURL = amURL
+		tmpl = newTmpl
+		apiV1.SetTemplate(tmpl)
 
 		inhibitor.Stop()
 		disp.Stop()
 
 		inhibitor = inhibit.NewInhibitor(alerts, conf.InhibitRules, marker, logger)
+		apiV1.SetInhibitor(inhibitor)
+		muteTimes := make(map[string][]timeinterval.TimeInterval, len(conf.MuteTimeIntervals))
+		for _, mt := range conf.MuteTimeIntervals {
+			muteTimes[mt.Name] = mt.TimeIntervals
+		}
+
 		pipeline = notify.BuildPipeline(
 			conf.Receivers,
+			muteTimes,
 			tmpl,
 			waitFunc,
 			inhibitor,
@@ -376,9 +482,14 @@ func main() {
 			notificationLog,
 			marker,
 			peer,
+			*maxRetries,
+			deadLetters,
+			history,
+			rateLimits,
 			logger,
 		)
 		disp = dispatch.NewDispatcher(alerts, dispatch.NewRoute(conf.Route, nil), pipeline, marker, timeoutFunc, logger)
+		apiV1.SetDispatcher(disp)
 
 		go disp.Run()
 		go inhibitor.Run()
@@ -411,7 +522,7 @@ func main() {
 
 	// TODO: How about having a http.handler for each (web, apiv1, apiv2) and
 	// combine them all together in `listen()`
-	go listen(*listenAddress, router, apiV2.Handler, logger)
+	go listen(*listenAddress, webConfig, router, apiV2.Handler, logger)
 
 	var (
 		hup      = make(chan os.Signal)
@@ -440,6 +551,48 @@ func main() {
 	<-term
 
 	level.Info(logger).Log("msg", "Received SIGTERM, exiting gracefully...")
+
+	if *drainTimeout > 0 {
+		// Block further reloads so the dispatcher being drained isn't
+		// replaced.
+		reloadMtx.Lock()
+		disp.Drain(*drainTimeout)
+	}
+}
+
+// expiringSilenceAlerts returns an alert for each of the expiring silences
+// which is resolved when the silence ends. The alerts of the forgotten
+// silences, which no longer expire soon, are resolved right away.
+func expiringSilenceAlerts(expiring, forgotten []*silencepb.Silence, externalURL *url.URL) []*types.Alert {
+	now := time.Now()
+	alerts := make([]*types.Alert, 0, len(expiring)+len(forgotten))
+
+	newAlert := func(sil *silencepb.Silence, endsAt time.Time) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels: model.LabelSet{
+					model.AlertNameLabel: "SilenceExpiring",
+					"silence_id":         model.LabelValue(sil.Id),
+					"created_by":         model.LabelValue(sil.CreatedBy),
+				},
+				Annotations: model.LabelSet{
+					"summary": model.LabelValue(fmt.Sprintf("Silence %s ends at %s", sil.Id, sil.EndsAt.Format(time.RFC3339))),
+					"comment": model.LabelValue(sil.Comment),
+				},
+				StartsAt:     now,
+				EndsAt:       endsAt,
+				GeneratorURL: externalURL.String() + "/#/silences/" + sil.Id,
+			},
+			UpdatedAt: now,
+		}
+	}
+	for _, sil := range expiring {
+		alerts = append(alerts, newAlert(sil, sil.EndsAt))
+	}
+	for _, sil := range forgotten {
+		alerts = append(alerts, newAlert(sil, now))
+	}
+	return alerts
 }
 
 // clusterWait returns a function that inspects the current peer state and returns
@@ -478,12 +631,12 @@ func extURL(listen, external string) (*url.URL, error) {
 	return u, nil
 }
 
-func listen(listen string, apiV1Handler *route.Router, apiV2Handler http.Handler, logger log.Logger) {
+func listen(listen string, webConfig *web.Config, apiV1Handler *route.Router, apiV2Handler http.Handler, logger log.Logger) {
 	level.Info(logger).Log("msg", "Listening", "address", listen)
 	mux := http.NewServeMux()
 	mux.Handle("/", apiV1Handler)
 	mux.Handle("/api/v2/", http.StripPrefix("/api/v2", apiV2Handler))
-	if err := http.ListenAndServe(listen, mux); err != nil {
+	if err := web.ListenAndServe(listen, webConfig, mux); err != nil {
 		level.Error(logger).Log("msg", "Listen error", "err", err)
 		os.Exit(1)
 	}
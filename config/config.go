@@ -27,6 +27,8 @@ import (
 	commoncfg "github.com/prometheus/common/config"
 	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/pkg/timeinterval"
 )
 
 // Secret is a string that must not be revealed on marshaling.
@@ -193,6 +195,9 @@ type Config struct {
 	Receivers    []*Receiver    `yaml:"receivers,omitempty" json:"receivers,omitempty"`
 	Templates    []string       `yaml:"templates" json:"templates"`
 
+	SilenceTemplates  []*SilenceTemplate  `yaml:"silence_templates,omitempty" json:"silence_templates,omitempty"`
+	MuteTimeIntervals []*MuteTimeInterval `yaml:"mute_time_intervals,omitempty" json:"mute_time_intervals,omitempty"`
+
 	// original is the input from which the config was parsed.
 	original string
 }
@@ -228,9 +233,13 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		if _, ok := names[rcv.Name]; ok {
 			return fmt.Errorf("notification config name %q is not unique", rcv.Name)
 		}
+		httpConfig := c.Global.HTTPConfig
+		if rcv.HTTPConfig != nil {
+			httpConfig = rcv.HTTPConfig
+		}
 		for _, wh := range rcv.WebhookConfigs {
 			if wh.HTTPConfig == nil {
-				wh.HTTPConfig = c.Global.HTTPConfig
+				wh.HTTPConfig = httpConfig
 			}
 		}
 		for _, ec := range rcv.EmailConfigs {
@@ -268,7 +277,7 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		for _, sc := range rcv.SlackConfigs {
 			if sc.HTTPConfig == nil {
-				sc.HTTPConfig = c.Global.HTTPConfig
+				sc.HTTPConfig = httpConfig
 			}
 			if sc.APIURL == nil {
 				if c.Global.SlackAPIURL == nil {
@@ -279,7 +288,7 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		for _, hc := range rcv.HipchatConfigs {
 			if hc.HTTPConfig == nil {
-				hc.HTTPConfig = c.Global.HTTPConfig
+				hc.HTTPConfig = httpConfig
 			}
 			if hc.APIURL == nil {
 				if c.Global.HipchatAPIURL == nil {
@@ -299,12 +308,118 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		for _, poc := range rcv.PushoverConfigs {
 			if poc.HTTPConfig == nil {
-				poc.HTTPConfig = c.Global.HTTPConfig
+				poc.HTTPConfig = httpConfig
+			}
+		}
+		for _, mtc := range rcv.MSTeamsConfigs {
+			if mtc.HTTPConfig == nil {
+				mtc.HTTPConfig = httpConfig
+			}
+		}
+		for _, sc := range rcv.SNSConfigs {
+			if sc.HTTPConfig == nil {
+				sc.HTTPConfig = httpConfig
+			}
+		}
+		for _, mc := range rcv.MatrixConfigs {
+			if mc.HTTPConfig == nil {
+				mc.HTTPConfig = httpConfig
+			}
+		}
+		for _, dc := range rcv.DiscordConfigs {
+			if dc.HTTPConfig == nil {
+				dc.HTTPConfig = httpConfig
+			}
+		}
+		for _, gc := range rcv.GitHubConfigs {
+			if gc.HTTPConfig == nil {
+				gc.HTTPConfig = httpConfig
+			}
+			if gc.APIURL == nil {
+				if c.Global.GitHubAPIURL == nil {
+					return fmt.Errorf("no global GitHub API URL set")
+				}
+				gc.APIURL = c.Global.GitHubAPIURL
+			}
+			if !strings.HasSuffix(gc.APIURL.Path, "/") {
+				gc.APIURL.Path += "/"
+			}
+		}
+		for _, zc := range rcv.ZendeskConfigs {
+			if zc.HTTPConfig == nil {
+				zc.HTTPConfig = httpConfig
+			}
+			if !strings.HasSuffix(zc.APIURL.Path, "/") {
+				zc.APIURL.Path += "/"
+			}
+		}
+		for _, sc := range rcv.ServiceNowConfigs {
+			if sc.HTTPConfig == nil {
+				sc.HTTPConfig = httpConfig
+			}
+		}
+		for _, jc := range rcv.JiraConfigs {
+			if jc.HTTPConfig == nil {
+				jc.HTTPConfig = httpConfig
+			}
+			if !strings.HasSuffix(jc.APIURL.Path, "/") {
+				jc.APIURL.Path += "/"
+			}
+		}
+		for _, kc := range rcv.KafkaConfigs {
+			if kc.HTTPConfig == nil {
+				kc.HTTPConfig = httpConfig
+			}
+		}
+		for _, gc := range rcv.GoogleChatConfigs {
+			if gc.HTTPConfig == nil {
+				gc.HTTPConfig = httpConfig
+			}
+		}
+		for _, rc := range rcv.RocketchatConfigs {
+			if rc.HTTPConfig == nil {
+				rc.HTTPConfig = httpConfig
+			}
+		}
+		for _, wc := range rcv.WebexConfigs {
+			if wc.HTTPConfig == nil {
+				wc.HTTPConfig = httpConfig
+			}
+			if wc.APIURL == nil {
+				if c.Global.WebexAPIURL == nil {
+					return fmt.Errorf("no global Webex API URL set")
+				}
+				wc.APIURL = c.Global.WebexAPIURL
+			}
+		}
+		for _, tc := range rcv.TwilioConfigs {
+			if tc.HTTPConfig == nil {
+				tc.HTTPConfig = httpConfig
+			}
+			if tc.APIURL == nil {
+				if c.Global.TwilioAPIURL == nil {
+					return fmt.Errorf("no global Twilio API URL set")
+				}
+				tc.APIURL = c.Global.TwilioAPIURL
+			}
+			if !strings.HasSuffix(tc.APIURL.Path, "/") {
+				tc.APIURL.Path += "/"
+			}
+		}
+		for _, tc := range rcv.TelegramConfigs {
+			if tc.HTTPConfig == nil {
+				tc.HTTPConfig = httpConfig
+			}
+			if tc.APIURL == nil {
+				if c.Global.TelegramAPIURL == nil {
+					return fmt.Errorf("no global Telegram API URL set")
+				}
+				tc.APIURL = c.Global.TelegramAPIURL
 			}
 		}
 		for _, pdc := range rcv.PagerdutyConfigs {
 			if pdc.HTTPConfig == nil {
-				pdc.HTTPConfig = c.Global.HTTPConfig
+				pdc.HTTPConfig = httpConfig
 			}
 			if pdc.URL == nil {
 				if c.Global.PagerdutyURL == nil {
@@ -315,7 +430,7 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		for _, ogc := range rcv.OpsGenieConfigs {
 			if ogc.HTTPConfig == nil {
-				ogc.HTTPConfig = c.Global.HTTPConfig
+				ogc.HTTPConfig = httpConfig
 			}
 			if ogc.APIURL == nil {
 				if c.Global.OpsGenieAPIURL == nil {
@@ -335,7 +450,7 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		for _, wcc := range rcv.WechatConfigs {
 			if wcc.HTTPConfig == nil {
-				wcc.HTTPConfig = c.Global.HTTPConfig
+				wcc.HTTPConfig = httpConfig
 			}
 
 			if wcc.APIURL == nil {
@@ -365,7 +480,7 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		for _, voc := range rcv.VictorOpsConfigs {
 			if voc.HTTPConfig == nil {
-				voc.HTTPConfig = c.Global.HTTPConfig
+				voc.HTTPConfig = httpConfig
 			}
 			if voc.APIURL == nil {
 				if c.Global.VictorOpsAPIURL == nil {
@@ -386,6 +501,22 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		names[rcv.Name] = struct{}{}
 	}
 
+	tmplNames := map[string]struct{}{}
+	for _, st := range c.SilenceTemplates {
+		if _, ok := tmplNames[st.Name]; ok {
+			return fmt.Errorf("silence template name %q is not unique", st.Name)
+		}
+		tmplNames[st.Name] = struct{}{}
+	}
+
+	muteTimes := map[string]struct{}{}
+	for _, mt := range c.MuteTimeIntervals {
+		if _, ok := muteTimes[mt.Name]; ok {
+			return fmt.Errorf("mute time interval name %q is not unique", mt.Name)
+		}
+		muteTimes[mt.Name] = struct{}{}
+	}
+
 	// The root route must not have any matchers as it is the fallback node
 	// for all alerts.
 	if c.Route == nil {
@@ -397,9 +528,32 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if len(c.Route.Match) > 0 || len(c.Route.MatchRE) > 0 {
 		return fmt.Errorf("root route must not have any matchers")
 	}
+	if len(c.Route.MuteTimeIntervals) > 0 {
+		return fmt.Errorf("root route must not have any mute time intervals")
+	}
+
+	// Validate that all receivers and mute time intervals used in the
+	// routing tree are defined.
+	if err := checkReceiver(c.Route, names); err != nil {
+		return err
+	}
+	return checkMuteTimeIntervals(c.Route, muteTimes)
+}
 
-	// Validate that all receivers used in the routing tree are defined.
-	return checkReceiver(c.Route, names)
+// checkMuteTimeIntervals returns an error if a node in the routing tree
+// references a mute time interval not in the given map.
+func checkMuteTimeIntervals(r *Route, muteTimes map[string]struct{}) error {
+	for _, name := range r.MuteTimeIntervals {
+		if _, ok := muteTimes[name]; !ok {
+			return fmt.Errorf("undefined mute time interval %q used in route", name)
+		}
+	}
+	for _, sr := range r.Routes {
+		if err := checkMuteTimeIntervals(sr, muteTimes); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // checkReceiver returns an error if a node in the routing tree
@@ -422,7 +576,7 @@ func checkReceiver(r *Route, receivers map[string]struct{}) error {
 // DefaultGlobalConfig provides global default values.
 var DefaultGlobalConfig = GlobalConfig{
 	ResolveTimeout: model.Duration(5 * time.Minute),
-	HTTPConfig:     &commoncfg.HTTPClientConfig{},
+	HTTPConfig:     &HTTPClientConfig{},
 
 	SMTPHello:       "localhost",
 	SMTPRequireTLS:  true,
@@ -431,6 +585,10 @@ var DefaultGlobalConfig = GlobalConfig{
 	OpsGenieAPIURL:  mustParseURL("https://api.opsgenie.com/"),
 	WeChatAPIURL:    mustParseURL("https://qyapi.weixin.qq.com/cgi-bin/"),
 	VictorOpsAPIURL: mustParseURL("https://alert.victorops.com/integrations/generic/20131114/alert/"),
+	TelegramAPIURL:  mustParseURL("https://api.telegram.org"),
+	TwilioAPIURL:    mustParseURL("https://api.twilio.com/2010-04-01/"),
+	WebexAPIURL:     mustParseURL("https://webexapis.com/v1/messages"),
+	GitHubAPIURL:    mustParseURL("https://api.github.com/"),
 }
 
 func mustParseURL(s string) *URL {
@@ -448,7 +606,7 @@ type GlobalConfig struct {
 	// if it has not been updated.
 	ResolveTimeout model.Duration `yaml:"resolve_timeout" json:"resolve_timeout"`
 
-	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
 	SMTPFrom         string     `yaml:"smtp_from,omitempty" json:"smtp_from,omitempty"`
 	SMTPHello        string     `yaml:"smtp_hello,omitempty" json:"smtp_hello,omitempty"`
@@ -469,6 +627,10 @@ type GlobalConfig struct {
 	WeChatAPICorpID  string     `yaml:"wechat_api_corp_id,omitempty" json:"wechat_api_corp_id,omitempty"`
 	VictorOpsAPIURL  *URL       `yaml:"victorops_api_url,omitempty" json:"victorops_api_url,omitempty"`
 	VictorOpsAPIKey  Secret     `yaml:"victorops_api_key,omitempty" json:"victorops_api_key,omitempty"`
+	TelegramAPIURL   *URL       `yaml:"telegram_api_url,omitempty" json:"telegram_api_url,omitempty"`
+	TwilioAPIURL     *URL       `yaml:"twilio_api_url,omitempty" json:"twilio_api_url,omitempty"`
+	WebexAPIURL      *URL       `yaml:"webex_api_url,omitempty" json:"webex_api_url,omitempty"`
+	GitHubAPIURL     *URL       `yaml:"github_api_url,omitempty" json:"github_api_url,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -478,6 +640,90 @@ func (c *GlobalConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return unmarshal((*plain)(c))
 }
 
+// HTTPClientConfig configures the HTTP client of notifiers. It extends the
+// common HTTP client configuration with timeouts and custom headers.
+type HTTPClientConfig struct {
+	commoncfg.HTTPClientConfig `yaml:",inline"`
+
+	// DialTimeout is the maximum time to establish a connection.
+	DialTimeout model.Duration `yaml:"dial_timeout,omitempty" json:"dial_timeout,omitempty"`
+	// ResponseTimeout is the maximum time to wait for the response headers
+	// after the request was sent.
+	ResponseTimeout model.Duration `yaml:"response_timeout,omitempty" json:"response_timeout,omitempty"`
+	// Headers are added to every request, replacing headers set by the
+	// notifier.
+	Headers map[string]Secret `yaml:"headers,omitempty" json:"headers,omitempty"`
+	// OAuth2 authenticates requests with an access token obtained with
+	// the OAuth 2.0 client credentials grant.
+	OAuth2 *OAuth2Config `yaml:"oauth2,omitempty" json:"oauth2,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *HTTPClientConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// The unmarshaler of the embedded configuration would be promoted to a
+	// plain copy of the type, so the fields are unmarshaled separately.
+	type common commoncfg.HTTPClientConfig
+	var plain struct {
+		common          `yaml:",inline"`
+		DialTimeout     model.Duration    `yaml:"dial_timeout,omitempty"`
+		ResponseTimeout model.Duration    `yaml:"response_timeout,omitempty"`
+		Headers         map[string]Secret `yaml:"headers,omitempty"`
+		OAuth2          *OAuth2Config     `yaml:"oauth2,omitempty"`
+	}
+	if err := unmarshal(&plain); err != nil {
+		return err
+	}
+	*c = HTTPClientConfig{
+		HTTPClientConfig: commoncfg.HTTPClientConfig(plain.common),
+		DialTimeout:      plain.DialTimeout,
+		ResponseTimeout:  plain.ResponseTimeout,
+		Headers:          plain.Headers,
+		OAuth2:           plain.OAuth2,
+	}
+	if c.OAuth2 != nil && (c.BasicAuth != nil || len(c.BearerToken) > 0 || len(c.BearerTokenFile) > 0) {
+		return fmt.Errorf("at most one of basic_auth, bearer_token, bearer_token_file & oauth2 must be configured")
+	}
+	for h := range c.Headers {
+		if !httpHeaderRE.MatchString(h) {
+			return fmt.Errorf("invalid header name %q in http_config", h)
+		}
+	}
+	return c.Validate()
+}
+
+// OAuth2Config configures the OAuth 2.0 client credentials grant.
+// https://tools.ietf.org/html/rfc6749#section-4.4
+type OAuth2Config struct {
+	ClientID         string            `yaml:"client_id" json:"client_id"`
+	ClientSecret     Secret            `yaml:"client_secret,omitempty" json:"client_secret,omitempty"`
+	ClientSecretFile string            `yaml:"client_secret_file,omitempty" json:"client_secret_file,omitempty"`
+	Scopes           []string          `yaml:"scopes,omitempty" json:"scopes,omitempty"`
+	TokenURL         *URL              `yaml:"token_url" json:"token_url"`
+	EndpointParams   map[string]string `yaml:"endpoint_params,omitempty" json:"endpoint_params,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *OAuth2Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain OAuth2Config
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.ClientID == "" {
+		return fmt.Errorf("missing client_id in oauth2 config")
+	}
+	if c.TokenURL == nil {
+		return fmt.Errorf("missing token_url in oauth2 config")
+	}
+	if len(c.ClientSecret) > 0 && c.ClientSecretFile != "" {
+		return fmt.Errorf("at most one of client_secret & client_secret_file must be configured in oauth2 config")
+	}
+	return nil
+}
+
+// httpHeaderRE matches valid header field names.
+// https://tools.ietf.org/html/rfc7230#section-3.2
+var httpHeaderRE = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
 // A Route is a node that contains definitions of how to handle alerts.
 type Route struct {
 	Receiver string            `yaml:"receiver,omitempty" json:"receiver,omitempty"`
@@ -491,6 +737,10 @@ type Route struct {
 	GroupWait      *model.Duration `yaml:"group_wait,omitempty" json:"group_wait,omitempty"`
 	GroupInterval  *model.Duration `yaml:"group_interval,omitempty" json:"group_interval,omitempty"`
 	RepeatInterval *model.Duration `yaml:"repeat_interval,omitempty" json:"repeat_interval,omitempty"`
+
+	// The names of the mute time intervals during which no notifications
+	// are sent for the route. They are not inherited by child routes.
+	MuteTimeIntervals []string `yaml:"mute_time_intervals,omitempty" json:"mute_time_intervals,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -590,16 +840,42 @@ func (r *InhibitRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type Receiver struct {
 	// A unique identifier for this receiver.
 	Name string `yaml:"name" json:"name"`
-
-	EmailConfigs     []*EmailConfig     `yaml:"email_configs,omitempty" json:"email_configs,omitempty"`
-	PagerdutyConfigs []*PagerdutyConfig `yaml:"pagerduty_configs,omitempty" json:"pagerduty_configs,omitempty"`
-	HipchatConfigs   []*HipchatConfig   `yaml:"hipchat_configs,omitempty" json:"hipchat_configs,omitempty"`
-	SlackConfigs     []*SlackConfig     `yaml:"slack_configs,omitempty" json:"slack_configs,omitempty"`
-	WebhookConfigs   []*WebhookConfig   `yaml:"webhook_configs,omitempty" json:"webhook_configs,omitempty"`
-	OpsGenieConfigs  []*OpsGenieConfig  `yaml:"opsgenie_configs,omitempty" json:"opsgenie_configs,omitempty"`
-	WechatConfigs    []*WechatConfig    `yaml:"wechat_configs,omitempty" json:"wechat_configs,omitempty"`
-	PushoverConfigs  []*PushoverConfig  `yaml:"pushover_configs,omitempty" json:"pushover_configs,omitempty"`
-	VictorOpsConfigs []*VictorOpsConfig `yaml:"victorops_configs,omitempty" json:"victorops_configs,omitempty"`
+	// RateLimit limits the rate of notifications sent by the integrations of
+	// the receiver, which share the limit.
+	RateLimit *RateLimitConfig `yaml:"rate_limit,omitempty" json:"rate_limit,omitempty"`
+	// HTTPConfig is the default HTTP client configuration of the integrations.
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	// MaxAlerts is the maximum number of alerts in a notification. 0 means
+	// no limit.
+	MaxAlerts int `yaml:"max_alerts,omitempty" json:"max_alerts,omitempty"`
+
+	EmailConfigs      []*EmailConfig      `yaml:"email_configs,omitempty" json:"email_configs,omitempty"`
+	PagerdutyConfigs  []*PagerdutyConfig  `yaml:"pagerduty_configs,omitempty" json:"pagerduty_configs,omitempty"`
+	HipchatConfigs    []*HipchatConfig    `yaml:"hipchat_configs,omitempty" json:"hipchat_configs,omitempty"`
+	SlackConfigs      []*SlackConfig      `yaml:"slack_configs,omitempty" json:"slack_configs,omitempty"`
+	WebhookConfigs    []*WebhookConfig    `yaml:"webhook_configs,omitempty" json:"webhook_configs,omitempty"`
+	OpsGenieConfigs   []*OpsGenieConfig   `yaml:"opsgenie_configs,omitempty" json:"opsgenie_configs,omitempty"`
+	WechatConfigs     []*WechatConfig     `yaml:"wechat_configs,omitempty" json:"wechat_configs,omitempty"`
+	PushoverConfigs   []*PushoverConfig   `yaml:"pushover_configs,omitempty" json:"pushover_configs,omitempty"`
+	VictorOpsConfigs  []*VictorOpsConfig  `yaml:"victorops_configs,omitempty" json:"victorops_configs,omitempty"`
+	MSTeamsConfigs    []*MSTeamsConfig    `yaml:"msteams_configs,omitempty" json:"msteams_configs,omitempty"`
+	TelegramConfigs   []*TelegramConfig   `yaml:"telegram_configs,omitempty" json:"telegram_configs,omitempty"`
+	SNSConfigs        []*SNSConfig        `yaml:"sns_configs,omitempty" json:"sns_configs,omitempty"`
+	TwilioConfigs     []*TwilioConfig     `yaml:"twilio_configs,omitempty" json:"twilio_configs,omitempty"`
+	DiscordConfigs    []*DiscordConfig    `yaml:"discord_configs,omitempty" json:"discord_configs,omitempty"`
+	MatrixConfigs     []*MatrixConfig     `yaml:"matrix_configs,omitempty" json:"matrix_configs,omitempty"`
+	IRCConfigs        []*IRCConfig        `yaml:"irc_configs,omitempty" json:"irc_configs,omitempty"`
+	XMPPConfigs       []*XMPPConfig       `yaml:"xmpp_configs,omitempty" json:"xmpp_configs,omitempty"`
+	WebexConfigs      []*WebexConfig      `yaml:"webex_configs,omitempty" json:"webex_configs,omitempty"`
+	RocketchatConfigs []*RocketchatConfig `yaml:"rocketchat_configs,omitempty" json:"rocketchat_configs,omitempty"`
+	GoogleChatConfigs []*GoogleChatConfig `yaml:"googlechat_configs,omitempty" json:"googlechat_configs,omitempty"`
+	ExecConfigs       []*ExecConfig       `yaml:"exec_configs,omitempty" json:"exec_configs,omitempty"`
+	MQTTConfigs       []*MQTTConfig       `yaml:"mqtt_configs,omitempty" json:"mqtt_configs,omitempty"`
+	KafkaConfigs      []*KafkaConfig      `yaml:"kafka_configs,omitempty" json:"kafka_configs,omitempty"`
+	JiraConfigs       []*JiraConfig       `yaml:"jira_configs,omitempty" json:"jira_configs,omitempty"`
+	ServiceNowConfigs []*ServiceNowConfig `yaml:"servicenow_configs,omitempty" json:"servicenow_configs,omitempty"`
+	ZendeskConfigs    []*ZendeskConfig    `yaml:"zendesk_configs,omitempty" json:"zendesk_configs,omitempty"`
+	GitHubConfigs     []*GitHubConfig     `yaml:"github_configs,omitempty" json:"github_configs,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -611,9 +887,186 @@ func (c *Receiver) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if c.Name == "" {
 		return fmt.Errorf("missing name in receiver")
 	}
+	if c.MaxAlerts < 0 {
+		return fmt.Errorf("negative max_alerts in receiver %q", c.Name)
+	}
 	return nil
 }
 
+// Integrations returns the types of the integrations configured for the
+// receiver, like "email" or "webhook", each listed once.
+func (c *Receiver) Integrations() []string {
+	var res []string
+	for _, i := range []struct {
+		name string
+		n    int
+	}{
+		{"email", len(c.EmailConfigs)},
+		{"pagerduty", len(c.PagerdutyConfigs)},
+		{"hipchat", len(c.HipchatConfigs)},
+		{"slack", len(c.SlackConfigs)},
+		{"webhook", len(c.WebhookConfigs)},
+		{"opsgenie", len(c.OpsGenieConfigs)},
+		{"wechat", len(c.WechatConfigs)},
+		{"pushover", len(c.PushoverConfigs)},
+		{"victorops", len(c.VictorOpsConfigs)},
+		{"msteams", len(c.MSTeamsConfigs)},
+		{"telegram", len(c.TelegramConfigs)},
+		{"sns", len(c.SNSConfigs)},
+		{"twilio", len(c.TwilioConfigs)},
+		{"discord", len(c.DiscordConfigs)},
+		{"matrix", len(c.MatrixConfigs)},
+		{"irc", len(c.IRCConfigs)},
+		{"xmpp", len(c.XMPPConfigs)},
+		{"webex", len(c.WebexConfigs)},
+		{"rocketchat", len(c.RocketchatConfigs)},
+		{"googlechat", len(c.GoogleChatConfigs)},
+		{"exec", len(c.ExecConfigs)},
+		{"mqtt", len(c.MQTTConfigs)},
+		{"kafka", len(c.KafkaConfigs)},
+		{"jira", len(c.JiraConfigs)},
+		{"servicenow", len(c.ServiceNowConfigs)},
+		{"zendesk", len(c.ZendeskConfigs)},
+		{"github", len(c.GitHubConfigs)},
+	} {
+		if i.n > 0 {
+			res = append(res, i.name)
+		}
+	}
+	return res
+}
+
+// Overflow behaviors of rate limits.
+const (
+	// RateLimitDrop drops the notifications exceeding the rate limit.
+	RateLimitDrop = "drop"
+	// RateLimitDelay delays the notifications exceeding the rate limit
+	// until they are allowed or the notification times out.
+	RateLimitDelay = "delay"
+	// RateLimitSummarize holds back the alerts of the notifications
+	// exceeding the rate limit and adds them to the next notification sent
+	// for the same alert group.
+	RateLimitSummarize = "summarize"
+)
+
+// RateLimitConfig configures a token bucket limiting the number of
+// notifications sent per interval.
+type RateLimitConfig struct {
+	Limit    int            `yaml:"limit" json:"limit"`
+	Interval model.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	// Burst is the number of notifications that can be sent at once. It
+	// defaults to the limit.
+	Burst    int    `yaml:"burst,omitempty" json:"burst,omitempty"`
+	Overflow string `yaml:"overflow,omitempty" json:"overflow,omitempty"`
+}
+
+// DefaultRateLimitConfig defines default values for rate limits.
+var DefaultRateLimitConfig = RateLimitConfig{
+	Interval: model.Duration(time.Minute),
+	Overflow: RateLimitDrop,
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *RateLimitConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultRateLimitConfig
+	type plain RateLimitConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.Limit <= 0 {
+		return fmt.Errorf("limit of rate limit must be positive")
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("interval of rate limit must be positive")
+	}
+	if c.Burst < 0 {
+		return fmt.Errorf("burst of rate limit must not be negative")
+	}
+	if c.Burst == 0 {
+		c.Burst = c.Limit
+	}
+	switch c.Overflow {
+	case RateLimitDrop, RateLimitDelay, RateLimitSummarize:
+	default:
+		return fmt.Errorf("unknown overflow behavior %q of rate limit", c.Overflow)
+	}
+	return nil
+}
+
+// MuteTimeInterval is a named set of time intervals during which routes
+// referencing it don't send notifications.
+type MuteTimeInterval struct {
+	Name          string                      `yaml:"name" json:"name"`
+	TimeIntervals []timeinterval.TimeInterval `yaml:"time_intervals" json:"time_intervals"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (mt *MuteTimeInterval) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain MuteTimeInterval
+	if err := unmarshal((*plain)(mt)); err != nil {
+		return err
+	}
+	if mt.Name == "" {
+		return fmt.Errorf("missing name in mute time interval")
+	}
+	if len(mt.TimeIntervals) == 0 {
+		return fmt.Errorf("mute time interval %q must have at least one time interval", mt.Name)
+	}
+	return nil
+}
+
+// SilenceTemplate is a named set of matchers and defaults from which
+// silences can be created.
+type SilenceTemplate struct {
+	// A unique identifier for this template.
+	Name string `yaml:"name" json:"name"`
+	// Match defines the labels silenced alerts must have the given values for.
+	Match map[string]string `yaml:"match,omitempty" json:"match,omitempty"`
+	// MatchRE defines pairs like Match but does regular expression matching.
+	MatchRE map[string]string `yaml:"match_re,omitempty" json:"match_re,omitempty"`
+	// Duration and comment of silences created from the template.
+	Duration model.Duration `yaml:"duration,omitempty" json:"duration,omitempty"`
+	Comment  string         `yaml:"comment,omitempty" json:"comment,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (st *SilenceTemplate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain SilenceTemplate
+	if err := unmarshal((*plain)(st)); err != nil {
+		return err
+	}
+	if st.Name == "" {
+		return fmt.Errorf("missing name in silence template")
+	}
+	if len(st.Match) == 0 && len(st.MatchRE) == 0 {
+		return fmt.Errorf("silence template %q must have at least one matcher", st.Name)
+	}
+	for k := range st.Match {
+		if !model.LabelNameRE.MatchString(k) {
+			return fmt.Errorf("invalid label name %q", k)
+		}
+	}
+	for k, v := range st.MatchRE {
+		if !model.LabelNameRE.MatchString(k) {
+			return fmt.Errorf("invalid label name %q", k)
+		}
+		if _, err := regexp.Compile("^(?:" + v + ")$"); err != nil {
+			return fmt.Errorf("invalid regular expression %q: %s", v, err)
+		}
+	}
+	if st.Duration < 0 {
+		return fmt.Errorf("negative duration in silence template %q", st.Name)
+	}
+	if st.Duration == 0 {
+		st.Duration = DefaultSilenceTemplateDuration
+	}
+	return nil
+}
+
+// DefaultSilenceTemplateDuration is the duration of silences created from
+// templates that don't define one.
+var DefaultSilenceTemplateDuration = model.Duration(time.Hour)
+
 // Regexp encapsulates a regexp.Regexp and makes it YAML marshalable.
 type Regexp struct {
 	*regexp.Regexp
@@ -82,6 +82,114 @@ receivers:
 
 }
 
+func TestSilenceTemplateNameIsUnique(t *testing.T) {
+	in := `
+route:
+    receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+silence_templates:
+- name: 'deploy'
+  match:
+    job: 'api'
+- name: 'deploy'
+  match:
+    job: 'db'
+`
+	_, err := Load(in)
+
+	expected := "silence template name \"deploy\" is not unique"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
+func TestSilenceTemplateHasMatchers(t *testing.T) {
+	in := `
+route:
+    receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+silence_templates:
+- name: 'deploy'
+  comment: 'Deployment'
+`
+	_, err := Load(in)
+
+	expected := "silence template \"deploy\" must have at least one matcher"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
+func TestMuteTimeIntervalExists(t *testing.T) {
+	in := `
+route:
+    receiver: team-X
+    routes:
+    - receiver: team-X
+      mute_time_intervals:
+      - business-hours
+
+receivers:
+- name: 'team-X'
+
+mute_time_intervals:
+- name: 'weekends'
+  time_intervals:
+  - weekdays: ['saturday', 'sunday']
+`
+	_, err := Load(in)
+
+	expected := "undefined mute time interval \"business-hours\" used in route"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
+func TestRootRouteHasNoMuteTimeIntervals(t *testing.T) {
+	in := `
+route:
+    receiver: team-X
+    mute_time_intervals:
+    - weekends
+
+receivers:
+- name: 'team-X'
+
+mute_time_intervals:
+- name: 'weekends'
+  time_intervals:
+  - weekdays: ['saturday', 'sunday']
+`
+	_, err := Load(in)
+
+	expected := "root route must not have any mute time intervals"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
 func TestReceiverExists(t *testing.T) {
 	in := `
 route:
@@ -123,6 +231,123 @@ receivers:
 
 }
 
+func TestReceiverRateLimit(t *testing.T) {
+	in := `
+route:
+    receiver: team-X
+
+receivers:
+- name: 'team-X'
+  rate_limit:
+    limit: 10
+`
+	c, err := Load(in)
+	if err != nil {
+		t.Fatalf("Error parsing %s: %s", in, err)
+	}
+
+	expected := &RateLimitConfig{
+		Limit:    10,
+		Interval: model.Duration(time.Minute),
+		Burst:    10,
+		Overflow: RateLimitDrop,
+	}
+	if !reflect.DeepEqual(c.Receivers[0].RateLimit, expected) {
+		t.Errorf("\nexpected:\n%v\ngot:\n%v", expected, c.Receivers[0].RateLimit)
+	}
+
+	for in, expected := range map[string]string{
+		"{limit: 0}":                    "limit of rate limit must be positive",
+		"{limit: 1, interval: 0s}":      "interval of rate limit must be positive",
+		"{limit: 1, burst: -1}":         "burst of rate limit must not be negative",
+		"{limit: 1, overflow: 'queue'}": `unknown overflow behavior "queue" of rate limit`,
+	} {
+		_, err := Load("route: {receiver: team-X}\nreceivers: [{name: team-X, rate_limit: " + in + "}]")
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%q", expected)
+		}
+		if err.Error() != expected {
+			t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+		}
+	}
+}
+
+func TestReceiverIntegrations(t *testing.T) {
+	in := `
+route:
+    receiver: team-X
+
+receivers:
+- name: 'team-X'
+  webhook_configs:
+  - url: 'http://example.com/a'
+  - url: 'http://example.com/b'
+  email_configs:
+  - to: 'team-X@example.com'
+    from: 'alertmanager@example.com'
+    smarthost: 'smtp.example.com:25'
+- name: 'team-Y'
+`
+	c, err := Load(in)
+	if err != nil {
+		t.Fatalf("Error parsing %s: %s", in, err)
+	}
+
+	require.Equal(t, []string{"email", "webhook"}, c.Receivers[0].Integrations())
+	require.Empty(t, c.Receivers[1].Integrations())
+}
+
+func TestReceiverHTTPConfig(t *testing.T) {
+	in := `
+route:
+    receiver: team-X
+
+receivers:
+- name: 'team-X'
+  http_config:
+    proxy_url: 'http://proxy.example.com:3128'
+    dial_timeout: 5s
+    response_timeout: 30s
+    headers:
+      X-Scope: 'ops'
+  webhook_configs:
+  - url: 'http://example.com/a'
+  - url: 'http://example.com/b'
+    http_config:
+      bearer_token: 'secret'
+`
+	c, err := Load(in)
+	if err != nil {
+		t.Fatalf("Error parsing %s: %s", in, err)
+	}
+
+	rcv := c.Receivers[0]
+	require.Equal(t, rcv.HTTPConfig, rcv.WebhookConfigs[0].HTTPConfig)
+	require.Equal(t, "http://proxy.example.com:3128", rcv.HTTPConfig.ProxyURL.String())
+	require.Equal(t, model.Duration(5*time.Second), rcv.HTTPConfig.DialTimeout)
+	require.Equal(t, model.Duration(30*time.Second), rcv.HTTPConfig.ResponseTimeout)
+	require.Equal(t, map[string]Secret{"X-Scope": "ops"}, rcv.HTTPConfig.Headers)
+	require.Equal(t, commoncfg.Secret("secret"), rcv.WebhookConfigs[1].HTTPConfig.BearerToken)
+	require.Nil(t, rcv.WebhookConfigs[1].HTTPConfig.ProxyURL.URL)
+
+	for in, expected := range map[string]string{
+		"{headers: {'X Scope': ops}}":                                                              `invalid header name "X Scope" in http_config`,
+		"{bearer_token: a, bearer_token_file: b}":                                                  "at most one of bearer_token & bearer_token_file must be configured",
+		"{oauth2: {token_url: 'http://a'}}":                                                        "missing client_id in oauth2 config",
+		"{oauth2: {client_id: a}}":                                                                 "missing token_url in oauth2 config",
+		"{oauth2: {client_id: a, token_url: 'http://a'}, bearer_token: b}":                         "at most one of basic_auth, bearer_token, bearer_token_file & oauth2 must be configured",
+		"{oauth2: {client_id: a, token_url: 'http://a', client_secret: b, client_secret_file: c}}": "at most one of client_secret & client_secret_file must be configured in oauth2 config",
+	} {
+		_, err := Load("route: {receiver: team-X}\nreceivers: [{name: team-X, http_config: " + in + "}]")
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%q", expected)
+		}
+		if err.Error() != expected {
+			t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+		}
+	}
+}
+
 func TestGroupByHasNoDuplicatedLabels(t *testing.T) {
 	in := `
 route:
@@ -409,7 +634,7 @@ func TestEmptyFieldsAndRegex(t *testing.T) {
 	var expectedConf = Config{
 
 		Global: &GlobalConfig{
-			HTTPConfig:       &commoncfg.HTTPClientConfig{},
+			HTTPConfig:       &HTTPClientConfig{},
 			ResolveTimeout:   model.Duration(5 * time.Minute),
 			SMTPSmarthost:    "localhost:25",
 			SMTPFrom:         "alertmanager@example.org",
@@ -421,6 +646,10 @@ func TestEmptyFieldsAndRegex(t *testing.T) {
 			OpsGenieAPIURL:   mustParseURL("https://api.opsgenie.com/"),
 			WeChatAPIURL:     mustParseURL("https://qyapi.weixin.qq.com/cgi-bin/"),
 			VictorOpsAPIURL:  mustParseURL("https://alert.victorops.com/integrations/generic/20131114/alert/"),
+			TelegramAPIURL:   mustParseURL("https://api.telegram.org"),
+			TwilioAPIURL:     mustParseURL("https://api.twilio.com/2010-04-01/"),
+			WebexAPIURL:      mustParseURL("https://webexapis.com/v1/messages"),
+			GitHubAPIURL:     mustParseURL("https://api.github.com/"),
 		},
 
 		Templates: []string{
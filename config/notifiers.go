@@ -15,10 +15,13 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/http"
 	"strings"
 	"time"
 
 	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
 )
 
 var (
@@ -123,6 +126,177 @@ var (
 		MonitoringTool:    `{{ template "victorops.default.monitoring_tool" . }}`,
 	}
 
+	// DefaultMSTeamsConfig defines default values for Microsoft Teams configurations.
+	DefaultMSTeamsConfig = MSTeamsConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Title:      `{{ template "msteams.default.title" . }}`,
+		Summary:    `{{ template "msteams.default.summary" . }}`,
+		Text:       `{{ template "msteams.default.text" . }}`,
+		ThemeColor: `{{ template "msteams.default.theme_color" . }}`,
+	}
+
+	// DefaultTelegramConfig defines default values for Telegram configurations.
+	DefaultTelegramConfig = TelegramConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Message: `{{ template "telegram.default.message" . }}`,
+	}
+
+	// DefaultSNSConfig defines default values for SNS configurations.
+	DefaultSNSConfig = SNSConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Subject: `{{ template "sns.default.subject" . }}`,
+		Message: `{{ template "sns.default.message" . }}`,
+	}
+
+	// DefaultTwilioConfig defines default values for Twilio configurations.
+	DefaultTwilioConfig = TwilioConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Message: `{{ template "twilio.default.message" . }}`,
+	}
+
+	// DefaultDiscordConfig defines default values for Discord configurations.
+	DefaultDiscordConfig = DiscordConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Title:   `{{ template "discord.default.title" . }}`,
+		Message: `{{ template "discord.default.message" . }}`,
+	}
+
+	// DefaultMatrixConfig defines default values for Matrix configurations.
+	DefaultMatrixConfig = MatrixConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Message:     `{{ template "matrix.default.message" . }}`,
+		HTMLMessage: `{{ template "matrix.default.html_message" . }}`,
+	}
+
+	// DefaultIRCConfig defines default values for IRC configurations.
+	DefaultIRCConfig = IRCConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Nick:    "alertmanager",
+		Message: `{{ template "irc.default.message" . }}`,
+	}
+
+	// DefaultXMPPConfig defines default values for XMPP configurations.
+	DefaultXMPPConfig = XMPPConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		RoomNick: "alertmanager",
+		Message:  `{{ template "xmpp.default.message" . }}`,
+	}
+
+	// DefaultWebexConfig defines default values for Webex configurations.
+	DefaultWebexConfig = WebexConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Message: `{{ template "webex.default.message" . }}`,
+	}
+
+	// DefaultRocketchatConfig defines default values for Rocket.Chat configurations.
+	DefaultRocketchatConfig = RocketchatConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: false,
+		},
+		Color:     `{{ if eq .Status "firing" }}#d63232{{ else }}#2eb886{{ end }}`,
+		Alias:     `{{ template "rocketchat.default.alias" . }}`,
+		Emoji:     `{{ template "rocketchat.default.emoji" . }}`,
+		Avatar:    `{{ template "rocketchat.default.avatar" . }}`,
+		Title:     `{{ template "rocketchat.default.title" . }}`,
+		TitleLink: `{{ template "rocketchat.default.titlelink" . }}`,
+		Text:      `{{ template "rocketchat.default.text" . }}`,
+	}
+
+	// DefaultGoogleChatConfig defines default values for Google Chat configurations.
+	DefaultGoogleChatConfig = GoogleChatConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Title:    `{{ template "googlechat.default.title" . }}`,
+		Subtitle: `{{ template "googlechat.default.subtitle" . }}`,
+		Text:     `{{ template "googlechat.default.text" . }}`,
+	}
+
+	// DefaultExecConfig defines default values for exec configurations.
+	DefaultExecConfig = ExecConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Timeout: model.Duration(30 * time.Second),
+	}
+
+	// DefaultMQTTConfig defines default values for MQTT configurations.
+	DefaultMQTTConfig = MQTTConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+	}
+
+	// DefaultKafkaConfig defines default values for Kafka configurations.
+	DefaultKafkaConfig = KafkaConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Serialization: "json",
+	}
+
+	// DefaultJiraConfig defines default values for Jira configurations.
+	DefaultJiraConfig = JiraConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Summary:           `{{ template "jira.default.summary" . }}`,
+		Description:       `{{ template "jira.default.description" . }}`,
+		Priority:          `{{ template "jira.default.priority" . }}`,
+		ResolveTransition: "Done",
+	}
+
+	// DefaultServiceNowConfig defines default values for ServiceNow configurations.
+	DefaultServiceNowConfig = ServiceNowConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		ShortDescription: `{{ template "servicenow.default.short_description" . }}`,
+		Description:      `{{ template "servicenow.default.description" . }}`,
+		Urgency:          `{{ template "servicenow.default.urgency" . }}`,
+		Impact:           `{{ template "servicenow.default.impact" . }}`,
+		CloseCode:        "Solved (Permanently)",
+		CloseNotes:       `{{ template "servicenow.default.close_notes" . }}`,
+	}
+
+	// DefaultZendeskConfig defines default values for Zendesk configurations.
+	DefaultZendeskConfig = ZendeskConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Subject:  `{{ template "zendesk.default.subject" . }}`,
+		Comment:  `{{ template "zendesk.default.comment" . }}`,
+		Priority: `{{ template "zendesk.default.priority" . }}`,
+		Tags:     `{{ template "zendesk.default.tags" . }}`,
+	}
+
+	// DefaultGitHubConfig defines default values for GitHub configurations.
+	DefaultGitHubConfig = GitHubConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Title: `{{ template "github.default.title" . }}`,
+		Body:  `{{ template "github.default.body" . }}`,
+	}
+
 	// DefaultPushoverConfig defines default values for Pushover configurations.
 	DefaultPushoverConfig = PushoverConfig{
 		NotifierConfig: NotifierConfig{
@@ -194,7 +368,7 @@ func (c *EmailConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type PagerdutyConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 
-	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
 	ServiceKey  Secret            `yaml:"service_key,omitempty" json:"service_key,omitempty"`
 	RoutingKey  Secret            `yaml:"routing_key,omitempty" json:"routing_key,omitempty"`
@@ -287,7 +461,7 @@ func (c *SlackField) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type SlackConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 
-	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
 	APIURL *SecretURL `yaml:"api_url,omitempty" json:"api_url,omitempty"`
 
@@ -323,7 +497,7 @@ func (c *SlackConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type HipchatConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 
-	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
 	APIURL        *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
 	AuthToken     Secret `yaml:"auth_token,omitempty" json:"auth_token,omitempty"`
@@ -352,10 +526,19 @@ func (c *HipchatConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type WebhookConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 
-	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
 	// URL to send POST request to.
 	URL *URL `yaml:"url" json:"url"`
+	// Additional headers to send with the request.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	// Timeout of a single request. If zero, only the notification timeout
+	// applies.
+	Timeout model.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// Template of the request body replacing the default JSON payload.
+	Body string `yaml:"body,omitempty" json:"body,omitempty"`
+	// Content type of the templated body, JSON by default.
+	ContentType string `yaml:"content_type,omitempty" json:"content_type,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -371,6 +554,25 @@ func (c *WebhookConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if c.URL.Scheme != "https" && c.URL.Scheme != "http" {
 		return fmt.Errorf("scheme required for webhook url")
 	}
+	// Header names are case-insensitive, check for collisions.
+	normalizedHeaders := map[string]string{}
+	for h, v := range c.Headers {
+		normalized := http.CanonicalHeaderKey(h)
+		if normalized == "Content-Type" {
+			return fmt.Errorf("header %q cannot be set in webhook config", normalized)
+		}
+		if _, ok := normalizedHeaders[normalized]; ok {
+			return fmt.Errorf("duplicate header %q in webhook config", normalized)
+		}
+		normalizedHeaders[normalized] = v
+	}
+	c.Headers = normalizedHeaders
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative in webhook config")
+	}
+	if c.ContentType != "" && c.Body == "" {
+		return fmt.Errorf("content_type requires a body in webhook config")
+	}
 	return nil
 }
 
@@ -378,7 +580,7 @@ func (c *WebhookConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type WechatConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 
-	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
 	APISecret Secret `yaml:"api_secret,omitempty" json:"api_secret,omitempty"`
 	CorpID    string `yaml:"corp_id,omitempty" json:"corp_id,omitempty"`
@@ -410,7 +612,7 @@ func (c *WechatConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type OpsGenieConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 
-	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
 	APIKey      Secret            `yaml:"api_key,omitempty" json:"api_key,omitempty"`
 	APIURL      *URL              `yaml:"api_url,omitempty" json:"api_url,omitempty"`
@@ -435,7 +637,7 @@ func (c *OpsGenieConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 type VictorOpsConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 
-	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
 	APIKey            Secret `yaml:"api_key" json:"api_key"`
 	APIURL            *URL   `yaml:"api_url" json:"api_url"`
@@ -459,6 +661,663 @@ func (c *VictorOpsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	return nil
 }
 
+// MSTeamsConfig configures notifications via Microsoft Teams incoming webhooks.
+type MSTeamsConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	WebhookURL *SecretURL `yaml:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+	Title      string     `yaml:"title,omitempty" json:"title,omitempty"`
+	Summary    string     `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Text       string     `yaml:"text,omitempty" json:"text,omitempty"`
+	ThemeColor string     `yaml:"theme_color,omitempty" json:"theme_color,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *MSTeamsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultMSTeamsConfig
+	type plain MSTeamsConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.WebhookURL == nil {
+		return fmt.Errorf("missing webhook URL in Microsoft Teams config")
+	}
+	return nil
+}
+
+// TelegramConfig configures notifications via a Telegram bot.
+type TelegramConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	APIURL   *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	BotToken Secret `yaml:"bot_token,omitempty" json:"bot_token,omitempty"`
+	ChatID   int64  `yaml:"chat_id,omitempty" json:"chat_id,omitempty"`
+	// The thread of a forum supergroup to send messages to.
+	MessageThreadID      int64  `yaml:"message_thread_id,omitempty" json:"message_thread_id,omitempty"`
+	Message              string `yaml:"message,omitempty" json:"message,omitempty"`
+	ParseMode            string `yaml:"parse_mode,omitempty" json:"parse_mode,omitempty"`
+	DisableNotifications bool   `yaml:"disable_notifications,omitempty" json:"disable_notifications,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *TelegramConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultTelegramConfig
+	type plain TelegramConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.BotToken == "" {
+		return fmt.Errorf("missing bot_token in Telegram config")
+	}
+	if c.ChatID == 0 {
+		return fmt.Errorf("missing chat_id in Telegram config")
+	}
+	switch c.ParseMode {
+	case "", "Markdown", "MarkdownV2", "HTML":
+	default:
+		return fmt.Errorf("unknown parse_mode %q in Telegram config", c.ParseMode)
+	}
+	return nil
+}
+
+// SigV4Config configures AWS Signature Version 4 request signing.
+type SigV4Config struct {
+	Region string `yaml:"region,omitempty" json:"region,omitempty"`
+	// If the keys are not set, they are read from the AWS_ACCESS_KEY_ID,
+	// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.
+	AccessKey string `yaml:"access_key,omitempty" json:"access_key,omitempty"`
+	SecretKey Secret `yaml:"secret_key,omitempty" json:"secret_key,omitempty"`
+}
+
+// SNSConfig configures notifications via AWS SNS.
+type SNSConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	// The SNS API endpoint. Defaults to the endpoint of the region.
+	APIURL      *URL        `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	SigV4       SigV4Config `yaml:"sigv4,omitempty" json:"sigv4,omitempty"`
+	TopicARN    string      `yaml:"topic_arn,omitempty" json:"topic_arn,omitempty"`
+	PhoneNumber string      `yaml:"phone_number,omitempty" json:"phone_number,omitempty"`
+	Subject     string      `yaml:"subject,omitempty" json:"subject,omitempty"`
+	Message     string      `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *SNSConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultSNSConfig
+	type plain SNSConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if (c.TopicARN == "") == (c.PhoneNumber == "") {
+		return fmt.Errorf("exactly one of topic_arn and phone_number must be set in SNS config")
+	}
+	if c.SigV4.Region == "" && c.TopicARN != "" {
+		// Topic ARNs have the format arn:aws:sns:<region>:<account>:<name>.
+		if parts := strings.Split(c.TopicARN, ":"); len(parts) == 6 {
+			c.SigV4.Region = parts[3]
+		}
+	}
+	if c.SigV4.Region == "" {
+		return fmt.Errorf("missing region in SNS config")
+	}
+	if (c.SigV4.AccessKey == "") != (c.SigV4.SecretKey == "") {
+		return fmt.Errorf("access_key and secret_key must be set together in SNS config")
+	}
+	return nil
+}
+
+// TwilioConfig configures notifications via Twilio SMS.
+type TwilioConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	APIURL     *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	AccountSID string `yaml:"account_sid,omitempty" json:"account_sid,omitempty"`
+	AuthToken  Secret `yaml:"auth_token,omitempty" json:"auth_token,omitempty"`
+	From       string `yaml:"from,omitempty" json:"from,omitempty"`
+	To         string `yaml:"to,omitempty" json:"to,omitempty"`
+	Message    string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *TwilioConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultTwilioConfig
+	type plain TwilioConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.AccountSID == "" {
+		return fmt.Errorf("missing account_sid in Twilio config")
+	}
+	if c.AuthToken == "" {
+		return fmt.Errorf("missing auth_token in Twilio config")
+	}
+	if c.From == "" {
+		return fmt.Errorf("missing from number in Twilio config")
+	}
+	if c.To == "" {
+		return fmt.Errorf("missing to number in Twilio config")
+	}
+	return nil
+}
+
+// DiscordConfig configures notifications via Discord webhooks.
+type DiscordConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	WebhookURL *SecretURL `yaml:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+	Title      string     `yaml:"title,omitempty" json:"title,omitempty"`
+	Message    string     `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *DiscordConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultDiscordConfig
+	type plain DiscordConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.WebhookURL == nil {
+		return fmt.Errorf("missing webhook URL in Discord config")
+	}
+	return nil
+}
+
+// MatrixConfig configures notifications via Matrix. Only unencrypted
+// rooms are supported.
+type MatrixConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	HomeserverURL *URL   `yaml:"homeserver_url,omitempty" json:"homeserver_url,omitempty"`
+	AccessToken   Secret `yaml:"access_token,omitempty" json:"access_token,omitempty"`
+	RoomID        string `yaml:"room_id,omitempty" json:"room_id,omitempty"`
+	// The plain text message and its HTML formatted version.
+	Message     string `yaml:"message,omitempty" json:"message,omitempty"`
+	HTMLMessage string `yaml:"html_message,omitempty" json:"html_message,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *MatrixConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultMatrixConfig
+	type plain MatrixConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.HomeserverURL == nil {
+		return fmt.Errorf("missing homeserver_url in Matrix config")
+	}
+	if c.AccessToken == "" {
+		return fmt.Errorf("missing access_token in Matrix config")
+	}
+	if !strings.HasPrefix(c.RoomID, "!") {
+		return fmt.Errorf("invalid room_id %q in Matrix config, room IDs start with '!'", c.RoomID)
+	}
+	return nil
+}
+
+// IRCConfig configures notifications via IRC. The connection to the server
+// is kept open and shared by all receivers using the same server and nick.
+type IRCConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	// Server is the address of the server as host:port.
+	Server    string              `yaml:"server,omitempty" json:"server,omitempty"`
+	UseTLS    bool                `yaml:"use_tls,omitempty" json:"use_tls,omitempty"`
+	TLSConfig commoncfg.TLSConfig `yaml:"tls_config,omitempty" json:"tls_config,omitempty"`
+	Password  Secret              `yaml:"password,omitempty" json:"password,omitempty"`
+	Nick      string              `yaml:"nick,omitempty" json:"nick,omitempty"`
+	// NickServPassword is used to identify the nick with NickServ.
+	NickServPassword Secret `yaml:"nickserv_password,omitempty" json:"nickserv_password,omitempty"`
+	Channel          string `yaml:"channel,omitempty" json:"channel,omitempty"`
+	ChannelKey       Secret `yaml:"channel_key,omitempty" json:"channel_key,omitempty"`
+	// Message is sent as a single line, line breaks are replaced by spaces.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *IRCConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultIRCConfig
+	type plain IRCConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.Server == "" {
+		return fmt.Errorf("missing server in IRC config")
+	}
+	if _, _, err := net.SplitHostPort(c.Server); err != nil {
+		return fmt.Errorf("invalid server %q in IRC config: %s", c.Server, err)
+	}
+	if c.Nick == "" || strings.ContainsAny(c.Nick, " \r\n") {
+		return fmt.Errorf("invalid nick %q in IRC config", c.Nick)
+	}
+	if !strings.HasPrefix(c.Channel, "#") && !strings.HasPrefix(c.Channel, "&") {
+		return fmt.Errorf("invalid channel %q in IRC config, channels start with '#' or '&'", c.Channel)
+	}
+	if strings.ContainsAny(c.Channel, " ,\r\n") {
+		return fmt.Errorf("invalid channel %q in IRC config", c.Channel)
+	}
+	return nil
+}
+
+// XMPPConfig configures notifications via XMPP. Messages are sent to
+// the JIDs in To and to the multi-user chat rooms in Rooms.
+type XMPPConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	// JID is the address to log in with, optionally with a resource.
+	JID      string `yaml:"jid,omitempty" json:"jid,omitempty"`
+	Password Secret `yaml:"password,omitempty" json:"password,omitempty"`
+	// Server is the address of the server as host:port. It defaults to
+	// port 5222 of the domain of the JID.
+	Server    string              `yaml:"server,omitempty" json:"server,omitempty"`
+	DirectTLS bool                `yaml:"direct_tls,omitempty" json:"direct_tls,omitempty"`
+	TLSConfig commoncfg.TLSConfig `yaml:"tls_config,omitempty" json:"tls_config,omitempty"`
+	// AllowPlaintext allows authenticating without TLS if the server
+	// doesn't offer STARTTLS.
+	AllowPlaintext bool     `yaml:"allow_plaintext,omitempty" json:"allow_plaintext,omitempty"`
+	To             []string `yaml:"to,omitempty" json:"to,omitempty"`
+	Rooms          []string `yaml:"rooms,omitempty" json:"rooms,omitempty"`
+	RoomNick       string   `yaml:"room_nick,omitempty" json:"room_nick,omitempty"`
+	Message        string   `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *XMPPConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultXMPPConfig
+	type plain XMPPConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if i := strings.Index(c.JID, "@"); i <= 0 || i == len(c.JID)-1 {
+		return fmt.Errorf("invalid jid %q in XMPP config", c.JID)
+	}
+	if c.Password == "" {
+		return fmt.Errorf("missing password in XMPP config")
+	}
+	if c.Server != "" {
+		if _, _, err := net.SplitHostPort(c.Server); err != nil {
+			return fmt.Errorf("invalid server %q in XMPP config: %s", c.Server, err)
+		}
+	}
+	if len(c.To) == 0 && len(c.Rooms) == 0 {
+		return fmt.Errorf("one of to or rooms must be configured in XMPP config")
+	}
+	if len(c.Rooms) > 0 && c.RoomNick == "" {
+		return fmt.Errorf("missing room_nick in XMPP config")
+	}
+	return nil
+}
+
+// WebexConfig configures notifications via a Webex bot.
+type WebexConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	APIURL   *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	BotToken Secret `yaml:"bot_token,omitempty" json:"bot_token,omitempty"`
+	RoomID   string `yaml:"room_id,omitempty" json:"room_id,omitempty"`
+	// Message is formatted with Markdown.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *WebexConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultWebexConfig
+	type plain WebexConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.BotToken == "" {
+		return fmt.Errorf("missing bot_token in Webex config")
+	}
+	if c.RoomID == "" {
+		return fmt.Errorf("missing room_id in Webex config")
+	}
+	return nil
+}
+
+// RocketchatConfig configures notifications via a Rocket.Chat incoming
+// webhook. The message is sent as an attachment like Slack notifications.
+type RocketchatConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	WebhookURL *SecretURL `yaml:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+
+	// Channel overrides the channel of the webhook, (like #other-channel or @username).
+	Channel string `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Alias   string `yaml:"alias,omitempty" json:"alias,omitempty"`
+	Emoji   string `yaml:"emoji,omitempty" json:"emoji,omitempty"`
+	Avatar  string `yaml:"avatar,omitempty" json:"avatar,omitempty"`
+	Color   string `yaml:"color,omitempty" json:"color,omitempty"`
+
+	Title       string        `yaml:"title,omitempty" json:"title,omitempty"`
+	TitleLink   string        `yaml:"title_link,omitempty" json:"title_link,omitempty"`
+	Text        string        `yaml:"text,omitempty" json:"text,omitempty"`
+	Fields      []*SlackField `yaml:"fields,omitempty" json:"fields,omitempty"`
+	ShortFields bool          `yaml:"short_fields,omitempty" json:"short_fields,omitempty"`
+	ImageURL    string        `yaml:"image_url,omitempty" json:"image_url,omitempty"`
+	ThumbURL    string        `yaml:"thumb_url,omitempty" json:"thumb_url,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *RocketchatConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultRocketchatConfig
+	type plain RocketchatConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.WebhookURL == nil {
+		return fmt.Errorf("missing webhook_url in Rocket.Chat config")
+	}
+	return nil
+}
+
+// GoogleChatConfig configures notifications via a Google Chat space webhook.
+type GoogleChatConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	WebhookURL *SecretURL `yaml:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+	// Title and Subtitle are shown in the header of the card.
+	Title    string `yaml:"title,omitempty" json:"title,omitempty"`
+	Subtitle string `yaml:"subtitle,omitempty" json:"subtitle,omitempty"`
+	// Text is shown in notifications and clients not supporting cards.
+	Text string `yaml:"text,omitempty" json:"text,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *GoogleChatConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultGoogleChatConfig
+	type plain GoogleChatConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.WebhookURL == nil {
+		return fmt.Errorf("missing webhook_url in Google Chat config")
+	}
+	return nil
+}
+
+// ExecConfig configures notifications via a local command. The command
+// receives the webhook message on its standard input.
+type ExecConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	// Command is the path of the executable followed by its arguments. It
+	// isn't run by a shell.
+	Command []string       `yaml:"command,omitempty" json:"command,omitempty"`
+	Timeout model.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *ExecConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultExecConfig
+	type plain ExecConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if len(c.Command) == 0 || c.Command[0] == "" {
+		return fmt.Errorf("missing command in exec config")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive in exec config")
+	}
+	return nil
+}
+
+// MQTTConfig configures notifications published to an MQTT topic. The
+// payload is the webhook message.
+type MQTTConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	// BrokerURL is the address of the broker, like tcp://broker:1883.
+	// The schemes ssl, tls and mqtts connect with TLS.
+	BrokerURL *URL                `yaml:"broker_url,omitempty" json:"broker_url,omitempty"`
+	TLSConfig commoncfg.TLSConfig `yaml:"tls_config,omitempty" json:"tls_config,omitempty"`
+	// ClientID identifies the connection to the broker. Brokers close the
+	// connection of a client when another one connects with the same ID,
+	// so a fixed client_id must be unique among all Alertmanagers and
+	// integrations using the broker. By default every connection uses a
+	// random ID of the form alertmanager-<random>.
+	ClientID string `yaml:"client_id,omitempty" json:"client_id,omitempty"`
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password Secret `yaml:"password,omitempty" json:"password,omitempty"`
+	Topic    string `yaml:"topic,omitempty" json:"topic,omitempty"`
+	QoS      int    `yaml:"qos,omitempty" json:"qos,omitempty"`
+	Retain   bool   `yaml:"retain,omitempty" json:"retain,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *MQTTConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultMQTTConfig
+	type plain MQTTConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.BrokerURL == nil {
+		return fmt.Errorf("missing broker_url in MQTT config")
+	}
+	switch c.BrokerURL.Scheme {
+	case "tcp", "mqtt", "ssl", "tls", "mqtts":
+	default:
+		return fmt.Errorf("unsupported scheme %q for broker_url in MQTT config", c.BrokerURL.Scheme)
+	}
+	if c.BrokerURL.Port() == "" {
+		return fmt.Errorf("missing port in broker_url in MQTT config")
+	}
+	if c.Topic == "" || strings.ContainsAny(c.Topic, "#+") {
+		return fmt.Errorf("invalid topic %q in MQTT config", c.Topic)
+	}
+	if c.QoS < 0 || c.QoS > 2 {
+		return fmt.Errorf("invalid qos %d in MQTT config, must be 0, 1 or 2", c.QoS)
+	}
+	if c.Password != "" && c.Username == "" {
+		return fmt.Errorf("password requires a username in MQTT config")
+	}
+	return nil
+}
+
+// KafkaConfig configures notifications produced to a Kafka topic through a
+// Confluent REST Proxy. The value of the record is the webhook message.
+type KafkaConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	RESTProxyURL *URL   `yaml:"rest_proxy_url,omitempty" json:"rest_proxy_url,omitempty"`
+	Topic        string `yaml:"topic,omitempty" json:"topic,omitempty"`
+	// Key is the key of the record. It defaults to the hash of the group key,
+	// so that all notifications of a group are produced to one partition.
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
+	// Serialization is either json or avro.
+	Serialization string `yaml:"serialization,omitempty" json:"serialization,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *KafkaConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultKafkaConfig
+	type plain KafkaConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.RESTProxyURL == nil {
+		return fmt.Errorf("missing rest_proxy_url in Kafka config")
+	}
+	if c.Topic == "" {
+		return fmt.Errorf("missing topic in Kafka config")
+	}
+	switch c.Serialization {
+	case "json", "avro":
+	default:
+		return fmt.Errorf("unknown serialization %q in Kafka config, must be json or avro", c.Serialization)
+	}
+	return nil
+}
+
+// JiraConfig configures notifications creating Jira issues. There is one
+// open issue per alert group, which is found by a label derived from the
+// group key.
+type JiraConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	// APIURL is the URL of the REST API, like https://jira.example.org/rest/api/2/.
+	APIURL      *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	Project     string `yaml:"project,omitempty" json:"project,omitempty"`
+	IssueType   string `yaml:"issue_type,omitempty" json:"issue_type,omitempty"`
+	Summary     string `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Priority is the name of the priority of the issue. It isn't set if
+	// it's empty.
+	Priority string   `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Labels   []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// ResolveTransition is the name of the transition applied when the
+	// group is resolved. Issues are left open if it's empty.
+	ResolveTransition string `yaml:"resolve_transition,omitempty" json:"resolve_transition,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *JiraConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultJiraConfig
+	type plain JiraConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.APIURL == nil {
+		return fmt.Errorf("missing api_url in Jira config")
+	}
+	if c.Project == "" {
+		return fmt.Errorf("missing project in Jira config")
+	}
+	if c.IssueType == "" {
+		return fmt.Errorf("missing issue_type in Jira config")
+	}
+	for _, l := range c.Labels {
+		if l == "" || strings.ContainsAny(l, " \t") {
+			return fmt.Errorf("invalid label %q in Jira config, labels can't contain spaces", l)
+		}
+	}
+	return nil
+}
+
+// ServiceNowConfig configures notifications opening ServiceNow incidents.
+// There is one active incident per alert group, which is found by its
+// correlation ID.
+type ServiceNowConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	// InstanceURL is the URL of the instance, like https://example.service-now.com.
+	InstanceURL      *URL   `yaml:"instance_url,omitempty" json:"instance_url,omitempty"`
+	AssignmentGroup  string `yaml:"assignment_group,omitempty" json:"assignment_group,omitempty"`
+	ShortDescription string `yaml:"short_description,omitempty" json:"short_description,omitempty"`
+	Description      string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Urgency and Impact must render to 1 (high), 2 (medium) or 3 (low).
+	Urgency string `yaml:"urgency,omitempty" json:"urgency,omitempty"`
+	Impact  string `yaml:"impact,omitempty" json:"impact,omitempty"`
+	// CloseCode and CloseNotes are set when the incident is resolved.
+	CloseCode  string `yaml:"close_code,omitempty" json:"close_code,omitempty"`
+	CloseNotes string `yaml:"close_notes,omitempty" json:"close_notes,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *ServiceNowConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultServiceNowConfig
+	type plain ServiceNowConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.InstanceURL == nil {
+		return fmt.Errorf("missing instance_url in ServiceNow config")
+	}
+	if c.CloseCode == "" {
+		return fmt.Errorf("missing close_code in ServiceNow config")
+	}
+	return nil
+}
+
+// ZendeskConfig configures notifications creating Zendesk tickets. There is
+// one ticket per alert group, which is found by its external ID.
+type ZendeskConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	// APIURL is the URL of the API, like https://example.zendesk.com/api/v2/.
+	APIURL  *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	Subject string `yaml:"subject,omitempty" json:"subject,omitempty"`
+	// Comment is added to the ticket on each notification.
+	Comment string `yaml:"comment,omitempty" json:"comment,omitempty"`
+	// Priority must render to urgent, high, normal, low or nothing.
+	Priority string `yaml:"priority,omitempty" json:"priority,omitempty"`
+	// Tags is split into tags at whitespace and commas.
+	Tags string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *ZendeskConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultZendeskConfig
+	type plain ZendeskConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.APIURL == nil {
+		return fmt.Errorf("missing api_url in Zendesk config")
+	}
+	return nil
+}
+
+// GitHubConfig configures notifications filing GitHub issues. There is one
+// open issue per alert group, which is found by a marker in its body.
+type GitHubConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	APIURL *URL `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	// Repository is the full name of the repository, like owner/name.
+	Repository string   `yaml:"repository,omitempty" json:"repository,omitempty"`
+	Labels     []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Title      string   `yaml:"title,omitempty" json:"title,omitempty"`
+	Body       string   `yaml:"body,omitempty" json:"body,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *GitHubConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultGitHubConfig
+	type plain GitHubConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if parts := strings.Split(c.Repository, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid repository %q in GitHub config, must be owner/name", c.Repository)
+	}
+	for _, l := range c.Labels {
+		if l == "" || strings.Contains(l, ",") {
+			return fmt.Errorf("invalid label %q in GitHub config", l)
+		}
+	}
+	return nil
+}
+
 type duration time.Duration
 
 func (d *duration) UnmarshalText(text []byte) error {
@@ -476,7 +1335,7 @@ func (d duration) MarshalText() ([]byte, error) {
 type PushoverConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 
-	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	HTTPConfig *HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
 	UserKey  Secret   `yaml:"user_key,omitempty" json:"user_key,omitempty"`
 	Token    Secret   `yaml:"token,omitempty" json:"token,omitempty"`
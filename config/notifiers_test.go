@@ -212,6 +212,59 @@ url: 'http://example.com'
 	}
 }
 
+func TestWebhookHeaders(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in: `
+url: 'http://example.com'
+headers:
+  X-Api-Key: 'foo'
+  x-api-key: 'bar'
+`,
+			expected: "duplicate header \"X-Api-Key\" in webhook config",
+		},
+		{
+			in: `
+url: 'http://example.com'
+headers:
+  content-type: 'text/plain'
+`,
+			expected: "header \"Content-Type\" cannot be set in webhook config",
+		},
+	} {
+		var cfg WebhookConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestWebhookContentTypeRequiresBody(t *testing.T) {
+	in := `
+url: 'http://example.com'
+content_type: 'text/plain'
+`
+	var cfg WebhookConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+
+	expected := "content_type requires a body in webhook config"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%v", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%v\ngot:\n%v", expected, err.Error())
+	}
+}
+
 func TestWebhookPasswordIsObsfucated(t *testing.T) {
 	in := `
 url: 'http://example.com'
@@ -438,3 +491,579 @@ fields:
 func newBoolPointer(b bool) *bool {
 	return &b
 }
+
+func TestMSTeamsWebhookURLIsPresent(t *testing.T) {
+	in := `{}`
+	var cfg MSTeamsConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+
+	expected := "missing webhook URL in Microsoft Teams config"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%v", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%v\ngot:\n%v", expected, err.Error())
+	}
+}
+
+func TestTelegramConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `chat_id: 1234`,
+			expected: "missing bot_token in Telegram config",
+		},
+		{
+			in:       `bot_token: 'secret'`,
+			expected: "missing chat_id in Telegram config",
+		},
+		{
+			in: `
+bot_token: 'secret'
+chat_id: 1234
+parse_mode: 'Plain'
+`,
+			expected: "unknown parse_mode \"Plain\" in Telegram config",
+		},
+	} {
+		var cfg TelegramConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestSNSConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `{}`,
+			expected: "exactly one of topic_arn and phone_number must be set in SNS config",
+		},
+		{
+			in: `
+topic_arn: 'arn:aws:sns:us-east-1:123456789012:alerts'
+phone_number: '+15555550100'
+`,
+			expected: "exactly one of topic_arn and phone_number must be set in SNS config",
+		},
+		{
+			in:       `phone_number: '+15555550100'`,
+			expected: "missing region in SNS config",
+		},
+		{
+			in: `
+topic_arn: 'arn:aws:sns:us-east-1:123456789012:alerts'
+sigv4:
+  access_key: 'AKIDEXAMPLE'
+`,
+			expected: "access_key and secret_key must be set together in SNS config",
+		},
+	} {
+		var cfg SNSConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestSNSRegionFromTopicARN(t *testing.T) {
+	in := `topic_arn: 'arn:aws:sns:eu-west-1:123456789012:alerts'`
+	var cfg SNSConfig
+	if err := yaml.UnmarshalStrict([]byte(in), &cfg); err != nil {
+		t.Fatalf("no error expected, returned:\n%v", err.Error())
+	}
+	if cfg.SigV4.Region != "eu-west-1" {
+		t.Errorf("expected region eu-west-1, got %q", cfg.SigV4.Region)
+	}
+}
+
+func TestTwilioConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `{}`,
+			expected: "missing account_sid in Twilio config",
+		},
+		{
+			in:       `account_sid: 'AC123'`,
+			expected: "missing auth_token in Twilio config",
+		},
+		{
+			in: `
+account_sid: 'AC123'
+auth_token: 'secret'
+to: '+15555550100'
+`,
+			expected: "missing from number in Twilio config",
+		},
+		{
+			in: `
+account_sid: 'AC123'
+auth_token: 'secret'
+from: '+15555550100'
+`,
+			expected: "missing to number in Twilio config",
+		},
+	} {
+		var cfg TwilioConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestDiscordWebhookURLIsPresent(t *testing.T) {
+	in := `{}`
+	var cfg DiscordConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+
+	expected := "missing webhook URL in Discord config"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%v", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%v\ngot:\n%v", expected, err.Error())
+	}
+}
+
+func TestMatrixConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `{}`,
+			expected: "missing homeserver_url in Matrix config",
+		},
+		{
+			in:       `homeserver_url: 'https://matrix.example.org'`,
+			expected: "missing access_token in Matrix config",
+		},
+		{
+			in: `
+homeserver_url: 'https://matrix.example.org'
+access_token: 'secret'
+room_id: '#alerts:example.org'
+`,
+			expected: "invalid room_id \"#alerts:example.org\" in Matrix config, room IDs start with '!'",
+		},
+	} {
+		var cfg MatrixConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestIRCConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `{}`,
+			expected: "missing server in IRC config",
+		},
+		{
+			in:       `server: 'irc.example.org'`,
+			expected: "invalid server \"irc.example.org\" in IRC config: address irc.example.org: missing port in address",
+		},
+		{
+			in: `
+server: 'irc.example.org:6697'
+nick: 'alert manager'
+`,
+			expected: "invalid nick \"alert manager\" in IRC config",
+		},
+		{
+			in: `
+server: 'irc.example.org:6697'
+channel: 'alerts'
+`,
+			expected: "invalid channel \"alerts\" in IRC config, channels start with '#' or '&'",
+		},
+		{
+			in: `
+server: 'irc.example.org:6697'
+channel: '#alerts,#other'
+`,
+			expected: "invalid channel \"#alerts,#other\" in IRC config",
+		},
+	} {
+		var cfg IRCConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestXMPPConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `jid: 'example.org'`,
+			expected: "invalid jid \"example.org\" in XMPP config",
+		},
+		{
+			in:       `jid: 'am@example.org'`,
+			expected: "missing password in XMPP config",
+		},
+		{
+			in: `
+jid: 'am@example.org'
+password: 'secret'
+server: 'example.org'
+`,
+			expected: "invalid server \"example.org\" in XMPP config: address example.org: missing port in address",
+		},
+		{
+			in: `
+jid: 'am@example.org'
+password: 'secret'
+`,
+			expected: "one of to or rooms must be configured in XMPP config",
+		},
+		{
+			in: `
+jid: 'am@example.org'
+password: 'secret'
+rooms: ['ops@conference.example.org']
+room_nick: ''
+`,
+			expected: "missing room_nick in XMPP config",
+		},
+	} {
+		var cfg XMPPConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestWebexConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `room_id: 'room'`,
+			expected: "missing bot_token in Webex config",
+		},
+		{
+			in:       `bot_token: 'secret'`,
+			expected: "missing room_id in Webex config",
+		},
+	} {
+		var cfg WebexConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestRocketchatWebhookURLIsPresent(t *testing.T) {
+	in := `{}`
+	var cfg RocketchatConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+
+	expected := "missing webhook_url in Rocket.Chat config"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%v", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%v\ngot:\n%v", expected, err.Error())
+	}
+}
+
+func TestGoogleChatWebhookURLIsPresent(t *testing.T) {
+	in := `{}`
+	var cfg GoogleChatConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+
+	expected := "missing webhook_url in Google Chat config"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%v", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%v\ngot:\n%v", expected, err.Error())
+	}
+}
+
+func TestExecConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `{}`,
+			expected: "missing command in exec config",
+		},
+		{
+			in: `
+command: ['/usr/local/bin/page']
+timeout: 0s
+`,
+			expected: "timeout must be positive in exec config",
+		},
+	} {
+		var cfg ExecConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestMQTTConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `topic: 'alerts'`,
+			expected: "missing broker_url in MQTT config",
+		},
+		{
+			in:       `broker_url: 'http://broker:1883'`,
+			expected: "unsupported scheme \"http\" for broker_url in MQTT config",
+		},
+		{
+			in:       `broker_url: 'tcp://broker'`,
+			expected: "missing port in broker_url in MQTT config",
+		},
+		{
+			in: `
+broker_url: 'tcp://broker:1883'
+topic: 'alerts/#'
+`,
+			expected: "invalid topic \"alerts/#\" in MQTT config",
+		},
+		{
+			in: `
+broker_url: 'tcp://broker:1883'
+topic: 'alerts'
+qos: 3
+`,
+			expected: "invalid qos 3 in MQTT config, must be 0, 1 or 2",
+		},
+		{
+			in: `
+broker_url: 'tcp://broker:1883'
+topic: 'alerts'
+password: 'secret'
+`,
+			expected: "password requires a username in MQTT config",
+		},
+	} {
+		var cfg MQTTConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestKafkaConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `topic: 'alerts'`,
+			expected: "missing rest_proxy_url in Kafka config",
+		},
+		{
+			in:       `rest_proxy_url: 'http://localhost:8082'`,
+			expected: "missing topic in Kafka config",
+		},
+		{
+			in: `
+rest_proxy_url: 'http://localhost:8082'
+topic: 'alerts'
+serialization: 'protobuf'
+`,
+			expected: "unknown serialization \"protobuf\" in Kafka config, must be json or avro",
+		},
+	} {
+		var cfg KafkaConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestJiraConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `project: 'OPS'`,
+			expected: "missing api_url in Jira config",
+		},
+		{
+			in:       `api_url: 'https://jira.example.org/rest/api/2/'`,
+			expected: "missing project in Jira config",
+		},
+		{
+			in: `
+api_url: 'https://jira.example.org/rest/api/2/'
+project: 'OPS'
+`,
+			expected: "missing issue_type in Jira config",
+		},
+		{
+			in: `
+api_url: 'https://jira.example.org/rest/api/2/'
+project: 'OPS'
+issue_type: 'Bug'
+labels: ['on call']
+`,
+			expected: "invalid label \"on call\" in Jira config, labels can't contain spaces",
+		},
+	} {
+		var cfg JiraConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestServiceNowConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `assignment_group: 'ops'`,
+			expected: "missing instance_url in ServiceNow config",
+		},
+		{
+			in: `
+instance_url: 'https://example.service-now.com'
+close_code: ''
+`,
+			expected: "missing close_code in ServiceNow config",
+		},
+	} {
+		var cfg ServiceNowConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestZendeskAPIURLIsPresent(t *testing.T) {
+	in := `{}`
+	var cfg ZendeskConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+
+	expected := "missing api_url in Zendesk config"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%v", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%v\ngot:\n%v", expected, err.Error())
+	}
+}
+
+func TestGitHubConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `repository: 'web'`,
+			expected: "invalid repository \"web\" in GitHub config, must be owner/name",
+		},
+		{
+			in: `
+repository: 'acme/web'
+labels: ['alert,slo']
+`,
+			expected: "invalid label \"alert,slo\" in GitHub config",
+		},
+	} {
+		var cfg GitHubConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
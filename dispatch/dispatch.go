@@ -44,6 +44,7 @@ type Dispatcher struct {
 	mtx        sync.RWMutex
 
 	done   chan struct{}
+	drainc chan time.Duration
 	ctx    context.Context
 	cancel func()
 
@@ -65,6 +66,7 @@ func NewDispatcher(
 		route:   r,
 		marker:  mk,
 		timeout: to,
+		drainc:  make(chan time.Duration),
 		logger:  log.With(l, "component", "dispatcher"),
 	}
 	return disp
@@ -127,12 +129,104 @@ func (d *Dispatcher) run(it provider.AlertIterator) {
 
 			d.mtx.Unlock()
 
+		case timeout := <-d.drainc:
+			d.drain(timeout)
+			return
+
 		case <-d.ctx.Done():
 			return
 		}
 	}
 }
 
+// drain flushes all aggregation groups and waits for their notifications
+// to finish for at most the given timeout.
+func (d *Dispatcher) drain(timeout time.Duration) {
+	// Don't hold the lock while waiting, so the groups can still be listed.
+	d.mtx.Lock()
+	var groups []*aggrGroup
+	for _, ags := range d.aggrGroups {
+		for _, ag := range ags {
+			close(ag.drainc)
+			groups = append(groups, ag)
+		}
+	}
+	d.mtx.Unlock()
+
+	level.Info(d.logger).Log("msg", "Draining aggregation groups", "groups", len(groups), "timeout", timeout)
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for _, ag := range groups {
+		select {
+		case <-ag.done:
+		case <-deadline.C:
+			level.Warn(d.logger).Log("msg", "Draining aggregation groups timed out, pending notifications are dropped")
+			return
+		}
+	}
+}
+
+// Drain stops processing new alerts and immediately sends notifications
+// for all pending aggregation groups. It waits at most for the given timeout
+// for the notifications to finish. Notifications still in flight are aborted
+// when the dispatcher is stopped.
+func (d *Dispatcher) Drain(timeout time.Duration) {
+	if d == nil || d.cancel == nil {
+		return
+	}
+	select {
+	case d.drainc <- timeout:
+		<-d.done
+	case <-d.done:
+	}
+}
+
+// AlertGroup is a snapshot of an aggregation group of the dispatcher.
+type AlertGroup struct {
+	Labels   model.LabelSet
+	GroupKey string
+	Receiver string
+	Alerts   types.AlertSlice
+	// The time the next notification for the group is due.
+	NextFlush time.Time
+}
+
+// Groups returns snapshots of all current aggregation groups sorted by
+// their group key.
+func (d *Dispatcher) Groups() []*AlertGroup {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	var groups []*AlertGroup
+	for _, ags := range d.aggrGroups {
+		for _, ag := range ags {
+			alerts := make(types.AlertSlice, 0, ag.alerts.Count())
+			for a := range ag.alerts.List() {
+				alerts = append(alerts, a)
+			}
+			sort.Stable(alerts)
+
+			ag.mtx.RLock()
+			nextFlush := ag.nextFlush
+			ag.mtx.RUnlock()
+
+			groups = append(groups, &AlertGroup{
+				Labels:    ag.labels,
+				GroupKey:  ag.GroupKey(),
+				Receiver:  ag.opts.Receiver,
+				Alerts:    alerts,
+				NextFlush: nextFlush,
+			})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].GroupKey < groups[j].GroupKey
+	})
+	return groups
+}
+
 // Stop the dispatcher.
 func (d *Dispatcher) Stop() {
 	if d == nil || d.cancel == nil {
@@ -152,13 +246,7 @@ type notifyFunc func(context.Context, ...*types.Alert) bool
 // processAlert determines in which aggregation group the alert falls
 // and inserts it.
 func (d *Dispatcher) processAlert(alert *types.Alert, route *Route) {
-	groupLabels := model.LabelSet{}
-
-	for ln, lv := range alert.Labels {
-		if _, ok := route.RouteOpts.GroupBy[ln]; ok {
-			groupLabels[ln] = lv
-		}
-	}
+	groupLabels := route.GroupLabels(alert.Labels)
 
 	fp := groupLabels.Fingerprint()
 
@@ -202,11 +290,13 @@ type aggrGroup struct {
 	ctx     context.Context
 	cancel  func()
 	done    chan struct{}
+	drainc  chan struct{}
 	next    *time.Timer
 	timeout func(time.Duration) time.Duration
 
 	mtx        sync.RWMutex
 	hasFlushed bool
+	nextFlush  time.Time
 }
 
 // newAggrGroup returns a new aggregation group.
@@ -219,6 +309,8 @@ func newAggrGroup(ctx context.Context, labels model.LabelSet, r *Route, to func(
 		routeKey: r.Key(),
 		opts:     &r.RouteOpts,
 		timeout:  to,
+		done:     make(chan struct{}),
+		drainc:   make(chan struct{}),
 		alerts:   store.NewAlerts(15 * time.Minute),
 	}
 	ag.ctx, ag.cancel = context.WithCancel(ctx)
@@ -229,6 +321,7 @@ func newAggrGroup(ctx context.Context, labels model.LabelSet, r *Route, to func(
 	// Set an initial one-time wait before flushing
 	// the first batch of notifications.
 	ag.next = time.NewTimer(ag.opts.GroupWait)
+	ag.nextFlush = time.Now().Add(ag.opts.GroupWait)
 
 	return ag
 }
@@ -246,8 +339,6 @@ func (ag *aggrGroup) String() string {
 }
 
 func (ag *aggrGroup) run(nf notifyFunc) {
-	ag.done = make(chan struct{})
-
 	defer close(ag.done)
 	defer ag.next.Stop()
 
@@ -264,15 +355,12 @@ func (ag *aggrGroup) run(nf notifyFunc) {
 			// which usually only becomes apparent in tests.
 			ctx = notify.WithNow(ctx, now)
 
-			// Populate context with information needed along the pipeline.
-			ctx = notify.WithGroupKey(ctx, ag.GroupKey())
-			ctx = notify.WithGroupLabels(ctx, ag.labels)
-			ctx = notify.WithReceiverName(ctx, ag.opts.Receiver)
-			ctx = notify.WithRepeatInterval(ctx, ag.opts.RepeatInterval)
+			ctx = ag.withNotificationContext(ctx)
 
 			// Wait the configured interval before calling flush again.
 			ag.mtx.Lock()
 			ag.next.Reset(ag.opts.GroupInterval)
+			ag.nextFlush = now.Add(ag.opts.GroupInterval)
 			ag.hasFlushed = true
 			ag.mtx.Unlock()
 
@@ -282,12 +370,33 @@ func (ag *aggrGroup) run(nf notifyFunc) {
 
 			cancel()
 
+		case <-ag.drainc:
+			// Flush a last time and exit. The notifications are aborted
+			// when the dispatcher is stopped.
+			ctx := notify.WithNow(ag.ctx, time.Now())
+			ctx = ag.withNotificationContext(ctx)
+
+			ag.flush(func(alerts ...*types.Alert) bool {
+				return nf(ctx, alerts...)
+			})
+			return
+
 		case <-ag.ctx.Done():
 			return
 		}
 	}
 }
 
+// withNotificationContext populates the context with information needed
+// along the notification pipeline.
+func (ag *aggrGroup) withNotificationContext(ctx context.Context) context.Context {
+	ctx = notify.WithGroupKey(ctx, ag.GroupKey())
+	ctx = notify.WithGroupLabels(ctx, ag.labels)
+	ctx = notify.WithReceiverName(ctx, ag.opts.Receiver)
+	ctx = notify.WithRepeatInterval(ctx, ag.opts.RepeatInterval)
+	return notify.WithMuteTimeIntervals(ctx, ag.opts.MuteTimeIntervals)
+}
+
 func (ag *aggrGroup) stop() {
 	// Calling cancel will terminate all in-process notifications
 	// and the run() loop.
@@ -307,6 +416,7 @@ func (ag *aggrGroup) insert(alert *types.Alert) {
 	defer ag.mtx.Unlock()
 	if !ag.hasFlushed && alert.StartsAt.Add(ag.opts.GroupWait).Before(time.Now()) {
 		ag.next.Reset(0)
+		ag.nextFlush = time.Now()
 	}
 }
 
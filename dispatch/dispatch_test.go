@@ -22,9 +22,13 @@ import (
 
 	"github.com/go-kit/kit/log"
 	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
+	"gopkg.in/yaml.v2"
 
+	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/provider/mem"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -240,3 +244,135 @@ func TestAggrGroup(t *testing.T) {
 
 	ag.stop()
 }
+
+func TestDispatcherGroups(t *testing.T) {
+	in := `
+receiver: 'default'
+group_by: ['alertname']
+group_wait: 1h
+routes:
+- match:
+    env: 'prod'
+  receiver: 'prod'
+  group_by: ['alertname', 'cluster']
+`
+	var ctree config.Route
+	require.NoError(t, yaml.UnmarshalStrict([]byte(in), &ctree))
+	route := NewRoute(&ctree, nil)
+
+	marker := types.NewMarker()
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, log.NewNopLogger())
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	stage := notify.StageFunc(func(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		return ctx, nil, nil
+	})
+	dispatcher := NewDispatcher(alerts, route, stage, marker, nil, log.NewNopLogger())
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	newAlert := func(lset model.LabelSet) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels:   lset,
+				StartsAt: time.Now(),
+				EndsAt:   time.Now().Add(time.Hour),
+			},
+			UpdatedAt: time.Now(),
+		}
+	}
+	require.NoError(t, alerts.Put(
+		newAlert(model.LabelSet{"alertname": "a", "env": "prod", "cluster": "eu"}),
+		newAlert(model.LabelSet{"alertname": "a", "env": "prod", "cluster": "us"}),
+		newAlert(model.LabelSet{"alertname": "b", "env": "dev"}),
+		newAlert(model.LabelSet{"alertname": "b", "env": "test"}),
+	))
+
+	var groups []*AlertGroup
+	for i := 0; i < 100; i++ {
+		groups = dispatcher.Groups()
+		var n int
+		for _, g := range groups {
+			n += len(g.Alerts)
+		}
+		if n == 4 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Len(t, groups, 3)
+
+	require.Equal(t, model.LabelSet{"alertname": "a", "cluster": "eu"}, groups[0].Labels)
+	require.Equal(t, "prod", groups[0].Receiver)
+	require.Len(t, groups[0].Alerts, 1)
+	require.Equal(t, model.LabelSet{"alertname": "a", "cluster": "us"}, groups[1].Labels)
+
+	require.Equal(t, model.LabelSet{"alertname": "b"}, groups[2].Labels)
+	require.Equal(t, "default", groups[2].Receiver)
+	require.Len(t, groups[2].Alerts, 2)
+
+	for _, g := range groups {
+		require.True(t, g.NextFlush.After(time.Now().Add(59*time.Minute)), "unexpected next flush %s", g.NextFlush)
+	}
+}
+
+func TestDispatcherDrain(t *testing.T) {
+	in := `
+receiver: 'default'
+group_by: ['alertname']
+group_wait: 1h
+`
+	var ctree config.Route
+	require.NoError(t, yaml.UnmarshalStrict([]byte(in), &ctree))
+	route := NewRoute(&ctree, nil)
+
+	marker := types.NewMarker()
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, log.NewNopLogger())
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	var (
+		mtx        sync.Mutex
+		notified   = map[model.LabelValue]int{}
+		dispatcher *Dispatcher
+	)
+	stage := notify.StageFunc(func(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		// Listing the groups must not block while draining.
+		dispatcher.Groups()
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, a := range alerts {
+			notified[a.Labels["alertname"]]++
+		}
+		return ctx, nil, nil
+	})
+	dispatcher = NewDispatcher(alerts, route, stage, marker, nil, log.NewNopLogger())
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	now := time.Now()
+	for _, name := range []model.LabelValue{"a", "b"} {
+		require.NoError(t, alerts.Put(&types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": name},
+				StartsAt: now,
+				EndsAt:   now.Add(time.Hour),
+			},
+			UpdatedAt: now,
+		}))
+	}
+	for i := 0; i < 100 && len(dispatcher.Groups()) < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Len(t, dispatcher.Groups(), 2)
+
+	// The group wait has not passed yet, so the alerts are only sent
+	// because of the drain.
+	dispatcher.Drain(time.Second)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.Equal(t, map[model.LabelValue]int{"a": 1, "b": 1}, notified)
+}
@@ -78,6 +78,8 @@ func NewRoute(cr *config.Route, parent *Route) *Route {
 	if cr.RepeatInterval != nil {
 		opts.RepeatInterval = time.Duration(*cr.RepeatInterval)
 	}
+	// Mute time intervals are not inherited.
+	opts.MuteTimeIntervals = cr.MuteTimeIntervals
 
 	// Build matchers.
 	var matchers types.Matchers
@@ -138,6 +140,24 @@ func (r *Route) Match(lset model.LabelSet) []*Route {
 	return all
 }
 
+// GroupLabels returns the labels of lset the route groups alerts by.
+func (r *Route) GroupLabels(lset model.LabelSet) model.LabelSet {
+	groupLabels := model.LabelSet{}
+
+	for ln, lv := range lset {
+		if _, ok := r.RouteOpts.GroupBy[ln]; ok {
+			groupLabels[ln] = lv
+		}
+	}
+	return groupLabels
+}
+
+// GroupKey returns the key of the aggregation group an alert with the
+// given labels is placed into on this route.
+func (r *Route) GroupKey(lset model.LabelSet) string {
+	return fmt.Sprintf("%s:%s", r.Key(), r.GroupLabels(lset))
+}
+
 // Key returns a key for the route. It does not uniquely identify a the route in general.
 func (r *Route) Key() string {
 	b := make([]byte, 0, 1024)
@@ -163,6 +183,9 @@ type RouteOpts struct {
 	GroupWait      time.Duration
 	GroupInterval  time.Duration
 	RepeatInterval time.Duration
+
+	// The names of the time intervals during which notifications are muted.
+	MuteTimeIntervals []string
 }
 
 func (ro *RouteOpts) String() string {
@@ -181,11 +204,13 @@ func (ro *RouteOpts) MarshalJSON() ([]byte, error) {
 		GroupWait      time.Duration    `json:"groupWait"`
 		GroupInterval  time.Duration    `json:"groupInterval"`
 		RepeatInterval time.Duration    `json:"repeatInterval"`
+		MuteTimes      []string         `json:"muteTimeIntervals,omitempty"`
 	}{
 		Receiver:       ro.Receiver,
 		GroupWait:      ro.GroupWait,
 		GroupInterval:  ro.GroupInterval,
 		RepeatInterval: ro.RepeatInterval,
+		MuteTimes:      ro.MuteTimeIntervals,
 	}
 	for ln := range ro.GroupBy {
 		v.GroupBy = append(v.GroupBy, ln)
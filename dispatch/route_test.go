@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 
 	"github.com/prometheus/alertmanager/config"
@@ -258,3 +259,28 @@ routes:
 		}
 	}
 }
+
+func TestRouteGroupKey(t *testing.T) {
+	in := `
+receiver: 'notify-def'
+group_by: ['alertname']
+
+routes:
+- match:
+    owner: 'team-A'
+  group_by: ['alertname', 'cluster']
+`
+	var ctree config.Route
+	if err := yaml.UnmarshalStrict([]byte(in), &ctree); err != nil {
+		t.Fatal(err)
+	}
+	tree := NewRoute(&ctree, nil)
+
+	lset := model.LabelSet{"alertname": "foo", "cluster": "eu", "owner": "team-A"}
+	routes := tree.Match(lset)
+	require.Len(t, routes, 1)
+
+	require.Equal(t, model.LabelSet{"alertname": "foo", "cluster": "eu"}, routes[0].GroupLabels(lset))
+	require.Equal(t, `{}/{owner="team-A"}:{alertname="foo", cluster="eu"}`, routes[0].GroupKey(lset))
+	require.Equal(t, model.LabelSet{"alertname": "foo"}, tree.GroupLabels(lset))
+}
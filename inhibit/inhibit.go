@@ -137,6 +137,35 @@ func (ih *Inhibitor) Mutes(lset model.LabelSet) bool {
 	return false
 }
 
+// An Inhibition is an inhibition rule together with the firing source alerts
+// through which it inhibits a label set.
+type Inhibition struct {
+	Rule    *config.InhibitRule
+	Sources []*types.Alert
+}
+
+// Explain returns the inhibitions that currently apply to the given label set.
+// Unlike Mutes, it reports all of them and does not update the marker.
+func (ih *Inhibitor) Explain(lset model.LabelSet) []*Inhibition {
+	var res []*Inhibition
+
+	for _, r := range ih.rules {
+		if r.SourceMatchers.Match(lset) || !r.TargetMatchers.Match(lset) {
+			continue
+		}
+		var sources []*types.Alert
+		for a := range r.scache.List() {
+			if !a.Resolved() && r.equal(a, lset) {
+				sources = append(sources, a)
+			}
+		}
+		if len(sources) > 0 {
+			res = append(res, &Inhibition{Rule: r.config, Sources: sources})
+		}
+	}
+	return res
+}
+
 // An InhibitRule specifies that a class of (source) alerts should inhibit
 // notifications for another class of (target) alerts if all specified matching
 // labels are equal between the two alerts. This may be used to inhibit alerts
@@ -155,6 +184,8 @@ type InhibitRule struct {
 
 	// Cache of alerts matching source labels.
 	scache *store.Alerts
+	// The configuration the rule was created from.
+	config *config.InhibitRule
 }
 
 // NewInhibitRule returns a new InhibitRule based on a configuration definition.
@@ -188,24 +219,32 @@ func NewInhibitRule(cr *config.InhibitRule) *InhibitRule {
 		TargetMatchers: targetm,
 		Equal:          equal,
 		scache:         store.NewAlerts(15 * time.Minute),
+		config:         cr,
 	}
 }
 
 // hasEqual checks whether the source cache contains alerts matching
 // the equal labels for the given label set.
 func (r *InhibitRule) hasEqual(lset model.LabelSet) (model.Fingerprint, bool) {
-Outer:
 	for a := range r.scache.List() {
 		// The cache might be stale and contain resolved alerts.
 		if a.Resolved() {
 			continue
 		}
-		for n := range r.Equal {
-			if a.Labels[n] != lset[n] {
-				continue Outer
-			}
+		if r.equal(a, lset) {
+			return a.Fingerprint(), true
 		}
-		return a.Fingerprint(), true
 	}
 	return model.Fingerprint(0), false
 }
+
+// equal returns whether the source alert and the label set have the same
+// values for the equal labels of the rule.
+func (r *InhibitRule) equal(a *types.Alert, lset model.LabelSet) bool {
+	for n := range r.Equal {
+		if a.Labels[n] != lset[n] {
+			return false
+		}
+	}
+	return true
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/go-kit/kit/log"
 	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
 
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/provider"
@@ -199,6 +200,58 @@ func TestInhibitRuleMatches(t *testing.T) {
 	}
 }
 
+func TestInhibitorExplain(t *testing.T) {
+	t.Parallel()
+
+	rules := []*config.InhibitRule{
+		{
+			SourceMatch: map[string]string{"s": "1"},
+			TargetMatch: map[string]string{"t": "1"},
+			Equal:       model.LabelNames{"e"},
+		},
+		{
+			SourceMatch: map[string]string{"s": "2"},
+			TargetMatch: map[string]string{"t": "1"},
+		},
+	}
+	m := types.NewMarker()
+	ih := NewInhibitor(nil, rules, m, nopLogger)
+	now := time.Now()
+
+	newAlert := func(lset model.LabelSet) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels:   lset,
+				StartsAt: now.Add(-time.Minute),
+				EndsAt:   now.Add(time.Hour),
+			},
+		}
+	}
+	source1 := newAlert(model.LabelSet{"s": "1", "e": "1"})
+	source2 := newAlert(model.LabelSet{"s": "2"})
+	for i, a := range []*types.Alert{source1, source2} {
+		ih.rules[i].scache = store.NewAlerts(5 * time.Minute)
+		ih.rules[i].scache.Set(a)
+	}
+
+	res := ih.Explain(model.LabelSet{"t": "1", "e": "1"})
+	require.Len(t, res, 2)
+	require.Equal(t, rules[0], res[0].Rule)
+	require.Equal(t, []*types.Alert{source1}, res[0].Sources)
+	require.Equal(t, rules[1], res[1].Rule)
+	require.Equal(t, []*types.Alert{source2}, res[1].Sources)
+
+	res = ih.Explain(model.LabelSet{"t": "1", "e": "0"})
+	require.Len(t, res, 1)
+	require.Equal(t, rules[1], res[0].Rule)
+
+	require.Len(t, ih.Explain(model.LabelSet{"t": "0"}), 0)
+
+	// Explaining doesn't mark the label set as inhibited.
+	_, inhibited := m.Inhibited(model.LabelSet{"t": "1", "e": "1"}.Fingerprint())
+	require.False(t, inhibited)
+}
+
 type fakeAlerts struct {
 	alerts   []*types.Alert
 	finished chan struct{}
@@ -32,6 +32,7 @@ import (
 	"github.com/matttproud/golang_protobuf_extensions/pbutil"
 	"github.com/prometheus/alertmanager/cluster"
 	pb "github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/pkg/wal"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -80,6 +81,8 @@ type Log struct {
 
 	runInterval time.Duration
 	snapf       string
+	walf        string
+	wal         *wal.WAL
 	stopc       chan struct{}
 	done        func()
 
@@ -210,6 +213,17 @@ func WithSnapshot(sf string) Option {
 	}
 }
 
+// WithWAL configures a write-ahead log file to which every entry, logged
+// locally or received from a peer, is appended before it's applied. It is
+// replayed on top of the snapshot on startup and truncated whenever a
+// snapshot is saved, which requires WithSnapshot.
+func WithWAL(f string) Option {
+	return func(l *Log) error {
+		l.walf = f
+		return nil
+	}
+}
+
 func utcNow() time.Time {
 	return time.Now().UTC()
 }
@@ -294,6 +308,10 @@ func New(opts ...Option) (*Log, error) {
 		l.metrics = newMetrics(nil)
 	}
 
+	if l.walf != "" && l.snapf == "" {
+		return nil, fmt.Errorf("snapshot file must be set for the write-ahead log")
+	}
+
 	if l.snapf != "" {
 		if f, err := os.Open(l.snapf); !os.IsNotExist(err) {
 			if err != nil {
@@ -306,6 +324,13 @@ func New(opts ...Option) (*Log, error) {
 			}
 		}
 	}
+	if l.walf != "" {
+		w, err := wal.Open(l.walf, l.replay)
+		if err != nil {
+			return l, err
+		}
+		l.wal = w
+	}
 
 	go l.run()
 
@@ -340,14 +365,9 @@ func (l *Log) run() {
 		if l.snapf == "" {
 			return nil
 		}
-		f, err := openReplace(l.snapf)
-		if err != nil {
-			return err
-		}
-		if size, err = l.Snapshot(f); err != nil {
-			return err
-		}
-		return f.Close()
+		var err error
+		size, err = l.checkpoint(l.snapf)
+		return err
 	}
 
 Loop:
@@ -411,6 +431,11 @@ func (l *Log) Log(r *pb.Receiver, gkey string, firingAlerts, resolvedAlerts []ui
 	if err != nil {
 		return err
 	}
+	if l.wal != nil {
+		if err := l.wal.Log(b); err != nil {
+			return fmt.Errorf("writing to the write-ahead log: %s", err)
+		}
+	}
 	l.st.merge(e)
 	l.broadcast(b)
 
@@ -506,6 +531,53 @@ func (l *Log) Snapshot(w io.Writer) (int64, error) {
 	return io.Copy(w, bytes.NewReader(b))
 }
 
+// checkpoint writes a snapshot of the state to the file and truncates the
+// write-ahead log, as the snapshot contains all logged entries.
+func (l *Log) checkpoint(filename string) (int64, error) {
+	start := time.Now()
+	defer func() { l.metrics.snapshotDuration.Observe(time.Since(start).Seconds()) }()
+
+	// Entries must not be logged between writing the snapshot and
+	// truncating the log.
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	b, err := l.st.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	f, err := openReplace(filename)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.File.Close()
+		os.Remove(f.File.Name())
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	if l.wal != nil {
+		if err := l.wal.Truncate(); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(b)), nil
+}
+
+// replay applies a record of the write-ahead log to the state.
+func (l *Log) replay(b []byte) error {
+	st, err := decodeState(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for _, e := range st {
+		l.st.merge(e)
+	}
+	return nil
+}
+
 // MarshalBinary serializes all contents of the notification log.
 func (l *Log) MarshalBinary() ([]byte, error) {
 	l.mtx.Lock()
@@ -523,6 +595,25 @@ func (l *Log) Merge(b []byte) error {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 
+	if l.wal != nil {
+		// Only log the entries which are merged as the message may contain
+		// outdated ones.
+		var records [][]byte
+		for _, e := range st {
+			if prev, ok := l.st[stateKey(string(e.Entry.GroupKey), e.Entry.Receiver)]; ok && !prev.Entry.Timestamp.Before(e.Entry.Timestamp) {
+				continue
+			}
+			rb, err := marshalMeshEntry(e)
+			if err != nil {
+				return err
+			}
+			records = append(records, rb)
+		}
+		if err := l.wal.Log(records...); err != nil {
+			return fmt.Errorf("writing to the write-ahead log: %s", err)
+		}
+	}
+
 	for _, e := range st {
 		if merged := l.st.merge(e); merged && !cluster.OversizedMessage(b) {
 			// If this is the first we've seen the message and it's
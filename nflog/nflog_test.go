@@ -123,6 +123,60 @@ func TestLogSnapshot(t *testing.T) {
 	}
 }
 
+func TestLogWAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nflog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := []Option{
+		WithRetention(time.Hour),
+		WithSnapshot(filepath.Join(dir, "nflog")),
+		WithWAL(filepath.Join(dir, "nflog.wal")),
+	}
+	l1, err := New(opts...)
+	require.NoError(t, err)
+
+	recv := &pb.Receiver{GroupName: "abc", Integration: "test1", Idx: 1}
+	require.NoError(t, l1.Log(recv, "key1", []uint64{1}, nil))
+
+	// Entries are recoverable without any snapshot.
+	l2, err := New(opts...)
+	require.NoError(t, err)
+	require.Equal(t, l1.st, l2.st)
+
+	// Entries received from peers are logged as well.
+	now := utcNow()
+	b, err := marshalMeshEntry(&pb.MeshEntry{
+		Entry: &pb.Entry{
+			GroupKey:  []byte("key2"),
+			Receiver:  recv,
+			Timestamp: now,
+		},
+		ExpiresAt: now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.NoError(t, l1.Merge(b))
+
+	l3, err := New(opts...)
+	require.NoError(t, err)
+	require.Len(t, l3.st, 2)
+	require.Equal(t, l1.st, l3.st)
+
+	// Snapshots truncate the log and entries after them are replayed on
+	// top.
+	_, err = l1.checkpoint(filepath.Join(dir, "nflog"))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), l1.wal.Size())
+	require.NoError(t, l1.Log(recv, "key1", []uint64{2}, nil))
+
+	l4, err := New(opts...)
+	require.NoError(t, err)
+	require.Equal(t, l1.st, l4.st)
+
+	_, err = New(WithWAL(filepath.Join(dir, "nflog.wal")))
+	require.EqualError(t, err, "snapshot file must be set for the write-ahead log")
+}
+
 func TestReplaceFile(t *testing.T) {
 	dir, err := ioutil.TempDir("", "replace_file")
 	require.NoError(t, err, "creating temp dir failed")
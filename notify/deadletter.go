@@ -0,0 +1,277 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/satori/go.uuid"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// ErrDeadLetterNotFound is returned if no dead letter exists for an ID.
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter is a notification which failed after all retries.
+type DeadLetter struct {
+	ID          string         `json:"id"`
+	Timestamp   time.Time      `json:"timestamp"`
+	Receiver    string         `json:"receiver"`
+	Integration string         `json:"integration"`
+	Index       int            `json:"index"`
+	GroupKey    string         `json:"groupKey"`
+	GroupLabels model.LabelSet `json:"groupLabels"`
+	Alerts      []*types.Alert `json:"alerts"`
+	Error       string         `json:"error"`
+}
+
+// DeadLetters stores failed notifications so they can be inspected and
+// replayed manually. The oldest ones are removed first when the maximum
+// number of dead letters is reached.
+type DeadLetters struct {
+	max    int
+	snapf  string
+	logger log.Logger
+	now    func() time.Time
+
+	mtx     sync.Mutex
+	entries []*DeadLetter
+	// The integrations of the current configuration by receiver, name and
+	// index, used for replaying.
+	integrations map[string]Integration
+}
+
+// NewDeadLetters returns a store keeping up to max dead letters. If the
+// snapshot file is set, the dead letters are loaded from it and it is
+// rewritten on every change.
+func NewDeadLetters(max int, snapf string, l log.Logger) (*DeadLetters, error) {
+	if l == nil {
+		l = log.NewNopLogger()
+	}
+	d := &DeadLetters{
+		max:          max,
+		snapf:        snapf,
+		logger:       l,
+		now:          time.Now,
+		entries:      []*DeadLetter{},
+		integrations: map[string]Integration{},
+	}
+	if snapf == "" {
+		return d, nil
+	}
+	b, err := ioutil.ReadFile(snapf)
+	if os.IsNotExist(err) {
+		return d, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &d.entries); err != nil {
+		return nil, fmt.Errorf("decoding dead letters: %s", err)
+	}
+	return d, nil
+}
+
+func integrationKey(receiver, name string, idx int) string {
+	return fmt.Sprintf("%s/%s/%d", receiver, name, idx)
+}
+
+// setIntegrations replaces the integrations used for replaying.
+func (d *DeadLetters) setIntegrations(integrations map[string]Integration) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.integrations = integrations
+}
+
+// Add stores a failed notification.
+func (d *DeadLetters) Add(dl *DeadLetter) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	dl.ID = uuid.NewV4().String()
+	dl.Timestamp = d.now()
+	d.entries = append(d.entries, dl)
+	if d.max > 0 && len(d.entries) > d.max {
+		d.entries = d.entries[len(d.entries)-d.max:]
+	}
+	d.persist()
+}
+
+// List returns the stored dead letters, the oldest first.
+func (d *DeadLetters) List() []*DeadLetter {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	res := make([]*DeadLetter, 0, len(d.entries))
+	for _, dl := range d.entries {
+		c := *dl
+		res = append(res, &c)
+	}
+	return res
+}
+
+// Delete removes the dead letter with the given ID.
+func (d *DeadLetters) Delete(id string) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	for i, dl := range d.entries {
+		if dl.ID == id {
+			d.entries = append(d.entries[:i:i], d.entries[i+1:]...)
+			d.persist()
+			return nil
+		}
+	}
+	return ErrDeadLetterNotFound
+}
+
+// Replay sends the dead letter with the given ID again through the
+// integration it failed on, without retrying. It is removed if the
+// notification succeeds and records the new error otherwise.
+func (d *DeadLetters) Replay(ctx context.Context, id string) error {
+	d.mtx.Lock()
+	var dl *DeadLetter
+	for _, e := range d.entries {
+		if e.ID == id {
+			dl = e
+			break
+		}
+	}
+	if dl == nil {
+		d.mtx.Unlock()
+		return ErrDeadLetterNotFound
+	}
+	i, ok := d.integrations[integrationKey(dl.Receiver, dl.Integration, dl.Index)]
+	d.mtx.Unlock()
+	if !ok {
+		return fmt.Errorf("integration %s[%d] of receiver %q does not exist anymore", dl.Integration, dl.Index, dl.Receiver)
+	}
+
+	var firing, resolved []uint64
+	for _, a := range dl.Alerts {
+		if a.Resolved() {
+			resolved = append(resolved, hashAlert(a))
+		} else {
+			firing = append(firing, hashAlert(a))
+		}
+	}
+	ctx = WithReceiverName(ctx, dl.Receiver)
+	ctx = WithGroupKey(ctx, dl.GroupKey)
+	ctx = WithGroupLabels(ctx, dl.GroupLabels)
+	ctx = WithFiringAlerts(ctx, firing)
+	ctx = WithResolvedAlerts(ctx, resolved)
+	ctx = WithNow(ctx, d.now())
+
+	_, err := i.Notify(ctx, dl.Alerts...)
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if err != nil {
+		numFailedNotifications.WithLabelValues(i.name).Inc()
+		dl.Error = err.Error()
+		d.persist()
+		return err
+	}
+	numNotifications.WithLabelValues(i.name).Inc()
+	for j, e := range d.entries {
+		if e == dl {
+			d.entries = append(d.entries[:j:j], d.entries[j+1:]...)
+			break
+		}
+	}
+	d.persist()
+	return nil
+}
+
+// persist writes the dead letters to the snapshot file. It must be called
+// with the lock held.
+func (d *DeadLetters) persist() {
+	if d.snapf == "" {
+		return
+	}
+	b, err := json.Marshal(d.entries)
+	if err == nil {
+		// Write to a temporary file first to not corrupt the snapshot.
+		tmp := d.snapf + ".tmp"
+		if err = ioutil.WriteFile(tmp, b, 0666); err == nil {
+			err = os.Rename(tmp, d.snapf)
+		}
+	}
+	if err != nil {
+		level.Error(d.logger).Log("msg", "Writing dead letters failed", "err", err)
+	}
+}
+
+// DeadLetterStage stores the notifications its inner stage failed to send.
+type DeadLetterStage struct {
+	stage       Stage
+	deadLetters *DeadLetters
+	receiver    string
+	integration Integration
+}
+
+// NewDeadLetterStage returns a new DeadLetterStage wrapping the stage sending
+// the notifications of the integration.
+func NewDeadLetterStage(s Stage, d *DeadLetters, receiver string, i Integration) *DeadLetterStage {
+	return &DeadLetterStage{
+		stage:       s,
+		deadLetters: d,
+		receiver:    receiver,
+		integration: i,
+	}
+}
+
+// Exec implements the Stage interface.
+func (n *DeadLetterStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	ctx, res, err := n.stage.Exec(ctx, l, alerts...)
+	// Notifications are canceled when the dispatcher is stopped, the alert
+	// groups of the new one will send them again.
+	if err == nil || ctx.Err() == context.Canceled {
+		return ctx, res, err
+	}
+
+	// Copy the alerts as they continue to be updated by the dispatcher.
+	copies := make([]*types.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		c := *a
+		copies = append(copies, &c)
+	}
+	gkey, _ := GroupKey(ctx)
+	groupLabels, _ := GroupLabels(ctx)
+	n.deadLetters.Add(&DeadLetter{
+		Receiver:    n.receiver,
+		Integration: n.integration.name,
+		Index:       n.integration.idx,
+		GroupKey:    gkey,
+		GroupLabels: groupLabels,
+		Alerts:      copies,
+		Error:       err.Error(),
+	})
+	level.Warn(l).Log("msg", "Notification added to dead letters", "integration", n.integration.name, "receiver", n.receiver, "err", err)
+
+	return ctx, res, err
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestDeadLetters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deadletters")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	snapf := filepath.Join(dir, "deadletters")
+
+	d, err := NewDeadLetters(2, snapf, nil)
+	require.NoError(t, err)
+
+	var (
+		fail = true
+		sent []*types.Alert
+		key  string
+	)
+	i := Integration{
+		name: "webhook",
+		idx:  1,
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			if fail {
+				return false, errors.New("bad request")
+			}
+			key, _ = GroupKey(ctx)
+			sent = alerts
+			return false, nil
+		}),
+		conf: notifierConfigFunc(func() bool { return false }),
+	}
+	d.setIntegrations(map[string]Integration{integrationKey("team-X", "webhook", 1): i})
+	s := NewDeadLetterStage(NewRetryStage(i, "team-X", 0), d, "team-X", i)
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{"alertname": "HighLatency"},
+			EndsAt: time.Now().Add(time.Hour),
+		},
+	}
+	ctx := WithGroupKey(context.Background(), "{}:{alertname=\"HighLatency\"}")
+	ctx = WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency"})
+	ctx = WithFiringAlerts(ctx, []uint64{hashAlert(alert)})
+
+	// Only the latest dead letters are kept.
+	for n := 0; n < 3; n++ {
+		_, _, err = s.Exec(ctx, log.NewNopLogger(), alert)
+		require.Error(t, err)
+	}
+	entries := d.List()
+	require.Len(t, entries, 2)
+	dl := entries[0]
+	require.Equal(t, "team-X", dl.Receiver)
+	require.Equal(t, "webhook", dl.Integration)
+	require.Equal(t, 1, dl.Index)
+	require.Equal(t, "{}:{alertname=\"HighLatency\"}", dl.GroupKey)
+	require.Equal(t, model.LabelSet{"alertname": "HighLatency"}, dl.GroupLabels)
+	require.Equal(t, `cancelling notify retry for "webhook" due to unrecoverable error: bad request`, dl.Error)
+	require.Len(t, dl.Alerts, 1)
+
+	// The dead letters are restored from the snapshot.
+	d, err = NewDeadLetters(2, snapf, nil)
+	require.NoError(t, err)
+	d.setIntegrations(map[string]Integration{integrationKey("team-X", "webhook", 1): i})
+	require.Len(t, d.List(), 2)
+	require.Equal(t, dl.ID, d.List()[0].ID)
+
+	require.Equal(t, ErrDeadLetterNotFound, d.Replay(context.Background(), "unknown"))
+
+	// A failed replay records the error and keeps the dead letter.
+	require.EqualError(t, d.Replay(context.Background(), dl.ID), "bad request")
+	require.Equal(t, "bad request", d.List()[0].Error)
+
+	fail = false
+	require.NoError(t, d.Replay(context.Background(), dl.ID))
+	require.Equal(t, dl.GroupKey, key)
+	require.Equal(t, alert.Labels, sent[0].Labels)
+	require.Len(t, d.List(), 1)
+
+	require.NoError(t, d.Delete(d.List()[0].ID))
+	require.Empty(t, d.List())
+	require.Equal(t, ErrDeadLetterNotFound, d.Delete(dl.ID))
+}
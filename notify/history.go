@@ -0,0 +1,168 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Notification records a notification sent for an alert group by an
+// integration of a receiver.
+type Notification struct {
+	Timestamp   time.Time      `json:"timestamp"`
+	Receiver    string         `json:"receiver"`
+	Integration string         `json:"integration"`
+	Index       int            `json:"index"`
+	GroupKey    string         `json:"groupKey"`
+	GroupLabels model.LabelSet `json:"groupLabels"`
+	// Fingerprints of the firing and resolved alerts of the notification.
+	Firing   []string `json:"firing"`
+	Resolved []string `json:"resolved"`
+	// Error of the last attempt if the notification failed.
+	Error string `json:"error,omitempty"`
+}
+
+// includes reports whether the alert with the given fingerprint was part of
+// the notification.
+func (n *Notification) includes(fp string) bool {
+	for _, f := range n.Firing {
+		if f == fp {
+			return true
+		}
+	}
+	for _, f := range n.Resolved {
+		if f == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationHistory keeps the notifications sent by this Alertmanager in
+// memory. The oldest ones are removed first when the maximum number of
+// notifications is reached.
+type NotificationHistory struct {
+	max int
+	now func() time.Time
+
+	mtx     sync.Mutex
+	entries []*Notification
+}
+
+// NewNotificationHistory returns a history keeping up to max notifications.
+func NewNotificationHistory(max int) *NotificationHistory {
+	return &NotificationHistory{
+		max:     max,
+		now:     time.Now,
+		entries: []*Notification{},
+	}
+}
+
+// Add records a notification.
+func (h *NotificationHistory) Add(n *Notification) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	n.Timestamp = h.now()
+	h.entries = append(h.entries, n)
+	if h.max > 0 && len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+}
+
+// Query returns the notifications of the receiver which included the alert
+// with the given fingerprint, the oldest first. Empty arguments match all
+// notifications.
+func (h *NotificationHistory) Query(receiver, fingerprint string) []*Notification {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	res := []*Notification{}
+	for _, n := range h.entries {
+		if receiver != "" && n.Receiver != receiver {
+			continue
+		}
+		if fingerprint != "" && !n.includes(fingerprint) {
+			continue
+		}
+		c := *n
+		res = append(res, &c)
+	}
+	return res
+}
+
+// HistoryStage records the notifications its inner stage sends, including
+// failed ones, in the notification history.
+type HistoryStage struct {
+	stage       Stage
+	history     *NotificationHistory
+	receiver    string
+	integration Integration
+}
+
+// NewHistoryStage returns a new HistoryStage wrapping the stage sending the
+// notifications of the integration.
+func NewHistoryStage(s Stage, h *NotificationHistory, receiver string, i Integration) *HistoryStage {
+	return &HistoryStage{
+		stage:       s,
+		history:     h,
+		receiver:    receiver,
+		integration: i,
+	}
+}
+
+// Exec implements the Stage interface.
+func (n *HistoryStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	ctx, res, err := n.stage.Exec(ctx, l, alerts...)
+	// Notifications canceled by stopping the dispatcher are sent again by
+	// the new one.
+	if err != nil && ctx.Err() == context.Canceled {
+		return ctx, res, err
+	}
+
+	entry := &Notification{
+		Receiver:    n.receiver,
+		Integration: n.integration.name,
+		Index:       n.integration.idx,
+		Firing:      []string{},
+		Resolved:    []string{},
+	}
+	for _, a := range alerts {
+		if !a.Resolved() {
+			entry.Firing = append(entry.Firing, a.Fingerprint().String())
+		} else if n.integration.conf.SendResolved() {
+			entry.Resolved = append(entry.Resolved, a.Fingerprint().String())
+		}
+	}
+	// Nothing is sent for resolved alerts if the integration doesn't
+	// notify about them.
+	if len(entry.Firing) == 0 && len(entry.Resolved) == 0 {
+		return ctx, res, err
+	}
+	entry.GroupKey, _ = GroupKey(ctx)
+	entry.GroupLabels, _ = GroupLabels(ctx)
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	n.history.Add(entry)
+
+	return ctx, res, err
+}
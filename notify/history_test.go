@@ -0,0 +1,112 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestNotificationHistory(t *testing.T) {
+	h := NewNotificationHistory(3)
+
+	var (
+		fail         bool
+		sendResolved bool
+	)
+	i := Integration{
+		name: "webhook",
+		idx:  1,
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			if fail {
+				return false, errors.New("bad request")
+			}
+			return false, nil
+		}),
+		conf: notifierConfigFunc(func() bool { return sendResolved }),
+	}
+	s := NewHistoryStage(NewRetryStage(i, "team-X", 0), h, "team-X", i)
+
+	var (
+		firing = &types.Alert{
+			Alert: model.Alert{
+				Labels: model.LabelSet{"alertname": "HighLatency"},
+				EndsAt: time.Now().Add(time.Hour),
+			},
+		}
+		resolved = &types.Alert{
+			Alert: model.Alert{
+				Labels: model.LabelSet{"alertname": "HighErrorRate"},
+				EndsAt: time.Now().Add(-time.Minute),
+			},
+		}
+		ffp = firing.Fingerprint().String()
+		rfp = resolved.Fingerprint().String()
+	)
+	exec := func(alerts ...*types.Alert) error {
+		var fps []uint64
+		for _, a := range alerts {
+			if !a.Resolved() {
+				fps = append(fps, hashAlert(a))
+			}
+		}
+		ctx := WithGroupKey(context.Background(), "{}:{}")
+		ctx = WithGroupLabels(ctx, model.LabelSet{})
+		ctx = WithFiringAlerts(ctx, fps)
+		_, _, err := s.Exec(ctx, log.NewNopLogger(), alerts...)
+		return err
+	}
+
+	require.NoError(t, exec(firing, resolved))
+	n := h.Query("", "")
+	require.Len(t, n, 1)
+	require.Equal(t, "team-X", n[0].Receiver)
+	require.Equal(t, "webhook", n[0].Integration)
+	require.Equal(t, 1, n[0].Index)
+	require.Equal(t, "{}:{}", n[0].GroupKey)
+	require.Equal(t, []string{ffp}, n[0].Firing)
+	require.Equal(t, []string{}, n[0].Resolved)
+	require.Empty(t, n[0].Error)
+
+	// Nothing is sent for resolved alerts only without send_resolved.
+	require.NoError(t, exec(resolved))
+	require.Len(t, h.Query("", ""), 1)
+
+	// Failed notifications are recorded with their error.
+	sendResolved = true
+	fail = true
+	require.Error(t, exec(resolved))
+	n = h.Query("", rfp)
+	require.Len(t, n, 1)
+	require.Equal(t, []string{rfp}, n[0].Resolved)
+	require.Equal(t, `cancelling notify retry for "webhook" due to unrecoverable error: bad request`, n[0].Error)
+
+	require.Len(t, h.Query("team-X", ffp), 1)
+	require.Empty(t, h.Query("team-Y", ""))
+
+	// Only the latest notifications are kept.
+	fail = false
+	require.NoError(t, exec(resolved))
+	require.NoError(t, exec(resolved))
+	require.Len(t, h.Query("", ""), 3)
+	require.Empty(t, h.Query("", ffp))
+}
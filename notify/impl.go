@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"io"
 	"io/ioutil"
 	"mime"
 	"mime/multipart"
@@ -29,11 +31,18 @@ import (
 	"net/smtp"
 	"net/textproto"
 	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	"github.com/mwitkow/go-conntrack"
 	commoncfg "github.com/prometheus/common/config"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/version"
@@ -52,6 +61,50 @@ type Notifier interface {
 	Notify(context.Context, ...*types.Alert) (bool, error)
 }
 
+// A NotifyError is an error of a notifier which knows whether the failed
+// notification may succeed when it is retried. Its classification takes
+// precedence over the flag returned by the notifier.
+type NotifyError interface {
+	error
+	Retryable() bool
+}
+
+type notifyError struct {
+	err       error
+	retryable bool
+}
+
+func (e *notifyError) Error() string   { return e.err.Error() }
+func (e *notifyError) Retryable() bool { return e.retryable }
+
+// NewRetryableError marks the error of a temporary failure, like a server
+// error or a timeout, which is retried with backoff.
+func NewRetryableError(err error) error {
+	return &notifyError{err: err, retryable: true}
+}
+
+// NewPermanentError marks the error of a failure that retrying can't fix,
+// like a rejected request or a broken template.
+func NewPermanentError(err error) error {
+	return &notifyError{err: err, retryable: false}
+}
+
+// isRetryable reports whether a notification which failed with the error
+// should be retried. Notify errors classify themselves and timeouts and
+// temporary network errors are always retried. Otherwise the retry flag
+// returned by the notifier is used.
+func isRetryable(err error, retry bool) bool {
+	switch e := err.(type) {
+	case NotifyError:
+		return e.Retryable()
+	case net.Error:
+		if e.Timeout() || e.Temporary() {
+			return true
+		}
+	}
+	return retry
+}
+
 // An Integration wraps a notifier and its config to be uniquely identified by
 // name and index from its origin in the configuration.
 type Integration struct {
@@ -117,6 +170,78 @@ func BuildReceiverIntegrations(nc *config.Receiver, tmpl *template.Template, log
 		n := NewPushover(c, tmpl, logger)
 		add("pushover", i, n, c)
 	}
+	for i, c := range nc.MSTeamsConfigs {
+		n := NewMSTeams(c, tmpl, logger)
+		add("msteams", i, n, c)
+	}
+	for i, c := range nc.TelegramConfigs {
+		n := NewTelegram(c, tmpl, logger)
+		add("telegram", i, n, c)
+	}
+	for i, c := range nc.SNSConfigs {
+		n := NewSNS(c, tmpl, logger)
+		add("sns", i, n, c)
+	}
+	for i, c := range nc.TwilioConfigs {
+		n := NewTwilio(c, tmpl, logger)
+		add("twilio", i, n, c)
+	}
+	for i, c := range nc.DiscordConfigs {
+		n := NewDiscord(c, tmpl, logger)
+		add("discord", i, n, c)
+	}
+	for i, c := range nc.MatrixConfigs {
+		n := NewMatrix(c, tmpl, logger)
+		add("matrix", i, n, c)
+	}
+	for i, c := range nc.IRCConfigs {
+		n := NewIRC(c, tmpl, logger)
+		add("irc", i, n, c)
+	}
+	for i, c := range nc.XMPPConfigs {
+		n := NewXMPP(c, tmpl, logger)
+		add("xmpp", i, n, c)
+	}
+	for i, c := range nc.WebexConfigs {
+		n := NewWebex(c, tmpl, logger)
+		add("webex", i, n, c)
+	}
+	for i, c := range nc.RocketchatConfigs {
+		n := NewRocketchat(c, tmpl, logger)
+		add("rocketchat", i, n, c)
+	}
+	for i, c := range nc.GoogleChatConfigs {
+		n := NewGoogleChat(c, tmpl, logger)
+		add("googlechat", i, n, c)
+	}
+	for i, c := range nc.ExecConfigs {
+		n := NewExec(c, tmpl, logger)
+		add("exec", i, n, c)
+	}
+	for i, c := range nc.MQTTConfigs {
+		n := NewMQTT(c, tmpl, logger)
+		add("mqtt", i, n, c)
+	}
+	for i, c := range nc.KafkaConfigs {
+		n := NewKafka(c, tmpl, logger)
+		add("kafka", i, n, c)
+	}
+	for i, c := range nc.JiraConfigs {
+		n := NewJira(c, tmpl, logger)
+		add("jira", i, n, c)
+	}
+	for i, c := range nc.ServiceNowConfigs {
+		n := NewServiceNow(c, tmpl, logger)
+		add("servicenow", i, n, c)
+	}
+	for i, c := range nc.ZendeskConfigs {
+		n := NewZendesk(c, tmpl, logger)
+		add("zendesk", i, n, c)
+	}
+	for i, c := range nc.GitHubConfigs {
+		n := NewGitHub(c, tmpl, logger)
+		add("github", i, n, c)
+	}
 	return integrations
 }
 
@@ -129,11 +254,12 @@ type Webhook struct {
 	conf   *config.WebhookConfig
 	tmpl   *template.Template
 	logger log.Logger
+	oauth2 *oauth2Token
 }
 
 // NewWebhook returns a new Webhook.
 func NewWebhook(conf *config.WebhookConfig, t *template.Template, l log.Logger) *Webhook {
-	return &Webhook{conf: conf, tmpl: t, logger: l}
+	return &Webhook{conf: conf, tmpl: t, logger: l, oauth2: newOAuth2Token(conf.HTTPConfig)}
 }
 
 // WebhookMessage defines the JSON object send to webhook endpoints.
@@ -147,36 +273,59 @@ type WebhookMessage struct {
 
 // Notify implements the Notifier interface.
 func (w *Webhook) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
-	data := w.tmpl.Data(receiverName(ctx, w.logger), groupLabels(ctx, w.logger), alerts...)
+	data := tmplData(ctx, w.tmpl, w.logger, alerts...)
 
 	groupKey, ok := GroupKey(ctx)
 	if !ok {
 		level.Error(w.logger).Log("msg", "group key missing")
 	}
 
-	msg := &WebhookMessage{
-		Version:  "4",
-		Data:     data,
-		GroupKey: groupKey,
-	}
-
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
-		return false, err
+	var (
+		buf         bytes.Buffer
+		contentType = contentTypeJSON
+	)
+	if w.conf.Body != "" {
+		var err error
+		body := tmplText(w.tmpl, data, &err)(w.conf.Body)
+		if err != nil {
+			return false, err
+		}
+		buf.WriteString(body)
+		if w.conf.ContentType != "" {
+			contentType = w.conf.ContentType
+		}
+	} else {
+		msg := &WebhookMessage{
+			Version:  "4",
+			Data:     data,
+			GroupKey: groupKey,
+		}
+		if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+			return false, err
+		}
 	}
 
 	req, err := http.NewRequest("POST", w.conf.URL.String(), &buf)
 	if err != nil {
 		return true, err
 	}
-	req.Header.Set("Content-Type", contentTypeJSON)
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("User-Agent", userAgentHeader)
+	for h, v := range w.conf.Headers {
+		req.Header.Set(h, v)
+	}
 
-	c, err := commoncfg.NewClientFromConfig(*w.conf.HTTPConfig, "webhook")
+	c, err := newHTTPClient(w.conf.HTTPConfig, w.oauth2, "webhook")
 	if err != nil {
 		return false, err
 	}
 
+	if w.conf.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(w.conf.Timeout))
+		defer cancel()
+	}
+
 	resp, err := ctxhttp.Do(ctx, c, req)
 	if err != nil {
 		return true, err
@@ -334,7 +483,7 @@ func (n *Email) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 
 	var (
 		tmplErr error
-		data    = n.tmpl.Data(receiverName(ctx, n.logger), groupLabels(ctx, n.logger), as...)
+		data    = tmplData(ctx, n.tmpl, n.logger, as...)
 		tmpl    = tmplText(n.tmpl, data, &tmplErr)
 		from    = tmpl(n.conf.From)
 		to      = tmpl(n.conf.To)
@@ -441,11 +590,12 @@ type PagerDuty struct {
 	conf   *config.PagerdutyConfig
 	tmpl   *template.Template
 	logger log.Logger
+	oauth2 *oauth2Token
 }
 
 // NewPagerDuty returns a new PagerDuty notifier.
 func NewPagerDuty(c *config.PagerdutyConfig, t *template.Template, l log.Logger) *PagerDuty {
-	return &PagerDuty{conf: c, tmpl: t, logger: l}
+	return &PagerDuty{conf: c, tmpl: t, logger: l, oauth2: newOAuth2Token(c.HTTPConfig)}
 }
 
 const (
@@ -588,7 +738,7 @@ func (n *PagerDuty) Notify(ctx context.Context, as ...*types.Alert) (bool, error
 	var err error
 	var (
 		alerts    = types.Alerts(as...)
-		data      = n.tmpl.Data(receiverName(ctx, n.logger), groupLabels(ctx, n.logger), as...)
+		data      = tmplData(ctx, n.tmpl, n.logger, as...)
 		eventType = pagerDutyEventTrigger
 	)
 	if alerts.Status() == model.AlertResolved {
@@ -610,7 +760,7 @@ func (n *PagerDuty) Notify(ctx context.Context, as ...*types.Alert) (bool, error
 		return false, err
 	}
 
-	c, err := commoncfg.NewClientFromConfig(*n.conf.HTTPConfig, "pagerduty")
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "pagerduty")
 	if err != nil {
 		return false, err
 	}
@@ -657,6 +807,7 @@ type Slack struct {
 	conf   *config.SlackConfig
 	tmpl   *template.Template
 	logger log.Logger
+	oauth2 *oauth2Token
 }
 
 // NewSlack returns a new Slack notification handler.
@@ -665,6 +816,7 @@ func NewSlack(c *config.SlackConfig, t *template.Template, l log.Logger) *Slack
 		conf:   c,
 		tmpl:   t,
 		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
 	}
 }
 
@@ -699,7 +851,7 @@ type slackAttachment struct {
 func (n *Slack) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 	var err error
 	var (
-		data     = n.tmpl.Data(receiverName(ctx, n.logger), groupLabels(ctx, n.logger), as...)
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
 		tmplText = tmplText(n.tmpl, data, &err)
 	)
 
@@ -769,7 +921,7 @@ func (n *Slack) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 		return false, err
 	}
 
-	c, err := commoncfg.NewClientFromConfig(*n.conf.HTTPConfig, "slack")
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "slack")
 	if err != nil {
 		return false, err
 	}
@@ -799,6 +951,7 @@ type Hipchat struct {
 	conf   *config.HipchatConfig
 	tmpl   *template.Template
 	logger log.Logger
+	oauth2 *oauth2Token
 }
 
 // NewHipchat returns a new Hipchat notification handler.
@@ -807,6 +960,7 @@ func NewHipchat(c *config.HipchatConfig, t *template.Template, l log.Logger) *Hi
 		conf:   c,
 		tmpl:   t,
 		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
 	}
 }
 
@@ -823,7 +977,7 @@ func (n *Hipchat) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 	var err error
 	var msg string
 	var (
-		data     = n.tmpl.Data(receiverName(ctx, n.logger), groupLabels(ctx, n.logger), as...)
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
 		tmplText = tmplText(n.tmpl, data, &err)
 		tmplHTML = tmplHTML(n.tmpl, data, &err)
 		roomid   = tmplText(n.conf.RoomID)
@@ -856,7 +1010,7 @@ func (n *Hipchat) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		return false, err
 	}
 
-	c, err := commoncfg.NewClientFromConfig(*n.conf.HTTPConfig, "hipchat")
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "hipchat")
 	if err != nil {
 		return false, err
 	}
@@ -890,6 +1044,7 @@ type Wechat struct {
 
 	accessToken   string
 	accessTokenAt time.Time
+	oauth2        *oauth2Token
 }
 
 // Wechat AccessToken with corpid and corpsecret.
@@ -918,7 +1073,7 @@ type weChatResponse struct {
 
 // NewWechat returns a new Wechat notifier.
 func NewWechat(c *config.WechatConfig, t *template.Template, l log.Logger) *Wechat {
-	return &Wechat{conf: c, tmpl: t, logger: l}
+	return &Wechat{conf: c, tmpl: t, logger: l, oauth2: newOAuth2Token(c.HTTPConfig)}
 }
 
 // Notify implements the Notifier interface.
@@ -929,7 +1084,7 @@ func (n *Wechat) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 	}
 
 	level.Debug(n.logger).Log("msg", "Notifying Wechat", "incident", key)
-	data := n.tmpl.Data(receiverName(ctx, n.logger), groupLabels(ctx, n.logger), as...)
+	data := tmplData(ctx, n.tmpl, n.logger, as...)
 
 	var err error
 	tmpl := tmplText(n.tmpl, data, &err)
@@ -937,7 +1092,7 @@ func (n *Wechat) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 		return false, err
 	}
 
-	c, err := commoncfg.NewClientFromConfig(*n.conf.HTTPConfig, "wechat")
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "wechat")
 	if err != nil {
 		return false, err
 	}
@@ -1053,11 +1208,12 @@ type OpsGenie struct {
 	conf   *config.OpsGenieConfig
 	tmpl   *template.Template
 	logger log.Logger
+	oauth2 *oauth2Token
 }
 
 // NewOpsGenie returns a new OpsGenie notifier.
 func NewOpsGenie(c *config.OpsGenieConfig, t *template.Template, l log.Logger) *OpsGenie {
-	return &OpsGenie{conf: c, tmpl: t, logger: l}
+	return &OpsGenie{conf: c, tmpl: t, logger: l, oauth2: newOAuth2Token(c.HTTPConfig)}
 }
 
 type opsGenieCreateMessage struct {
@@ -1083,7 +1239,7 @@ func (n *OpsGenie) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		return retry, err
 	}
 
-	c, err := commoncfg.NewClientFromConfig(*n.conf.HTTPConfig, "opsgenie")
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "opsgenie")
 	if err != nil {
 		return false, err
 	}
@@ -1116,7 +1272,7 @@ func (n *OpsGenie) createRequest(ctx context.Context, as ...*types.Alert) (*http
 	if !ok {
 		return nil, false, fmt.Errorf("group key missing")
 	}
-	data := n.tmpl.Data(receiverName(ctx, n.logger), groupLabels(ctx, n.logger), as...)
+	data := tmplData(ctx, n.tmpl, n.logger, as...)
 
 	level.Debug(n.logger).Log("msg", "Notifying OpsGenie", "incident", key)
 
@@ -1202,6 +1358,7 @@ type VictorOps struct {
 	conf   *config.VictorOpsConfig
 	tmpl   *template.Template
 	logger log.Logger
+	oauth2 *oauth2Token
 }
 
 // NewVictorOps returns a new VictorOps notifier.
@@ -1210,6 +1367,7 @@ func NewVictorOps(c *config.VictorOpsConfig, t *template.Template, l log.Logger)
 		conf:   c,
 		tmpl:   t,
 		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
 	}
 }
 
@@ -1242,7 +1400,7 @@ func (n *VictorOps) Notify(ctx context.Context, as ...*types.Alert) (bool, error
 	var err error
 	var (
 		alerts       = types.Alerts(as...)
-		data         = n.tmpl.Data(receiverName(ctx, n.logger), groupLabels(ctx, n.logger), as...)
+		data         = tmplData(ctx, n.tmpl, n.logger, as...)
 		tmpl         = tmplText(n.tmpl, data, &err)
 		apiURL       = n.conf.APIURL.Copy()
 		messageType  = tmpl(n.conf.MessageType)
@@ -1280,7 +1438,7 @@ func (n *VictorOps) Notify(ctx context.Context, as ...*types.Alert) (bool, error
 		return false, err
 	}
 
-	c, err := commoncfg.NewClientFromConfig(*n.conf.HTTPConfig, "victorops")
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "victorops")
 	if err != nil {
 		return false, err
 	}
@@ -1312,11 +1470,12 @@ type Pushover struct {
 	conf   *config.PushoverConfig
 	tmpl   *template.Template
 	logger log.Logger
+	oauth2 *oauth2Token
 }
 
 // NewPushover returns a new Pushover notifier.
 func NewPushover(c *config.PushoverConfig, t *template.Template, l log.Logger) *Pushover {
-	return &Pushover{conf: c, tmpl: t, logger: l}
+	return &Pushover{conf: c, tmpl: t, logger: l, oauth2: newOAuth2Token(c.HTTPConfig)}
 }
 
 // Notify implements the Notifier interface.
@@ -1325,7 +1484,7 @@ func (n *Pushover) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 	if !ok {
 		return false, fmt.Errorf("group key missing")
 	}
-	data := n.tmpl.Data(receiverName(ctx, n.logger), groupLabels(ctx, n.logger), as...)
+	data := tmplData(ctx, n.tmpl, n.logger, as...)
 
 	level.Debug(n.logger).Log("msg", "Notifying Pushover", "incident", key)
 
@@ -1377,7 +1536,7 @@ func (n *Pushover) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 	u.RawQuery = parameters.Encode()
 	level.Debug(n.logger).Log("msg", "Sending Pushover message", "incident", key, "url", u.String())
 
-	c, err := commoncfg.NewClientFromConfig(*n.conf.HTTPConfig, "pushover")
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "pushover")
 	if err != nil {
 		return false, err
 	}
@@ -1404,6 +1563,1920 @@ func (n *Pushover) retry(statusCode int) (bool, error) {
 	return false, nil
 }
 
+// MSTeams implements a Notifier for Microsoft Teams notifications.
+type MSTeams struct {
+	conf   *config.MSTeamsConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewMSTeams returns a new Microsoft Teams notification handler.
+func NewMSTeams(c *config.MSTeamsConfig, t *template.Template, l log.Logger) *MSTeams {
+	return &MSTeams{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// msTeamsMessageCard is a MessageCard posted to a Teams incoming webhook.
+// https://docs.microsoft.com/en-us/outlook/actionable-messages/message-card-reference
+type msTeamsMessageCard struct {
+	Type       string `json:"@type"`
+	Context    string `json:"@context"`
+	ThemeColor string `json:"themeColor,omitempty"`
+	Summary    string `json:"summary"`
+	Title      string `json:"title,omitempty"`
+	Text       string `json:"text"`
+}
+
+// Notify implements the Notifier interface.
+func (n *MSTeams) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+	)
+
+	card := &msTeamsMessageCard{
+		Type:       "MessageCard",
+		Context:    "http://schema.org/extensions",
+		ThemeColor: tmplText(n.conf.ThemeColor),
+		Summary:    tmplText(n.conf.Summary),
+		Title:      tmplText(n.conf.Title),
+		Text:       tmplText(n.conf.Text),
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(card); err != nil {
+		return false, err
+	}
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "msteams")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Post(ctx, c, n.conf.WebhookURL.String(), contentTypeJSON, &buf)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	return n.retry(resp.StatusCode)
+}
+
+func (n *MSTeams) retry(statusCode int) (bool, error) {
+	// Teams rate limits incoming webhooks with 429 responses, which are
+	// recoverable like 5xx response codes.
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// Telegram implements a Notifier for Telegram notifications.
+type Telegram struct {
+	conf   *config.TelegramConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewTelegram returns a new Telegram notification handler.
+func NewTelegram(c *config.TelegramConfig, t *template.Template, l log.Logger) *Telegram {
+	return &Telegram{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// telegramMessage is the request for the sendMessage method of the Telegram
+// Bot API.
+// https://core.telegram.org/bots/api#sendmessage
+type telegramMessage struct {
+	ChatID              int64  `json:"chat_id"`
+	MessageThreadID     int64  `json:"message_thread_id,omitempty"`
+	Text                string `json:"text"`
+	ParseMode           string `json:"parse_mode,omitempty"`
+	DisableNotification bool   `json:"disable_notification,omitempty"`
+}
+
+// telegramResponse is the response of the Telegram Bot API.
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// Notify implements the Notifier interface.
+func (n *Telegram) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+	)
+	if n.conf.ParseMode == "HTML" {
+		tmplText = tmplHTML(n.tmpl, data, &err)
+	}
+
+	msg := &telegramMessage{
+		ChatID:              n.conf.ChatID,
+		MessageThreadID:     n.conf.MessageThreadID,
+		Text:                tmplText(n.conf.Message),
+		ParseMode:           n.conf.ParseMode,
+		DisableNotification: n.conf.DisableNotifications,
+	}
+	if err != nil {
+		return false, err
+	}
+	// Telegram rejects messages longer than 4096 characters.
+	if r := []rune(msg.Text); len(r) > 4096 {
+		msg.Text = string(r[:4095]) + "…"
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+		return false, err
+	}
+
+	u := *n.conf.APIURL.URL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/bot" + string(n.conf.BotToken) + "/sendMessage"
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "telegram")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Post(ctx, c, u.String(), contentTypeJSON, &buf)
+	if err != nil {
+		// Don't leak the bot token contained in the URL.
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	return n.retry(resp)
+}
+
+func (n *Telegram) retry(resp *http.Response) (bool, error) {
+	// Rate limited requests are answered with 429 and can be retried.
+	// https://core.telegram.org/bots/faq#my-bot-is-hitting-limits-how-do-i-avoid-this
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	var tresp telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tresp); err != nil || tresp.Description == "" {
+		tresp.Description = "unknown error"
+	}
+	err := fmt.Errorf("unexpected status code %v: %s", resp.StatusCode, tresp.Description)
+	return resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// SNS implements a Notifier for AWS SNS notifications.
+type SNS struct {
+	conf   *config.SNSConfig
+	tmpl   *template.Template
+	logger log.Logger
+	now    func() time.Time
+	oauth2 *oauth2Token
+}
+
+// NewSNS returns a new SNS notification handler.
+func NewSNS(c *config.SNSConfig, t *template.Template, l log.Logger) *SNS {
+	return &SNS{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		now:    time.Now,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+const (
+	// snsMaxMessageSize is the maximum size of a message published to a topic.
+	snsMaxMessageSize = 256 * 1024
+	// snsMaxSMSSize is the maximum size of a message sent as SMS.
+	snsMaxSMSSize = 1600
+	// snsMaxSubjectLength is the maximum length of a subject.
+	snsMaxSubjectLength = 100
+)
+
+// Notify implements the Notifier interface.
+func (n *SNS) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+	)
+
+	// https://docs.aws.amazon.com/sns/latest/api/API_Publish.html
+	params := url.Values{}
+	params.Set("Action", "Publish")
+	params.Set("Version", "2010-03-31")
+
+	message := tmplText(n.conf.Message)
+	if n.conf.TopicARN != "" {
+		params.Set("TopicArn", n.conf.TopicARN)
+		message = truncateBytes(message, snsMaxMessageSize)
+		// Subjects are only used for email subscriptions of topics.
+		subject := tmplText(n.conf.Subject)
+		if r := []rune(subject); len(r) > snsMaxSubjectLength {
+			subject = string(r[:snsMaxSubjectLength-3]) + "..."
+		}
+		params.Set("Subject", subject)
+	} else {
+		params.Set("PhoneNumber", n.conf.PhoneNumber)
+		message = truncateBytes(message, snsMaxSMSSize)
+	}
+	params.Set("Message", message)
+	if err != nil {
+		return false, err
+	}
+
+	creds := awsCredentials{
+		accessKey: n.conf.SigV4.AccessKey,
+		secretKey: string(n.conf.SigV4.SecretKey),
+	}
+	if creds.accessKey == "" {
+		creds = awsCredentials{
+			accessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+			secretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			sessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		}
+	}
+	if creds.accessKey == "" || creds.secretKey == "" {
+		return false, fmt.Errorf("no AWS credentials configured")
+	}
+
+	apiURL := fmt.Sprintf("https://sns.%s.amazonaws.com/", n.conf.SigV4.Region)
+	if n.conf.APIURL != nil {
+		apiURL = n.conf.APIURL.String()
+	}
+
+	payload := []byte(params.Encode())
+	req, err := http.NewRequest("POST", apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	req.Header.Set("User-Agent", userAgentHeader)
+	signV4(req, payload, creds, n.conf.SigV4.Region, "sns", n.now())
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "sns")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Do(ctx, c, req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	return n.retry(resp)
+}
+
+func (n *SNS) retry(resp *http.Response) (bool, error) {
+	// Throttled requests are answered with 400 and an error code of
+	// Throttling, but also 429 is recoverable like 5xx response codes.
+	// https://docs.aws.amazon.com/sns/latest/api/CommonErrors.html
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+	err := fmt.Errorf("unexpected status code %v: %s", resp.StatusCode, body)
+
+	if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+		return true, err
+	}
+	return bytes.Contains(body, []byte("<Code>Throttling</Code>")), err
+}
+
+// Twilio implements a Notifier for Twilio SMS notifications.
+type Twilio struct {
+	conf   *config.TwilioConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewTwilio returns a new Twilio notification handler.
+func NewTwilio(c *config.TwilioConfig, t *template.Template, l log.Logger) *Twilio {
+	return &Twilio{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// twilioMaxBodyLength is the maximum number of characters of a message.
+const twilioMaxBodyLength = 1600
+
+// Notify implements the Notifier interface.
+func (n *Twilio) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		body     = strings.TrimSpace(tmplText(n.conf.Message))
+	)
+	if err != nil {
+		return false, err
+	}
+	if r := []rune(body); len(r) > twilioMaxBodyLength {
+		body = string(r[:twilioMaxBodyLength-3]) + "..."
+	}
+
+	// https://www.twilio.com/docs/sms/api/message-resource#create-a-message-resource
+	params := url.Values{}
+	params.Set("From", n.conf.From)
+	params.Set("To", n.conf.To)
+	params.Set("Body", body)
+
+	u := n.conf.APIURL.Copy()
+	u.Path += fmt.Sprintf("Accounts/%s/Messages.json", url.PathEscape(n.conf.AccountSID))
+
+	req, err := http.NewRequest("POST", u.String(), strings.NewReader(params.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgentHeader)
+	req.SetBasicAuth(n.conf.AccountSID, string(n.conf.AuthToken))
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "twilio")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Do(ctx, c, req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	return n.retry(resp.StatusCode)
+}
+
+func (n *Twilio) retry(statusCode int) (bool, error) {
+	// Twilio answers rate limited requests with 429, which is recoverable
+	// like 5xx response codes.
+	// https://www.twilio.com/docs/usage/requests-to-twilio
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// Discord implements a Notifier for Discord notifications.
+type Discord struct {
+	conf   *config.DiscordConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewDiscord returns a new Discord notification handler.
+func NewDiscord(c *config.DiscordConfig, t *template.Template, l log.Logger) *Discord {
+	return &Discord{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+const (
+	// Limits of embeds in Discord messages.
+	// https://discord.com/developers/docs/resources/channel#embed-limits
+	discordMaxTitleLength       = 256
+	discordMaxDescriptionLength = 4096
+
+	discordColorFiring   = 0x992D22
+	discordColorResolved = 0x2ECC71
+)
+
+// discordMessage is the request for executing a Discord webhook.
+// https://discord.com/developers/docs/resources/webhook#execute-webhook
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Color       int    `json:"color"`
+}
+
+// Notify implements the Notifier interface.
+func (n *Discord) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		title    = tmplText(n.conf.Title)
+		desc     = tmplText(n.conf.Message)
+	)
+	if err != nil {
+		return false, err
+	}
+	if r := []rune(title); len(r) > discordMaxTitleLength {
+		title = string(r[:discordMaxTitleLength-1]) + "…"
+	}
+	if r := []rune(desc); len(r) > discordMaxDescriptionLength {
+		desc = string(r[:discordMaxDescriptionLength-1]) + "…"
+	}
+
+	color := discordColorFiring
+	if types.Alerts(as...).Status() == model.AlertResolved {
+		color = discordColorResolved
+	}
+
+	msg := &discordMessage{
+		Embeds: []discordEmbed{{
+			Title:       title,
+			Description: desc,
+			Color:       color,
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+		return false, err
+	}
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "discord")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Post(ctx, c, n.conf.WebhookURL.String(), contentTypeJSON, &buf)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	return n.retry(resp.StatusCode)
+}
+
+func (n *Discord) retry(statusCode int) (bool, error) {
+	// Discord answers rate limited requests with 429, which is recoverable
+	// like 5xx response codes.
+	// https://discord.com/developers/docs/topics/rate-limits
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// Matrix implements a Notifier for Matrix notifications.
+type Matrix struct {
+	conf   *config.MatrixConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewMatrix returns a new Matrix notification handler.
+func NewMatrix(c *config.MatrixConfig, t *template.Template, l log.Logger) *Matrix {
+	return &Matrix{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// matrixMessage is an m.room.message event with an HTML formatted body.
+// https://spec.matrix.org/v1.1/client-server-api/#mroommessage
+type matrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+// Notify implements the Notifier interface.
+func (n *Matrix) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, ok := GroupKey(ctx)
+	if !ok {
+		return false, fmt.Errorf("group key missing")
+	}
+	now, ok := Now(ctx)
+	if !ok {
+		return false, fmt.Errorf("now time missing")
+	}
+
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		tmplHTML = tmplHTML(n.tmpl, data, &err)
+		msg      = &matrixMessage{
+			MsgType: "m.text",
+			Body:    tmplText(n.conf.Message),
+		}
+	)
+	if n.conf.HTMLMessage != "" {
+		msg.Format = "org.matrix.custom.html"
+		msg.FormattedBody = tmplHTML(n.conf.HTMLMessage)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+		return false, err
+	}
+
+	// The transaction ID makes retries of the same notification idempotent.
+	txnID := hashKey(fmt.Sprintf("%s:%d", key, now.UnixNano()))
+	u := n.conf.HomeserverURL.Copy()
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/_matrix/client/r0/rooms/" + n.conf.RoomID + "/send/m.room.message/" + txnID
+
+	req, err := http.NewRequest("PUT", u.String(), &buf)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentTypeJSON)
+	req.Header.Set("User-Agent", userAgentHeader)
+	req.Header.Set("Authorization", "Bearer "+string(n.conf.AccessToken))
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "matrix")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Do(ctx, c, req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	return n.retry(resp.StatusCode)
+}
+
+func (n *Matrix) retry(statusCode int) (bool, error) {
+	// Homeservers answer rate limited requests with 429, which is
+	// recoverable like 5xx response codes.
+	// https://spec.matrix.org/v1.1/client-server-api/#rate-limiting
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// IRC implements a Notifier for IRC notifications.
+type IRC struct {
+	conf   *config.IRCConfig
+	tmpl   *template.Template
+	logger log.Logger
+}
+
+// NewIRC returns a new IRC notification handler.
+func NewIRC(c *config.IRCConfig, t *template.Template, l log.Logger) *IRC {
+	return &IRC{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+	}
+}
+
+// Notify implements the Notifier interface.
+func (n *IRC) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		msg      = tmplText(n.conf.Message)
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// Messages are limited to a single line.
+	msg = truncateBytes(strings.Join(strings.Fields(msg), " "), ircMaxMessageLength)
+	if msg == "" {
+		return false, fmt.Errorf("empty IRC message")
+	}
+
+	if err := getIRCClient(n.conf, n.logger).send(ctx, n.conf, msg); err != nil {
+		return true, err
+	}
+	return false, nil
+}
+
+// XMPP implements a Notifier for XMPP notifications.
+type XMPP struct {
+	conf   *config.XMPPConfig
+	tmpl   *template.Template
+	logger log.Logger
+}
+
+// NewXMPP returns a new XMPP notification handler.
+func NewXMPP(c *config.XMPPConfig, t *template.Template, l log.Logger) *XMPP {
+	return &XMPP{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+	}
+}
+
+// Notify implements the Notifier interface.
+func (n *XMPP) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		msg      = tmplText(n.conf.Message)
+	)
+	if err != nil {
+		return false, err
+	}
+
+	s, err := dialXMPP(ctx, n.conf)
+	if err != nil {
+		_, ok := err.(*xmppError)
+		return !ok, err
+	}
+	defer s.close()
+
+	if err := s.send(msg); err != nil {
+		return true, err
+	}
+	return false, nil
+}
+
+// Webex implements a Notifier for Webex notifications.
+type Webex struct {
+	conf   *config.WebexConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewWebex returns a new Webex notification handler.
+func NewWebex(c *config.WebexConfig, t *template.Template, l log.Logger) *Webex {
+	return &Webex{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// webexMessage is the request to create a message in a Webex room.
+// https://developer.webex.com/docs/api/v1/messages/create-a-message
+type webexMessage struct {
+	RoomID   string `json:"roomId"`
+	Markdown string `json:"markdown"`
+}
+
+// Notify implements the Notifier interface.
+func (n *Webex) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		msg      = &webexMessage{
+			RoomID:   n.conf.RoomID,
+			Markdown: tmplText(n.conf.Message),
+		}
+	)
+	if err != nil {
+		return false, err
+	}
+	// Webex rejects messages longer than 7439 bytes.
+	msg.Markdown = truncateBytes(msg.Markdown, 7439)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest("POST", n.conf.APIURL.String(), &buf)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentTypeJSON)
+	req.Header.Set("User-Agent", userAgentHeader)
+	req.Header.Set("Authorization", "Bearer "+string(n.conf.BotToken))
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "webex")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Do(ctx, c, req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	return n.retry(resp.StatusCode)
+}
+
+func (n *Webex) retry(statusCode int) (bool, error) {
+	// Rate limited requests are answered with 429 and can be retried.
+	// https://developer.webex.com/docs/basics#rate-limiting
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// Rocketchat implements a Notifier for Rocket.Chat notifications.
+type Rocketchat struct {
+	conf   *config.RocketchatConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewRocketchat returns a new Rocket.Chat notification handler.
+func NewRocketchat(c *config.RocketchatConfig, t *template.Template, l log.Logger) *Rocketchat {
+	return &Rocketchat{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// rocketchatReq is the request for a Rocket.Chat incoming webhook.
+// https://docs.rocket.chat/use-rocket.chat/workspace-administration/integrations
+type rocketchatReq struct {
+	Channel     string                 `json:"channel,omitempty"`
+	Alias       string                 `json:"alias,omitempty"`
+	Emoji       string                 `json:"emoji,omitempty"`
+	Avatar      string                 `json:"avatar,omitempty"`
+	Attachments []rocketchatAttachment `json:"attachments"`
+}
+
+// rocketchatAttachment is used to display a richly-formatted message block.
+type rocketchatAttachment struct {
+	Title     string              `json:"title,omitempty"`
+	TitleLink string              `json:"title_link,omitempty"`
+	Text      string              `json:"text"`
+	Color     string              `json:"color,omitempty"`
+	Fields    []config.SlackField `json:"fields,omitempty"`
+	ImageURL  string              `json:"image_url,omitempty"`
+	ThumbURL  string              `json:"thumb_url,omitempty"`
+}
+
+// Notify implements the Notifier interface.
+func (n *Rocketchat) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+	)
+
+	attachment := rocketchatAttachment{
+		Title:     tmplText(n.conf.Title),
+		TitleLink: tmplText(n.conf.TitleLink),
+		Text:      tmplText(n.conf.Text),
+		Color:     tmplText(n.conf.Color),
+		ImageURL:  tmplText(n.conf.ImageURL),
+		ThumbURL:  tmplText(n.conf.ThumbURL),
+	}
+	for _, field := range n.conf.Fields {
+		// Fall back to the global setting if short isn't set for the field.
+		short := n.conf.ShortFields
+		if field.Short != nil {
+			short = *field.Short
+		}
+		attachment.Fields = append(attachment.Fields, config.SlackField{
+			Title: tmplText(field.Title),
+			Value: tmplText(field.Value),
+			Short: &short,
+		})
+	}
+
+	req := &rocketchatReq{
+		Channel:     tmplText(n.conf.Channel),
+		Alias:       tmplText(n.conf.Alias),
+		Emoji:       tmplText(n.conf.Emoji),
+		Avatar:      tmplText(n.conf.Avatar),
+		Attachments: []rocketchatAttachment{attachment},
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(req); err != nil {
+		return false, err
+	}
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "rocketchat")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Post(ctx, c, n.conf.WebhookURL.String(), contentTypeJSON, &buf)
+	if err != nil {
+		// Don't leak the webhook token contained in the URL.
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		return true, err
+	}
+	resp.Body.Close()
+
+	return n.retry(resp.StatusCode)
+}
+
+func (n *Rocketchat) retry(statusCode int) (bool, error) {
+	// Rate limited requests are answered with 429 and can be retried.
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// GoogleChat implements a Notifier for Google Chat notifications.
+type GoogleChat struct {
+	conf   *config.GoogleChatConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewGoogleChat returns a new Google Chat notification handler.
+func NewGoogleChat(c *config.GoogleChatConfig, t *template.Template, l log.Logger) *GoogleChat {
+	return &GoogleChat{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// googleChatMessage is a message with a card.
+// https://developers.google.com/chat/api/reference/rest/v1/cards
+type googleChatMessage struct {
+	Text    string           `json:"text,omitempty"`
+	CardsV2 []googleChatCard `json:"cardsV2"`
+}
+
+type googleChatCard struct {
+	CardID string `json:"cardId"`
+	Card   struct {
+		Header   googleChatHeader    `json:"header"`
+		Sections []googleChatSection `json:"sections"`
+	} `json:"card"`
+}
+
+type googleChatHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type googleChatSection struct {
+	Header  string             `json:"header,omitempty"`
+	Widgets []googleChatWidget `json:"widgets"`
+}
+
+// googleChatWidget has exactly one of its fields set.
+type googleChatWidget struct {
+	TextParagraph *googleChatText    `json:"textParagraph,omitempty"`
+	ButtonList    *googleChatButtons `json:"buttonList,omitempty"`
+}
+
+type googleChatText struct {
+	Text string `json:"text"`
+}
+
+type googleChatButtons struct {
+	Buttons []googleChatButton `json:"buttons"`
+}
+
+type googleChatButton struct {
+	Text    string `json:"text"`
+	OnClick struct {
+		OpenLink struct {
+			URL string `json:"url"`
+		} `json:"openLink"`
+	} `json:"onClick"`
+}
+
+// googleChatMaxAlerts is the maximum number of alerts shown in a card,
+// which must not exceed the message size limit of Google Chat.
+const googleChatMaxAlerts = 20
+
+// Notify implements the Notifier interface.
+func (n *GoogleChat) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		card     googleChatCard
+	)
+	card.CardID = "alerts"
+	card.Card.Header = googleChatHeader{
+		Title:    tmplText(n.conf.Title),
+		Subtitle: tmplText(n.conf.Subtitle),
+	}
+	msg := &googleChatMessage{
+		Text: tmplText(n.conf.Text),
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Each alert is shown in its own section with its labels, annotations
+	// and a link to its source.
+	for i, a := range data.Alerts {
+		if i == googleChatMaxAlerts {
+			card.Card.Sections = append(card.Card.Sections, googleChatSection{
+				Widgets: []googleChatWidget{{
+					TextParagraph: &googleChatText{Text: fmt.Sprintf("%d more alerts", len(data.Alerts)-i)},
+				}},
+			})
+			break
+		}
+		var b strings.Builder
+		for _, p := range a.Labels.SortedPairs() {
+			fmt.Fprintf(&b, "<b>%s</b>: %s<br>", html.EscapeString(p.Name), html.EscapeString(p.Value))
+		}
+		for _, p := range a.Annotations.SortedPairs() {
+			fmt.Fprintf(&b, "<br><b>%s</b>: %s", html.EscapeString(p.Name), html.EscapeString(p.Value))
+		}
+		section := googleChatSection{
+			Header:  fmt.Sprintf("[%s] %s", strings.ToUpper(a.Status), a.Labels["alertname"]),
+			Widgets: []googleChatWidget{{TextParagraph: &googleChatText{Text: b.String()}}},
+		}
+		if a.GeneratorURL != "" {
+			section.Widgets = append(section.Widgets, googleChatWidget{
+				ButtonList: &googleChatButtons{Buttons: []googleChatButton{googleChatLink("Source", a.GeneratorURL)}},
+			})
+		}
+		card.Card.Sections = append(card.Card.Sections, section)
+	}
+	if data.ExternalURL != "" {
+		card.Card.Sections = append(card.Card.Sections, googleChatSection{
+			Widgets: []googleChatWidget{{
+				ButtonList: &googleChatButtons{Buttons: []googleChatButton{googleChatLink("View in Alertmanager", data.ExternalURL)}},
+			}},
+		})
+	}
+	msg.CardsV2 = []googleChatCard{card}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+		return false, err
+	}
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "googlechat")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Post(ctx, c, n.conf.WebhookURL.String(), contentTypeJSON, &buf)
+	if err != nil {
+		// Don't leak the webhook key contained in the URL.
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		return true, err
+	}
+	resp.Body.Close()
+
+	return n.retry(resp.StatusCode)
+}
+
+func googleChatLink(text, link string) googleChatButton {
+	b := googleChatButton{Text: text}
+	b.OnClick.OpenLink.URL = link
+	return b
+}
+
+func (n *GoogleChat) retry(statusCode int) (bool, error) {
+	// Rate limited requests are answered with 429 and can be retried.
+	// https://developers.google.com/chat/limits
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// Exec implements a Notifier running a local command.
+type Exec struct {
+	conf   *config.ExecConfig
+	tmpl   *template.Template
+	logger log.Logger
+}
+
+// NewExec returns a new Exec notification handler.
+func NewExec(c *config.ExecConfig, t *template.Template, l log.Logger) *Exec {
+	return &Exec{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+	}
+}
+
+// execTempFail is the exit status of commands signaling a temporary
+// failure, EX_TEMPFAIL in sysexits.h.
+const execTempFail = 75
+
+// execMaxStderr is the maximum number of bytes of the standard error of a
+// command that are logged.
+const execMaxStderr = 4096
+
+// Notify implements the Notifier interface. The command gets the same JSON
+// message as webhooks on its standard input. Commands exiting with status 75
+// or timing out are retried, other failures are not.
+func (n *Exec) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	groupKey, ok := GroupKey(ctx)
+	if !ok {
+		level.Error(n.logger).Log("msg", "group key missing")
+	}
+
+	msg := &WebhookMessage{
+		Version:  "4",
+		Data:     tmplData(ctx, n.tmpl, n.logger, as...),
+		GroupKey: groupKey,
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(n.conf.Timeout))
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, n.conf.Command[0], n.conf.Command[1:]...)
+	cmd.Stdin = &buf
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if stderr.Len() > 0 {
+		level.Warn(n.logger).Log("msg", "Command wrote to stderr", "command", n.conf.Command[0], "stderr", truncateBytes(stderr.String(), execMaxStderr))
+	}
+	if err == nil {
+		return false, nil
+	}
+	if ctx.Err() != nil {
+		return true, fmt.Errorf("command %q: %s", n.conf.Command[0], ctx.Err())
+	}
+	if ee, ok := err.(*exec.ExitError); ok {
+		if ws, ok := ee.Sys().(syscall.WaitStatus); ok && ws.ExitStatus() == execTempFail {
+			return true, fmt.Errorf("command %q: %s", n.conf.Command[0], err)
+		}
+	}
+	return false, fmt.Errorf("command %q: %s", n.conf.Command[0], err)
+}
+
+// MQTT implements a Notifier publishing to an MQTT topic.
+type MQTT struct {
+	conf   *config.MQTTConfig
+	tmpl   *template.Template
+	logger log.Logger
+}
+
+// NewMQTT returns a new MQTT notification handler.
+func NewMQTT(c *config.MQTTConfig, t *template.Template, l log.Logger) *MQTT {
+	return &MQTT{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+	}
+}
+
+// Notify implements the Notifier interface. The webhook message is
+// published as the payload.
+func (n *MQTT) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	groupKey, ok := GroupKey(ctx)
+	if !ok {
+		level.Error(n.logger).Log("msg", "group key missing")
+	}
+
+	msg := &WebhookMessage{
+		Version:  "4",
+		Data:     tmplData(ctx, n.tmpl, n.logger, as...),
+		GroupKey: groupKey,
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+		return false, err
+	}
+
+	if err := publishMQTT(ctx, n.conf, buf.Bytes()); err != nil {
+		_, ok := err.(*mqttError)
+		return !ok, err
+	}
+	return false, nil
+}
+
+// Kafka implements a Notifier producing to a Kafka topic through a REST
+// Proxy.
+type Kafka struct {
+	conf   *config.KafkaConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewKafka returns a new Kafka notification handler.
+func NewKafka(c *config.KafkaConfig, t *template.Template, l log.Logger) *Kafka {
+	return &Kafka{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// kafkaAvroSchema is the Avro schema of the webhook message.
+const kafkaAvroSchema = `{
+  "type": "record",
+  "name": "Notification",
+  "namespace": "io.prometheus.alertmanager",
+  "fields": [
+    {"name": "version", "type": "string"},
+    {"name": "groupKey", "type": "string"},
+    {"name": "receiver", "type": "string"},
+    {"name": "status", "type": "string"},
+    {"name": "alerts", "type": {"type": "array", "items": {
+      "type": "record",
+      "name": "Alert",
+      "fields": [
+        {"name": "status", "type": "string"},
+        {"name": "labels", "type": {"type": "map", "values": "string"}},
+        {"name": "annotations", "type": {"type": "map", "values": "string"}},
+        {"name": "startsAt", "type": "string"},
+        {"name": "endsAt", "type": "string"},
+        {"name": "generatorURL", "type": "string"},
+        {"name": "fingerprint", "type": "string"}
+      ]
+    }}},
+    {"name": "groupLabels", "type": {"type": "map", "values": "string"}},
+    {"name": "commonLabels", "type": {"type": "map", "values": "string"}},
+    {"name": "commonAnnotations", "type": {"type": "map", "values": "string"}},
+    {"name": "externalURL", "type": "string"},
+    {"name": "truncatedAlerts", "type": "int", "default": 0}
+  ]
+}`
+
+// kafkaProduceRequest is the request to produce records with the REST Proxy.
+// https://docs.confluent.io/platform/current/kafka-rest/api.html#topics
+type kafkaProduceRequest struct {
+	KeySchema   string        `json:"key_schema,omitempty"`
+	ValueSchema string        `json:"value_schema,omitempty"`
+	Records     []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value *WebhookMessage `json:"value"`
+}
+
+// kafkaProduceResponse is the response of the REST Proxy. Records failing
+// with error code 2 can be retried.
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode int    `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Notify implements the Notifier interface.
+func (n *Kafka) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	groupKey, ok := GroupKey(ctx)
+	if !ok {
+		return false, fmt.Errorf("group key missing")
+	}
+
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		key      = tmplText(n.conf.Key)
+	)
+	if err != nil {
+		return false, err
+	}
+	if key == "" {
+		key = hashKey(groupKey)
+	}
+
+	req := &kafkaProduceRequest{
+		Records: []kafkaRecord{{
+			Key: key,
+			Value: &WebhookMessage{
+				Version:  "4",
+				Data:     data,
+				GroupKey: groupKey,
+			},
+		}},
+	}
+	contentType := "application/vnd.kafka.json.v2+json"
+	if n.conf.Serialization == "avro" {
+		contentType = "application/vnd.kafka.avro.v2+json"
+		req.KeySchema = `"string"`
+		req.ValueSchema = kafkaAvroSchema
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(req); err != nil {
+		return false, err
+	}
+
+	u := n.conf.RESTProxyURL.Copy()
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/topics/" + url.PathEscape(n.conf.Topic)
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "kafka")
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := ctxhttp.Post(ctx, c, u.String(), contentType, &buf)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	return n.retry(resp)
+}
+
+func (n *Kafka) retry(resp *http.Response) (bool, error) {
+	if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	} else if resp.StatusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+
+	var kresp kafkaProduceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&kresp); err != nil {
+		return false, err
+	}
+	for _, o := range kresp.Offsets {
+		if o.ErrorCode != 0 {
+			return o.ErrorCode == 2, fmt.Errorf("producing record failed: %s", o.Error)
+		}
+	}
+	return false, nil
+}
+
+// Jira implements a Notifier creating Jira issues.
+type Jira struct {
+	conf   *config.JiraConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewJira returns a new Jira notification handler.
+func NewJira(c *config.JiraConfig, t *template.Template, l log.Logger) *Jira {
+	return &Jira{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// jiraIssue is an issue of the Jira REST API.
+// https://docs.atlassian.com/software/jira/docs/api/REST/8.0.0/#api/2/issue
+type jiraIssue struct {
+	Key    string          `json:"key,omitempty"`
+	Fields jiraIssueFields `json:"fields"`
+}
+
+type jiraIssueFields struct {
+	Project     *jiraName `json:"project,omitempty"`
+	IssueType   *jiraName `json:"issuetype,omitempty"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description"`
+	Priority    *jiraName `json:"priority,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
+}
+
+// jiraName references a project by key and other entities by name.
+type jiraName struct {
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type jiraSearchResult struct {
+	Issues []jiraIssue `json:"issues"`
+}
+
+type jiraTransitions struct {
+	Transitions []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"transitions"`
+}
+
+// Notify implements the Notifier interface. The open issue of the group is
+// updated while it's firing or created if there is none, and transitioned
+// when the group is resolved.
+func (n *Jira) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, ok := GroupKey(ctx)
+	if !ok {
+		return false, fmt.Errorf("group key missing")
+	}
+	// The label identifying the issues of the group.
+	groupLabel := "ALERT{" + hashKey(key)[:32] + "}"
+
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		fields   = jiraIssueFields{
+			Summary:     truncateBytes(tmplText(n.conf.Summary), 255),
+			Description: tmplText(n.conf.Description),
+		}
+	)
+	if p := tmplText(n.conf.Priority); p != "" {
+		fields.Priority = &jiraName{Name: p}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "jira")
+	if err != nil {
+		return false, err
+	}
+
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s" AND statusCategory != Done ORDER BY created DESC`, n.conf.Project, groupLabel)
+	var result jiraSearchResult
+	if retry, err := n.do(ctx, c, "GET", "search?"+url.Values{"jql": {jql}, "maxResults": {"1"}, "fields": {"summary"}}.Encode(), nil, &result); err != nil {
+		return retry, err
+	}
+
+	if data.Status == string(model.AlertResolved) {
+		if len(result.Issues) == 0 || n.conf.ResolveTransition == "" {
+			return false, nil
+		}
+		return n.transition(ctx, c, result.Issues[0].Key)
+	}
+
+	if len(result.Issues) > 0 {
+		return n.do(ctx, c, "PUT", "issue/"+result.Issues[0].Key, &jiraIssue{Fields: fields}, nil)
+	}
+
+	fields.Project = &jiraName{Key: n.conf.Project}
+	fields.IssueType = &jiraName{Name: n.conf.IssueType}
+	fields.Labels = append([]string{groupLabel}, n.conf.Labels...)
+	return n.do(ctx, c, "POST", "issue", &jiraIssue{Fields: fields}, nil)
+}
+
+// transition applies the resolve transition to the issue.
+func (n *Jira) transition(ctx context.Context, c *http.Client, issue string) (bool, error) {
+	var ts jiraTransitions
+	if retry, err := n.do(ctx, c, "GET", "issue/"+issue+"/transitions", nil, &ts); err != nil {
+		return retry, err
+	}
+	for _, t := range ts.Transitions {
+		if t.Name == n.conf.ResolveTransition {
+			req := map[string]interface{}{"transition": map[string]string{"id": t.ID}}
+			return n.do(ctx, c, "POST", "issue/"+issue+"/transitions", req, nil)
+		}
+	}
+	return false, fmt.Errorf("transition %q not available for issue %s", n.conf.ResolveTransition, issue)
+}
+
+// do sends a request to the REST API.
+func (n *Jira) do(ctx context.Context, c *http.Client, method, path string, in, out interface{}) (bool, error) {
+	return doJSON(ctx, c, method, n.conf.APIURL.String()+path, in, out, n.retry)
+}
+
+func (n *Jira) retry(statusCode int) (bool, error) {
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// ServiceNow implements a Notifier opening ServiceNow incidents.
+type ServiceNow struct {
+	conf   *config.ServiceNowConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewServiceNow returns a new ServiceNow notification handler.
+func NewServiceNow(c *config.ServiceNowConfig, t *template.Template, l log.Logger) *ServiceNow {
+	return &ServiceNow{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// serviceNowIncident holds the fields of an incident set by notifications.
+// https://docs.servicenow.com/bundle/rome-application-development/page/integrate/inbound-rest/concept/c_TableAPI.html
+type serviceNowIncident struct {
+	SysID            string `json:"sys_id,omitempty"`
+	CorrelationID    string `json:"correlation_id,omitempty"`
+	AssignmentGroup  string `json:"assignment_group,omitempty"`
+	ShortDescription string `json:"short_description,omitempty"`
+	Description      string `json:"description,omitempty"`
+	Urgency          string `json:"urgency,omitempty"`
+	Impact           string `json:"impact,omitempty"`
+	State            string `json:"state,omitempty"`
+	CloseCode        string `json:"close_code,omitempty"`
+	CloseNotes       string `json:"close_notes,omitempty"`
+}
+
+type serviceNowResult struct {
+	Result []serviceNowIncident `json:"result"`
+}
+
+// serviceNowStateResolved is the state of resolved incidents.
+const serviceNowStateResolved = "6"
+
+// Notify implements the Notifier interface. The active incident of the group
+// is updated while it's firing or created if there is none, and resolved
+// when the group is resolved.
+func (n *ServiceNow) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, ok := GroupKey(ctx)
+	if !ok {
+		return false, fmt.Errorf("group key missing")
+	}
+	correlationID := hashKey(key)
+
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		incident = serviceNowIncident{
+			ShortDescription: truncateBytes(tmplText(n.conf.ShortDescription), 160),
+			Description:      tmplText(n.conf.Description),
+		}
+		resolved = data.Status == string(model.AlertResolved)
+	)
+	if resolved {
+		incident.State = serviceNowStateResolved
+		incident.CloseCode = n.conf.CloseCode
+		incident.CloseNotes = tmplText(n.conf.CloseNotes)
+	} else {
+		incident.Urgency = tmplText(n.conf.Urgency)
+		incident.Impact = tmplText(n.conf.Impact)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "servicenow")
+	if err != nil {
+		return false, err
+	}
+
+	u := n.conf.InstanceURL.Copy()
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/api/now/table/incident"
+	base := u.String()
+	u.RawQuery = url.Values{
+		"sysparm_query":  {"active=true^correlation_id=" + correlationID},
+		"sysparm_fields": {"sys_id"},
+		"sysparm_limit":  {"1"},
+	}.Encode()
+
+	var res serviceNowResult
+	if retry, err := doJSON(ctx, c, "GET", u.String(), nil, &res, n.retry); err != nil {
+		return retry, err
+	}
+
+	if len(res.Result) > 0 {
+		return doJSON(ctx, c, "PATCH", base+"/"+url.PathEscape(res.Result[0].SysID), &incident, nil, n.retry)
+	}
+	if resolved {
+		return false, nil
+	}
+	incident.CorrelationID = correlationID
+	incident.AssignmentGroup = n.conf.AssignmentGroup
+	return doJSON(ctx, c, "POST", base, &incident, nil, n.retry)
+}
+
+func (n *ServiceNow) retry(statusCode int) (bool, error) {
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// Zendesk implements a Notifier creating Zendesk tickets.
+type Zendesk struct {
+	conf   *config.ZendeskConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewZendesk returns a new Zendesk notification handler.
+func NewZendesk(c *config.ZendeskConfig, t *template.Template, l log.Logger) *Zendesk {
+	return &Zendesk{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// zendeskTicket holds the fields of a ticket set by notifications.
+// https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/
+type zendeskTicket struct {
+	ID         int64           `json:"id,omitempty"`
+	ExternalID string          `json:"external_id,omitempty"`
+	Subject    string          `json:"subject,omitempty"`
+	Comment    *zendeskComment `json:"comment,omitempty"`
+	Priority   string          `json:"priority,omitempty"`
+	Status     string          `json:"status,omitempty"`
+	Tags       []string        `json:"tags,omitempty"`
+}
+
+type zendeskComment struct {
+	Body   string `json:"body"`
+	Public bool   `json:"public"`
+}
+
+type zendeskTicketRequest struct {
+	Ticket *zendeskTicket `json:"ticket"`
+}
+
+type zendeskTickets struct {
+	Tickets []zendeskTicket `json:"tickets"`
+}
+
+// Notify implements the Notifier interface. A ticket is created when the
+// group fires first. Later notifications add a comment to it, reopening the
+// ticket when the group fires again and solving it when the group resolves.
+// Closed tickets can't be updated, a new ticket is created instead.
+func (n *Zendesk) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, ok := GroupKey(ctx)
+	if !ok {
+		return false, fmt.Errorf("group key missing")
+	}
+	externalID := hashKey(key)
+
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		comment  = &zendeskComment{Body: tmplText(n.conf.Comment)}
+		subject  = tmplText(n.conf.Subject)
+		priority = tmplText(n.conf.Priority)
+		tags     = zendeskTags(tmplText(n.conf.Tags))
+		resolved = data.Status == string(model.AlertResolved)
+	)
+	if err != nil {
+		return false, err
+	}
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "zendesk")
+	if err != nil {
+		return false, err
+	}
+
+	var existing zendeskTickets
+	u := n.conf.APIURL.String() + "tickets.json?" + url.Values{"external_id": {externalID}}.Encode()
+	if retry, err := doJSON(ctx, c, "GET", u, nil, &existing, n.retry); err != nil {
+		return retry, err
+	}
+	var ticket *zendeskTicket
+	for i := range existing.Tickets {
+		if existing.Tickets[i].Status != "closed" {
+			ticket = &existing.Tickets[i]
+			break
+		}
+	}
+
+	if ticket == nil {
+		if resolved {
+			return false, nil
+		}
+		req := &zendeskTicketRequest{Ticket: &zendeskTicket{
+			ExternalID: externalID,
+			Subject:    subject,
+			Comment:    comment,
+			Priority:   priority,
+			Tags:       tags,
+		}}
+		return doJSON(ctx, c, "POST", n.conf.APIURL.String()+"tickets.json", req, nil, n.retry)
+	}
+
+	update := &zendeskTicket{Comment: comment}
+	switch {
+	case resolved && ticket.Status != "solved":
+		update.Status = "solved"
+	case !resolved && ticket.Status == "solved":
+		update.Status = "open"
+	case resolved:
+		// Don't comment on solved tickets again.
+		return false, nil
+	}
+	u = fmt.Sprintf("%stickets/%d.json", n.conf.APIURL.String(), ticket.ID)
+	return doJSON(ctx, c, "PUT", u, &zendeskTicketRequest{Ticket: update}, nil, n.retry)
+}
+
+// zendeskTags splits s into tags, which are lower case in Zendesk.
+func zendeskTags(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+func (n *Zendesk) retry(statusCode int) (bool, error) {
+	// Rate limited requests are answered with 429 and can be retried.
+	// https://developer.zendesk.com/api-reference/introduction/rate-limits/
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// GitHub implements a Notifier filing GitHub issues.
+type GitHub struct {
+	conf   *config.GitHubConfig
+	tmpl   *template.Template
+	logger log.Logger
+	oauth2 *oauth2Token
+}
+
+// NewGitHub returns a new GitHub notification handler.
+func NewGitHub(c *config.GitHubConfig, t *template.Template, l log.Logger) *GitHub {
+	return &GitHub{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		oauth2: newOAuth2Token(c.HTTPConfig),
+	}
+}
+
+// gitHubIssue holds the fields of an issue set by notifications.
+// https://docs.github.com/en/rest/issues/issues
+type gitHubIssue struct {
+	Number int      `json:"number,omitempty"`
+	Title  string   `json:"title,omitempty"`
+	Body   string   `json:"body,omitempty"`
+	State  string   `json:"state,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// gitHubPageSize is the number of issues listed per request.
+const gitHubPageSize = 100
+
+// Notify implements the Notifier interface. The open issue of the group is
+// updated while it's firing or created if there is none, and closed when
+// the group is resolved.
+func (n *GitHub) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, ok := GroupKey(ctx)
+	if !ok {
+		return false, fmt.Errorf("group key missing")
+	}
+	// The marker identifying the issue of the group, hidden when rendered.
+	marker := "<!-- alertmanager group " + hashKey(key) + " -->"
+
+	var err error
+	var (
+		data     = tmplData(ctx, n.tmpl, n.logger, as...)
+		tmplText = tmplText(n.tmpl, data, &err)
+		issue    = &gitHubIssue{
+			Title: tmplText(n.conf.Title),
+			Body:  tmplText(n.conf.Body) + "\n\n" + marker,
+		}
+	)
+	if err != nil {
+		return false, err
+	}
+
+	c, err := newHTTPClient(n.conf.HTTPConfig, n.oauth2, "github")
+	if err != nil {
+		return false, err
+	}
+
+	base := n.conf.APIURL.String() + "repos/" + n.conf.Repository + "/issues"
+	number, retry, err := n.find(ctx, c, base, marker)
+	if err != nil {
+		return retry, err
+	}
+
+	if data.Status == string(model.AlertResolved) {
+		if number == 0 {
+			return false, nil
+		}
+		issue.State = "closed"
+	}
+	if number != 0 {
+		return doJSON(ctx, c, "PATCH", fmt.Sprintf("%s/%d", base, number), issue, nil, n.retry)
+	}
+	issue.Labels = n.conf.Labels
+	return doJSON(ctx, c, "POST", base, issue, nil, n.retry)
+}
+
+// find returns the number of the open issue with the marker in its body, or
+// 0 if there is none. Only issues with the configured labels are searched.
+func (n *GitHub) find(ctx context.Context, c *http.Client, base, marker string) (int, bool, error) {
+	for page := 1; ; page++ {
+		q := url.Values{
+			"state":    {"open"},
+			"per_page": {strconv.Itoa(gitHubPageSize)},
+			"page":     {strconv.Itoa(page)},
+		}
+		if len(n.conf.Labels) > 0 {
+			q.Set("labels", strings.Join(n.conf.Labels, ","))
+		}
+		var issues []gitHubIssue
+		if retry, err := doJSON(ctx, c, "GET", base+"?"+q.Encode(), nil, &issues, n.retry); err != nil {
+			return 0, retry, err
+		}
+		for _, i := range issues {
+			if strings.Contains(i.Body, marker) {
+				return i.Number, false, nil
+			}
+		}
+		if len(issues) < gitHubPageSize {
+			return 0, false, nil
+		}
+	}
+}
+
+func (n *GitHub) retry(statusCode int) (bool, error) {
+	if statusCode/100 == 5 || statusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("unexpected status code %v", statusCode)
+	} else if statusCode/100 != 2 {
+		return false, fmt.Errorf("unexpected status code %v", statusCode)
+	}
+
+	return false, nil
+}
+
+// newHTTPClient returns a new HTTP client for the configuration of a
+// notifier. The OAuth2 token cache of the notifier is used if the
+// configuration uses OAuth2, so tokens outlive a single notification. The
+// name is used as go-conntrack metric label.
+func newHTTPClient(conf *config.HTTPClientConfig, tok *oauth2Token, name string) (*http.Client, error) {
+	tlsConfig, err := commoncfg.NewTLSConfig(&conf.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	var rt http.RoundTripper = &http.Transport{
+		Proxy:                 http.ProxyURL(conf.ProxyURL.URL),
+		MaxIdleConns:          20000,
+		MaxIdleConnsPerHost:   1000,
+		TLSClientConfig:       tlsConfig,
+		DisableCompression:    true,
+		IdleConnTimeout:       5 * time.Minute,
+		ResponseHeaderTimeout: time.Duration(conf.ResponseTimeout),
+		DialContext: conntrack.NewDialContextFunc(
+			conntrack.DialWithTracing(),
+			conntrack.DialWithName(name),
+			conntrack.DialWithDialer(&net.Dialer{Timeout: time.Duration(conf.DialTimeout)}),
+		),
+	}
+
+	if len(conf.BearerToken) > 0 {
+		rt = commoncfg.NewBearerAuthRoundTripper(conf.BearerToken, rt)
+	} else if len(conf.BearerTokenFile) > 0 {
+		rt = commoncfg.NewBearerAuthFileRoundTripper(conf.BearerTokenFile, rt)
+	}
+	if conf.BasicAuth != nil {
+		rt = commoncfg.NewBasicAuthRoundTripper(conf.BasicAuth.Username, conf.BasicAuth.Password, conf.BasicAuth.PasswordFile, rt)
+	}
+	if conf.OAuth2 != nil {
+		if tok == nil {
+			tok = newOAuth2Token(conf)
+		}
+		rt = newOAuth2RoundTripper(tok, rt)
+	}
+	if len(conf.Headers) > 0 {
+		rt = &headersRoundTripper{headers: conf.Headers, rt: rt}
+	}
+
+	return &http.Client{Transport: rt}, nil
+}
+
+// headersRoundTripper sets the configured headers on every request.
+type headersRoundTripper struct {
+	headers map[string]config.Secret
+	rt      http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *headersRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Round trippers must not modify the request.
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header)+len(t.headers))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+	for k, v := range t.headers {
+		r.Header.Set(k, string(v))
+	}
+	return t.rt.RoundTrip(r)
+}
+
+// doJSON sends in encoded as JSON if it's not nil and decodes the response
+// into out if it's not nil. The status code of the response is checked
+// with retry.
+func doJSON(ctx context.Context, c *http.Client, method, u string, in, out interface{}, retry func(int) (bool, error)) (bool, error) {
+	var body io.Reader
+	if in != nil {
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(in); err != nil {
+			return false, err
+		}
+		body = &buf
+	}
+
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return false, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", contentTypeJSON)
+	}
+	req.Header.Set("Accept", contentTypeJSON)
+	req.Header.Set("User-Agent", userAgentHeader)
+
+	resp, err := ctxhttp.Do(ctx, c, req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if retry, err := retry(resp.StatusCode); err != nil {
+		return retry, fmt.Errorf("%s %s: %s", method, req.URL.Path, err)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// truncateBytes truncates s to at most n bytes without splitting runes.
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	s = s[:n]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// tmplData returns the template data of the notification in the context.
+func tmplData(ctx context.Context, tmpl *template.Template, l log.Logger, alerts ...*types.Alert) *template.Data {
+	data := tmpl.Data(receiverName(ctx, l), groupLabels(ctx, l), alerts...)
+	data.TruncatedAlerts, _ = TruncatedAlerts(ctx)
+	return data
+}
+
 // tmplText is using monadic error handling in order to make string templating
 // less verbose. Use with care as the final error checking is easily missed.
 func tmplText(tmpl *template.Template, data *template.Data, err *error) func(string) string {
@@ -14,16 +14,25 @@
 package notify
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
+	"gopkg.in/yaml.v2"
 
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/template"
@@ -43,6 +52,1376 @@ func TestWebhookRetry(t *testing.T) {
 	}
 }
 
+func TestWebhookHeadersAndTimeout(t *testing.T) {
+	var (
+		gotHeader string
+		block     = make(chan struct{})
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Api-Key")
+		if r.URL.Path == "/slow" {
+			<-block
+		}
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	conf := &config.WebhookConfig{
+		URL:        &config.URL{URL: u},
+		HTTPConfig: &config.HTTPClientConfig{},
+		Headers:    map[string]string{"X-Api-Key": "secret"},
+		Timeout:    model.Duration(100 * time.Millisecond),
+	}
+	notifier := NewWebhook(conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx, &types.Alert{})
+	require.NoError(t, err)
+	require.Equal(t, "secret", gotHeader)
+
+	// Requests exceeding the timeout are aborted and retried.
+	slow := *u
+	slow.Path = "/slow"
+	conf.URL = &config.URL{URL: &slow}
+	retry, err := notifier.Notify(ctx, &types.Alert{})
+	require.Error(t, err)
+	require.True(t, retry)
+}
+
+func TestWebhookBody(t *testing.T) {
+	var (
+		body        string
+		contentType string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+		contentType = r.Header.Get("Content-Type")
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	conf := &config.WebhookConfig{
+		URL:        &config.URL{URL: u},
+		HTTPConfig: &config.HTTPClientConfig{},
+		Body:       `{"summary":{{ .CommonLabels.alertname | toJson }},"count":{{ len .Alerts }}}`,
+	}
+	notifier := NewWebhook(conf, createTmpl(t), log.NewNopLogger())
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{"alertname": `Disk "full"`},
+			EndsAt: time.Now().Add(time.Hour),
+		},
+	}
+	ctx := WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.Equal(t, `{"summary":"Disk \"full\"","count":1}`, body)
+	require.Equal(t, contentTypeJSON, contentType)
+
+	conf.Body = `{{ .Status }}: {{ .CommonLabels.alertname }}`
+	conf.ContentType = "text/plain"
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.Equal(t, `firing: Disk "full"`, body)
+	require.Equal(t, "text/plain", contentType)
+
+	// Broken templates are not retried.
+	conf.Body = `{{ .Unknown }}`
+	retry, err := notifier.Notify(ctx, alert)
+	require.Error(t, err)
+	require.False(t, retry)
+}
+
+func TestMSTeamsRetry(t *testing.T) {
+	notifier := new(MSTeams)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestMSTeamsMessageCard(t *testing.T) {
+	var card msTeamsMessageCard
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&card))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var conf config.MSTeamsConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`webhook_url: 'http://example.com'`), &conf))
+	conf.WebhookURL = &config.SecretURL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewMSTeams(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupLabels(context.Background(), model.LabelSet{"alertname": "HighLatency"})
+	_, err = notifier.Notify(ctx, &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "severity": "critical"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "MessageCard", card.Type)
+	require.Equal(t, "8C1A1A", card.ThemeColor)
+	require.Equal(t, "[FIRING:1] HighLatency (critical)", card.Title)
+	require.Equal(t, card.Title, card.Summary)
+}
+
+func TestTelegramRetry(t *testing.T) {
+	notifier := new(Telegram)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		resp := &http.Response{
+			StatusCode: statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"ok":false,"description":"error"}`)),
+		}
+		actual, _ := notifier.retry(resp)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestTelegramMessage(t *testing.T) {
+	var (
+		path string
+		msg  telegramMessage
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var conf config.TelegramConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+bot_token: 'secret'
+chat_id: -1234
+message_thread_id: 42
+parse_mode: 'HTML'
+message: '{{ .CommonLabels.alertname }}'
+`), &conf))
+	conf.APIURL = &config.URL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewTelegram(&conf, createTmpl(t), log.NewNopLogger())
+
+	_, err = notifier.Notify(context.Background(), &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "<HighLatency>"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "/botsecret/sendMessage", path)
+	require.Equal(t, telegramMessage{
+		ChatID:          -1234,
+		MessageThreadID: 42,
+		Text:            "&lt;HighLatency&gt;",
+		ParseMode:       "HTML",
+	}, msg)
+}
+
+func TestSNSRetry(t *testing.T) {
+	notifier := new(SNS)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		resp := &http.Response{
+			StatusCode: statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}
+		actual, _ := notifier.retry(resp)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+
+	// Throttling is reported with a 400 response code.
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       ioutil.NopCloser(strings.NewReader("<ErrorResponse><Error><Code>Throttling</Code></Error></ErrorResponse>")),
+	}
+	actual, _ := notifier.retry(resp)
+	require.True(t, actual)
+}
+
+func TestSNSPublish(t *testing.T) {
+	var (
+		auth   string
+		params url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		require.NoError(t, r.ParseForm())
+		params = r.PostForm
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var conf config.SNSConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+topic_arn: 'arn:aws:sns:us-east-1:123456789012:alerts'
+sigv4:
+  access_key: 'AKIDEXAMPLE'
+  secret_key: 'secret'
+message: '{{ .CommonLabels.alertname }}'
+`), &conf))
+	conf.APIURL = &config.URL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewSNS(&conf, createTmpl(t), log.NewNopLogger())
+
+	_, err = notifier.Notify(WithGroupLabels(context.Background(), model.LabelSet{"alertname": "HighLatency"}), &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "Publish", params.Get("Action"))
+	require.Equal(t, "arn:aws:sns:us-east-1:123456789012:alerts", params.Get("TopicArn"))
+	require.Equal(t, "HighLatency", params.Get("Message"))
+	require.Equal(t, "[FIRING:1] HighLatency ", params.Get("Subject"))
+	require.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), auth)
+}
+
+func TestTruncateBytes(t *testing.T) {
+	require.Equal(t, "abc", truncateBytes("abc", 5))
+	require.Equal(t, "ab", truncateBytes("abc", 2))
+	// The two-byte rune is not split.
+	require.Equal(t, "a", truncateBytes("aé", 2))
+}
+
+func TestTwilioRetry(t *testing.T) {
+	notifier := new(Twilio)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestTwilioMessage(t *testing.T) {
+	var (
+		path     string
+		user     string
+		password string
+		params   url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		user, password, _ = r.BasicAuth()
+		require.NoError(t, r.ParseForm())
+		params = r.PostForm
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/2010-04-01/")
+	require.NoError(t, err)
+
+	var conf config.TwilioConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+account_sid: 'AC123'
+auth_token: 'secret'
+from: '+15555550100'
+to: '+15555550101'
+`), &conf))
+	conf.APIURL = &config.URL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewTwilio(&conf, createTmpl(t), log.NewNopLogger())
+
+	_, err = notifier.Notify(WithGroupLabels(context.Background(), model.LabelSet{"alertname": "HighLatency"}), &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighLatency"},
+			Annotations: model.LabelSet{"summary": "Latency is high"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", path)
+	require.Equal(t, "AC123", user)
+	require.Equal(t, "secret", password)
+	require.Equal(t, "+15555550100", params.Get("From"))
+	require.Equal(t, "+15555550101", params.Get("To"))
+	require.Equal(t, "[FIRING:1] HighLatency  - Latency is high", params.Get("Body"))
+}
+
+func TestDiscordRetry(t *testing.T) {
+	notifier := new(Discord)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestDiscordEmbedColor(t *testing.T) {
+	var msg discordMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var conf config.DiscordConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`webhook_url: 'http://example.com'`), &conf))
+	conf.WebhookURL = &config.SecretURL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewDiscord(&conf, createTmpl(t), log.NewNopLogger())
+
+	for _, tc := range []struct {
+		endsAt time.Time
+		color  int
+	}{
+		{endsAt: time.Now().Add(time.Hour), color: discordColorFiring},
+		{endsAt: time.Now().Add(-time.Minute), color: discordColorResolved},
+	} {
+		_, err = notifier.Notify(context.Background(), &types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "HighLatency"},
+				StartsAt: time.Now().Add(-time.Hour),
+				EndsAt:   tc.endsAt,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, msg.Embeds, 1)
+		require.Equal(t, tc.color, msg.Embeds[0].Color)
+	}
+}
+
+func TestMatrixRetry(t *testing.T) {
+	notifier := new(Matrix)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestMatrixMessage(t *testing.T) {
+	var (
+		paths []string
+		auth  string
+		msg   matrixMessage
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "PUT", r.Method)
+		paths = append(paths, r.URL.EscapedPath())
+		auth = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var conf config.MatrixConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+homeserver_url: 'https://matrix.example.org'
+access_token: 'secret'
+room_id: '!room:example.org'
+`), &conf))
+	conf.HomeserverURL = &config.URL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewMatrix(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupKey(context.Background(), "1")
+	ctx = WithNow(ctx, time.Now())
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "<HighLatency>"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	for i := 0; i < 2; i++ {
+		_, err = notifier.Notify(ctx, alert)
+		require.NoError(t, err)
+	}
+
+	// Retries of the same notification use the same transaction.
+	require.Len(t, paths, 2)
+	require.Equal(t, paths[0], paths[1])
+	require.True(t, strings.HasPrefix(paths[0], "/_matrix/client/r0/rooms/%21room:example.org/send/m.room.message/"), paths[0])
+	require.Equal(t, "Bearer secret", auth)
+	require.Equal(t, "m.text", msg.MsgType)
+	require.Equal(t, "org.matrix.custom.html", msg.Format)
+	require.Contains(t, msg.Body, "<HighLatency>")
+	require.Contains(t, msg.FormattedBody, "&lt;HighLatency&gt;")
+}
+
+func TestIRCMessage(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	var (
+		lines = make(chan string, 10)
+		pongs = make(chan string, 1)
+	)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		joined := make(chan struct{})
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				close(lines)
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "USER "):
+				conn.Write([]byte(":irc.example.org 001 am :Welcome\r\n"))
+			case strings.HasPrefix(line, "JOIN "):
+				// The JOIN of another user doesn't confirm ours, which is
+				// confirmed a bit later. Check that pings are answered.
+				conn.Write([]byte(":bob!bob@example.org JOIN :#alerts\r\n"))
+				conn.Write([]byte("PING :irc.example.org\r\n"))
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					close(joined)
+					conn.Write([]byte(":am!am@example.org JOIN :#alerts\r\n"))
+				}()
+			case strings.HasPrefix(line, "PONG "):
+				pongs <- line
+				continue
+			case strings.HasPrefix(line, "PRIVMSG #alerts "):
+				select {
+				case <-joined:
+				default:
+					line = "before JOIN confirmation: " + line
+				}
+			}
+			lines <- line
+		}
+	}()
+
+	var conf config.IRCConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+server: '`+ln.Addr().String()+`'
+nick: 'am'
+nickserv_password: 'secret'
+channel: '#alerts'
+`), &conf))
+	notifier := NewIRC(&conf, createTmpl(t), log.NewNopLogger())
+	defer func() {
+		c := getIRCClient(&conf, log.NewNopLogger())
+		c.mtx.Lock()
+		c.close()
+		c.mtx.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency"})
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	// The second notification reuses the connection and the joined channel.
+	for i := 0; i < 2; i++ {
+		retry, err := notifier.Notify(ctx, alert)
+		require.NoError(t, err)
+		require.False(t, retry)
+	}
+
+	expected := []string{
+		"NICK am",
+		"USER am 0 * :Alertmanager",
+		"PRIVMSG NickServ :IDENTIFY secret",
+		"JOIN #alerts",
+		"PRIVMSG #alerts :[FIRING:1] HighLatency http://am/#/alerts?receiver=",
+		"PRIVMSG #alerts :[FIRING:1] HighLatency http://am/#/alerts?receiver=",
+	}
+	for _, e := range expected {
+		select {
+		case l := <-lines:
+			require.Equal(t, e, l)
+		case <-ctx.Done():
+			t.Fatalf("expected line %q", e)
+		}
+	}
+	select {
+	case l := <-pongs:
+		require.Equal(t, "PONG :irc.example.org", l)
+	case <-ctx.Done():
+		t.Fatal("expected PONG")
+	}
+}
+
+func TestParseIRCLine(t *testing.T) {
+	for _, tc := range []struct {
+		line, source, cmd, params string
+	}{
+		{"PING :irc.example.org\r\n", "", "PING", ":irc.example.org"},
+		{":irc.example.org 001 am :Welcome\r\n", "irc.example.org", "001", "am :Welcome"},
+		{":am!am@example.org JOIN :#alerts\r\n", "am", "JOIN", ":#alerts"},
+		{":am@example.org QUIT\r\n", "am", "QUIT", ""},
+		{":irc.example.org\r\n", "", "", ""},
+	} {
+		source, cmd, params := parseIRCLine(tc.line)
+		require.Equal(t, tc.source, source, tc.line)
+		require.Equal(t, tc.cmd, cmd, tc.line)
+		require.Equal(t, tc.params, params, tc.line)
+	}
+}
+
+func TestIRCJoinError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "USER "):
+				conn.Write([]byte(":irc.example.org 001 am :Welcome\r\n"))
+			case strings.HasPrefix(line, "JOIN "):
+				conn.Write([]byte(":irc.example.org 474 am #alerts :Cannot join channel (+b)\r\n"))
+			}
+		}
+	}()
+
+	conf := &config.IRCConfig{
+		Server:  ln.Addr().String(),
+		Nick:    "am",
+		Channel: "#alerts",
+		Message: "test",
+	}
+	notifier := NewIRC(conf, createTmpl(t), log.NewNopLogger())
+	defer func() {
+		c := getIRCClient(conf, log.NewNopLogger())
+		c.mtx.Lock()
+		if c.conn != nil {
+			c.close()
+		}
+		c.mtx.Unlock()
+	}()
+
+	// Messages aren't sent to channels the server refused to join.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	retry, err := notifier.Notify(ctx, &types.Alert{})
+	require.EqualError(t, err, "joining IRC channel #alerts: 474 Cannot join channel (+b)")
+	require.True(t, retry)
+}
+
+func TestWebexRetry(t *testing.T) {
+	notifier := new(Webex)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestWebexMessage(t *testing.T) {
+	var (
+		auth string
+		msg  webexMessage
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var conf config.WebexConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+bot_token: 'secret'
+room_id: 'room'
+`), &conf))
+	conf.APIURL = &config.URL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewWebex(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupLabels(context.Background(), model.LabelSet{"alertname": "HighLatency"})
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighLatency"},
+			Annotations: model.LabelSet{"description": "p99 above 1s"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+	retry, err := notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	require.Equal(t, "Bearer secret", auth)
+	require.Equal(t, "room", msg.RoomID)
+	require.Equal(t, "**[FIRING:1] HighLatency **\n\nAlerts Firing:\n- alertname=`HighLatency` \n  p99 above 1s\n\n[View in Alertmanager](http://am/#/alerts?receiver=)", msg.Markdown)
+}
+
+func TestRocketchatRetry(t *testing.T) {
+	notifier := new(Rocketchat)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestRocketchatMessage(t *testing.T) {
+	var req rocketchatReq
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var conf config.RocketchatConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+webhook_url: 'https://chat.example.org/hooks/token'
+channel: '#alerts'
+emoji: ':fire:'
+short_fields: true
+fields:
+- title: 'Severity'
+  value: '{{ .CommonLabels.severity }}'
+`), &conf))
+	conf.WebhookURL = &config.SecretURL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewRocketchat(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupLabels(context.Background(), model.LabelSet{"alertname": "HighLatency"})
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighLatency", "severity": "critical"},
+			Annotations: model.LabelSet{"summary": "p99 above 1s"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+	retry, err := notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	require.Equal(t, "#alerts", req.Channel)
+	require.Equal(t, "AlertManager", req.Alias)
+	require.Equal(t, ":fire:", req.Emoji)
+	require.Len(t, req.Attachments, 1)
+	a := req.Attachments[0]
+	require.Equal(t, "[FIRING:1] HighLatency (critical)", a.Title)
+	require.Equal(t, "http://am/#/alerts?receiver=", a.TitleLink)
+	require.Equal(t, "p99 above 1s\n", a.Text)
+	require.Equal(t, "#d63232", a.Color)
+	require.Len(t, a.Fields, 1)
+	require.Equal(t, "Severity", a.Fields[0].Title)
+	require.Equal(t, "critical", a.Fields[0].Value)
+	require.True(t, *a.Fields[0].Short)
+}
+
+func TestGoogleChatRetry(t *testing.T) {
+	notifier := new(GoogleChat)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestGoogleChatMessage(t *testing.T) {
+	var msg googleChatMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var conf config.GoogleChatConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`webhook_url: 'https://chat.googleapis.com/v1/spaces/AAA/messages?key=secret'`), &conf))
+	conf.WebhookURL = &config.SecretURL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewGoogleChat(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupLabels(context.Background(), model.LabelSet{"alertname": "HighLatency"})
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:       model.LabelSet{"alertname": "HighLatency", "instance": "<host>"},
+			Annotations:  model.LabelSet{"summary": "p99 above 1s"},
+			GeneratorURL: "http://prometheus/graph",
+			StartsAt:     time.Now(),
+			EndsAt:       time.Now().Add(time.Hour),
+		},
+	}
+	retry, err := notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	require.Equal(t, "[FIRING:1] HighLatency (<host>)", msg.Text)
+	require.Len(t, msg.CardsV2, 1)
+	card := msg.CardsV2[0].Card
+	require.Equal(t, "[FIRING:1] HighLatency (<host>)", card.Header.Title)
+	require.Equal(t, "p99 above 1s", card.Header.Subtitle)
+	require.Len(t, card.Sections, 2)
+	require.Equal(t, "[FIRING] HighLatency", card.Sections[0].Header)
+	require.Equal(t,
+		"<b>alertname</b>: HighLatency<br><b>instance</b>: &lt;host&gt;<br><br><b>summary</b>: p99 above 1s",
+		card.Sections[0].Widgets[0].TextParagraph.Text,
+	)
+	require.Equal(t, "http://prometheus/graph", card.Sections[0].Widgets[1].ButtonList.Buttons[0].OnClick.OpenLink.URL)
+	require.Equal(t, "http://am", card.Sections[1].Widgets[0].ButtonList.Buttons[0].OnClick.OpenLink.URL)
+}
+
+func TestExec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exec")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "out")
+
+	ctx := WithGroupKey(context.Background(), "1")
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+
+	for _, tc := range []struct {
+		script string
+		retry  bool
+		err    string
+	}{
+		{
+			script: `cat > "$0"`,
+		},
+		{
+			script: `echo failed >&2; exit 75`,
+			retry:  true,
+			err:    `command "sh": exit status 75`,
+		},
+		{
+			script: `exit 1`,
+			err:    `command "sh": exit status 1`,
+		},
+		{
+			script: `exec sleep 10`,
+			retry:  true,
+			err:    `command "sh": context deadline exceeded`,
+		},
+	} {
+		t.Run(tc.script, func(t *testing.T) {
+			conf := &config.ExecConfig{
+				Command: []string{"sh", "-c", tc.script, out},
+				Timeout: model.Duration(100 * time.Millisecond),
+			}
+			notifier := NewExec(conf, createTmpl(t), log.NewNopLogger())
+
+			retry, err := notifier.Notify(ctx, alert)
+			require.Equal(t, tc.retry, retry)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			b, err := ioutil.ReadFile(out)
+			require.NoError(t, err)
+			var msg WebhookMessage
+			require.NoError(t, json.Unmarshal(b, &msg))
+			require.Equal(t, "4", msg.Version)
+			require.Equal(t, "1", msg.GroupKey)
+			require.Equal(t, "HighLatency", msg.Alerts[0].Labels["alertname"])
+		})
+	}
+}
+
+func TestKafkaRetry(t *testing.T) {
+	notifier := new(Kafka)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		resp := &http.Response{
+			StatusCode: statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"offsets":[{"partition":0,"offset":1}]}`)),
+		}
+		actual, _ := notifier.retry(resp)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+
+	for errorCode, expected := range map[int]bool{1: false, 2: true} {
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"offsets":[{"error_code":%d,"error":"failed"}]}`, errorCode))),
+		}
+		actual, err := notifier.retry(resp)
+		require.EqualError(t, err, "producing record failed: failed")
+		require.Equal(t, expected, actual, fmt.Sprintf("error on error code %d", errorCode))
+	}
+}
+
+func TestKafkaProduce(t *testing.T) {
+	for _, serialization := range []string{"json", "avro"} {
+		t.Run(serialization, func(t *testing.T) {
+			var (
+				path        string
+				contentType string
+				req         kafkaProduceRequest
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				contentType = r.Header.Get("Content-Type")
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				w.Write([]byte(`{"offsets":[{"partition":0,"offset":1}]}`))
+			}))
+			defer srv.Close()
+
+			u, err := url.Parse(srv.URL + "/kafka")
+			require.NoError(t, err)
+
+			var conf config.KafkaConfig
+			require.NoError(t, yaml.UnmarshalStrict([]byte(`
+rest_proxy_url: 'http://localhost:8082'
+topic: 'alerts'
+serialization: '`+serialization+`'
+`), &conf))
+			conf.RESTProxyURL = &config.URL{URL: u}
+			conf.HTTPConfig = &config.HTTPClientConfig{}
+			notifier := NewKafka(&conf, createTmpl(t), log.NewNopLogger())
+
+			ctx := WithGroupKey(context.Background(), "1")
+			alert := &types.Alert{
+				Alert: model.Alert{
+					Labels:   model.LabelSet{"alertname": "HighLatency"},
+					StartsAt: time.Now(),
+					EndsAt:   time.Now().Add(time.Hour),
+				},
+			}
+			retry, err := notifier.Notify(ctx, alert)
+			require.NoError(t, err)
+			require.False(t, retry)
+
+			require.Equal(t, "/kafka/topics/alerts", path)
+			require.Equal(t, "application/vnd.kafka."+serialization+".v2+json", contentType)
+			require.Len(t, req.Records, 1)
+			require.Equal(t, hashKey("1"), req.Records[0].Key)
+			require.Equal(t, "1", req.Records[0].Value.GroupKey)
+			require.Equal(t, "HighLatency", req.Records[0].Value.Alerts[0].Labels["alertname"])
+			if serialization == "avro" {
+				require.Equal(t, `"string"`, req.KeySchema)
+				require.Equal(t, kafkaAvroSchema, req.ValueSchema)
+			} else {
+				require.Empty(t, req.ValueSchema)
+			}
+		})
+	}
+}
+
+func TestKafkaAvroSchemaFields(t *testing.T) {
+	type field struct {
+		Name string          `json:"name"`
+		Type json.RawMessage `json:"type"`
+	}
+	var schema struct {
+		Fields []field `json:"fields"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(kafkaAvroSchema), &schema))
+
+	var alertSchema struct {
+		Items struct {
+			Fields []field `json:"fields"`
+		} `json:"items"`
+	}
+	names := func(fields []field) []string {
+		var res []string
+		for _, f := range fields {
+			res = append(res, f.Name)
+			if f.Name == "alerts" {
+				require.NoError(t, json.Unmarshal(f.Type, &alertSchema))
+			}
+		}
+		sort.Strings(res)
+		return res
+	}
+	keys := func(v interface{}) []string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		var m map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(b, &m))
+		var res []string
+		for k := range m {
+			res = append(res, k)
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	// Every key of the webhook message must be in the schema, else the REST
+	// Proxy rejects the records.
+	msg := &WebhookMessage{Data: &template.Data{}}
+	require.Equal(t, keys(msg), names(schema.Fields))
+	require.Equal(t, keys(template.Alert{}), names(alertSchema.Items.Fields))
+}
+
+func TestJiraRetry(t *testing.T) {
+	notifier := new(Jira)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestJiraIssueLifecycle(t *testing.T) {
+	var (
+		requests []string
+		issue    *jiraIssue
+		resolved bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/rest/api/2/search":
+			require.Contains(t, r.URL.Query().Get("jql"), `project = "OPS" AND labels = "ALERT{`)
+			var res jiraSearchResult
+			if issue != nil && !resolved {
+				res.Issues = []jiraIssue{*issue}
+			}
+			json.NewEncoder(w).Encode(res)
+		case r.Method == "POST" && r.URL.Path == "/rest/api/2/issue":
+			issue = &jiraIssue{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(issue))
+			issue.Key = "OPS-1"
+		case r.Method == "PUT" && r.URL.Path == "/rest/api/2/issue/OPS-1":
+			var update jiraIssue
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			issue.Fields.Summary = update.Fields.Summary
+		case r.Method == "GET" && r.URL.Path == "/rest/api/2/issue/OPS-1/transitions":
+			w.Write([]byte(`{"transitions":[{"id":"11","name":"In Progress"},{"id":"31","name":"Done"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/api/2/issue/OPS-1/transitions":
+			b, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"transition":{"id":"31"}}`, string(b))
+			resolved = true
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/rest/api/2/")
+	require.NoError(t, err)
+
+	var conf config.JiraConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+api_url: 'https://jira.example.org/rest/api/2/'
+project: 'OPS'
+issue_type: 'Bug'
+priority: '{{ if eq .CommonLabels.severity "critical" }}High{{ end }}'
+labels: ['alertmanager']
+`), &conf))
+	conf.APIURL = &config.URL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewJira(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupKey(context.Background(), "1")
+	ctx = WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency"})
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "severity": "critical"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+
+	// The first notification creates the issue, the second updates it.
+	for i := 0; i < 2; i++ {
+		_, err = notifier.Notify(ctx, alert)
+		require.NoError(t, err)
+	}
+	require.Equal(t, "[FIRING:1] HighLatency (critical)", issue.Fields.Summary)
+	require.Equal(t, "OPS", issue.Fields.Project.Key)
+	require.Equal(t, "Bug", issue.Fields.IssueType.Name)
+	require.Equal(t, "High", issue.Fields.Priority.Name)
+	require.Equal(t, []string{"ALERT{" + hashKey("1")[:32] + "}", "alertmanager"}, issue.Fields.Labels)
+
+	alert.EndsAt = time.Now().Add(-time.Minute)
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.True(t, resolved)
+
+	require.Equal(t, []string{
+		"GET /rest/api/2/search",
+		"POST /rest/api/2/issue",
+		"GET /rest/api/2/search",
+		"PUT /rest/api/2/issue/OPS-1",
+		"GET /rest/api/2/search",
+		"GET /rest/api/2/issue/OPS-1/transitions",
+		"POST /rest/api/2/issue/OPS-1/transitions",
+	}, requests)
+}
+
+func TestServiceNowRetry(t *testing.T) {
+	notifier := new(ServiceNow)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestServiceNowIncidentLifecycle(t *testing.T) {
+	var (
+		requests []string
+		incident *serviceNowIncident
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "GET":
+			require.Equal(t, "active=true^correlation_id="+hashKey("1"), r.URL.Query().Get("sysparm_query"))
+			var res serviceNowResult
+			if incident != nil && incident.State != serviceNowStateResolved {
+				res.Result = []serviceNowIncident{{SysID: "abc"}}
+			}
+			json.NewEncoder(w).Encode(res)
+		case "POST":
+			incident = &serviceNowIncident{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(incident))
+		case "PATCH":
+			var update serviceNowIncident
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			incident.State = update.State
+			incident.CloseCode = update.CloseCode
+		}
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var conf config.ServiceNowConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+instance_url: 'https://example.service-now.com'
+assignment_group: 'ops'
+`), &conf))
+	conf.InstanceURL = &config.URL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewServiceNow(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupKey(context.Background(), "1")
+	ctx = WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency"})
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "severity": "warning"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+
+	// The first notification opens the incident, the second updates it.
+	for i := 0; i < 2; i++ {
+		_, err = notifier.Notify(ctx, alert)
+		require.NoError(t, err)
+	}
+	require.Equal(t, hashKey("1"), incident.CorrelationID)
+	require.Equal(t, "ops", incident.AssignmentGroup)
+	require.Equal(t, "[FIRING:1] HighLatency (warning)", incident.ShortDescription)
+	require.Equal(t, "2", incident.Urgency)
+	require.Equal(t, "2", incident.Impact)
+
+	alert.EndsAt = time.Now().Add(-time.Minute)
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.Equal(t, serviceNowStateResolved, incident.State)
+	require.Equal(t, "Solved (Permanently)", incident.CloseCode)
+
+	// Notifications for resolved groups without an active incident are
+	// dropped.
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"GET /api/now/table/incident",
+		"POST /api/now/table/incident",
+		"GET /api/now/table/incident",
+		"PATCH /api/now/table/incident/abc",
+		"GET /api/now/table/incident",
+		"PATCH /api/now/table/incident/abc",
+		"GET /api/now/table/incident",
+	}, requests)
+}
+
+func TestZendeskRetry(t *testing.T) {
+	notifier := new(Zendesk)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestZendeskTicketLifecycle(t *testing.T) {
+	var (
+		requests []string
+		ticket   *zendeskTicket
+		comments []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "GET":
+			require.Equal(t, hashKey("1"), r.URL.Query().Get("external_id"))
+			var res zendeskTickets
+			if ticket != nil {
+				res.Tickets = []zendeskTicket{*ticket}
+			}
+			json.NewEncoder(w).Encode(res)
+		case "POST":
+			var req zendeskTicketRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			ticket = req.Ticket
+			ticket.ID = 7
+			ticket.Status = "new"
+			comments = append(comments, ticket.Comment.Body)
+		case "PUT":
+			var req zendeskTicketRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Ticket.Status != "" {
+				ticket.Status = req.Ticket.Status
+			}
+			comments = append(comments, req.Ticket.Comment.Body)
+		}
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/api/v2/")
+	require.NoError(t, err)
+
+	var conf config.ZendeskConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+api_url: 'https://example.zendesk.com/api/v2/'
+comment: '{{ .Status }}'
+`), &conf))
+	conf.APIURL = &config.URL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewZendesk(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupKey(context.Background(), "1")
+	ctx = WithGroupLabels(ctx, model.LabelSet{"alertname": "High Latency", "team": "Ops"})
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "High Latency", "team": "Ops", "severity": "critical"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	notify := func(endsAt time.Time) {
+		alert.EndsAt = endsAt
+		_, err := notifier.Notify(ctx, alert)
+		require.NoError(t, err)
+	}
+
+	notify(time.Now().Add(time.Hour))
+	require.Equal(t, hashKey("1"), ticket.ExternalID)
+	require.Equal(t, "[FIRING:1] High Latency Ops (critical)", ticket.Subject)
+	require.Equal(t, "urgent", ticket.Priority)
+	require.Equal(t, []string{"alertmanager", "alertname_high_latency", "team_ops"}, ticket.Tags)
+
+	notify(time.Now().Add(-time.Minute))
+	require.Equal(t, "solved", ticket.Status)
+	// Notifications for solved tickets are dropped until the group fires again.
+	notify(time.Now().Add(-time.Minute))
+	notify(time.Now().Add(time.Hour))
+	require.Equal(t, "open", ticket.Status)
+
+	require.Equal(t, []string{"firing", "resolved", "firing"}, comments)
+	require.Equal(t, []string{
+		"GET /api/v2/tickets.json",
+		"POST /api/v2/tickets.json",
+		"GET /api/v2/tickets.json",
+		"PUT /api/v2/tickets/7.json",
+		"GET /api/v2/tickets.json",
+		"GET /api/v2/tickets.json",
+		"PUT /api/v2/tickets/7.json",
+	}, requests)
+}
+
+func TestGitHubRetry(t *testing.T) {
+	notifier := new(GitHub)
+
+	retryCodes := append(defaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range retryTests(retryCodes) {
+		actual, _ := notifier.retry(statusCode)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestGitHubIssueLifecycle(t *testing.T) {
+	var (
+		requests []string
+		issues   = []gitHubIssue{{Number: 1, Body: "unrelated", State: "open"}}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "GET":
+			require.Equal(t, "open", r.URL.Query().Get("state"))
+			require.Equal(t, "alert,slo", r.URL.Query().Get("labels"))
+			var open []gitHubIssue
+			for _, i := range issues {
+				if i.State == "open" {
+					open = append(open, i)
+				}
+			}
+			json.NewEncoder(w).Encode(open)
+		case "POST":
+			var i gitHubIssue
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&i))
+			i.Number = len(issues) + 1
+			i.State = "open"
+			issues = append(issues, i)
+		case "PATCH":
+			require.Equal(t, "/repos/acme/web/issues/2", r.URL.Path)
+			var i gitHubIssue
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&i))
+			issues[1].Title = i.Title
+			if i.State != "" {
+				issues[1].State = i.State
+			}
+		}
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+
+	var conf config.GitHubConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+repository: 'acme/web'
+labels: ['alert', 'slo']
+`), &conf))
+	conf.APIURL = &config.URL{URL: u}
+	conf.HTTPConfig = &config.HTTPClientConfig{}
+	notifier := NewGitHub(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx := WithGroupKey(context.Background(), "1")
+	ctx = WithGroupLabels(ctx, model.LabelSet{"alertname": "ErrorBudgetBurn"})
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "ErrorBudgetBurn"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+
+	// The first notification files the issue, the second updates it.
+	for i := 0; i < 2; i++ {
+		_, err = notifier.Notify(ctx, alert)
+		require.NoError(t, err)
+	}
+	require.Len(t, issues, 2)
+	require.Equal(t, "[FIRING:1] ErrorBudgetBurn ", issues[1].Title)
+	require.Equal(t, []string{"alert", "slo"}, issues[1].Labels)
+	require.True(t, strings.HasSuffix(issues[1].Body, "<!-- alertmanager group "+hashKey("1")+" -->"), issues[1].Body)
+
+	alert.EndsAt = time.Now().Add(-time.Minute)
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.Equal(t, "closed", issues[1].State)
+	require.Equal(t, "[RESOLVED] ErrorBudgetBurn ", issues[1].Title)
+
+	require.Equal(t, []string{
+		"GET /repos/acme/web/issues",
+		"POST /repos/acme/web/issues",
+		"GET /repos/acme/web/issues",
+		"PATCH /repos/acme/web/issues/2",
+		"GET /repos/acme/web/issues",
+		"PATCH /repos/acme/web/issues/2",
+	}, requests)
+}
+
+func TestHTTPClientHeaders(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	defer srv.Close()
+
+	conf := &config.HTTPClientConfig{
+		Headers: map[string]config.Secret{
+			"X-Scope":    "ops",
+			"User-Agent": "gateway",
+		},
+	}
+	conf.BearerToken = "token"
+	c, err := newHTTPClient(conf, nil, "test")
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("GET", srv.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "Alertmanager")
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, "ops", got.Get("X-Scope"))
+	require.Equal(t, "gateway", got.Get("User-Agent"))
+	require.Equal(t, "Bearer token", got.Get("Authorization"))
+	// The request of the notifier is left untouched.
+	require.Equal(t, "Alertmanager", req.Header.Get("User-Agent"))
+}
+
 func TestPagerDutyRetryV1(t *testing.T) {
 	notifier := new(PagerDuty)
 
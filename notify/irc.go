@@ -0,0 +1,361 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	commoncfg "github.com/prometheus/common/config"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+// ircMaxMessageLength is the maximum length of a message sent to a channel.
+// Lines are limited to 512 bytes, which includes the command, the channel
+// and the prefix the server adds when relaying the message.
+const ircMaxMessageLength = 400
+
+// ircWriteTimeout is the timeout of writes which aren't part of a
+// notification, like the answers to pings of the server.
+const ircWriteTimeout = 10 * time.Second
+
+var (
+	ircClientsMtx sync.Mutex
+	// IRC connections are kept across configuration reloads, so they are
+	// shared between all notifiers using the same server and nick.
+	ircClients = map[string]*ircClient{}
+)
+
+// getIRCClient returns the client for the server and nick of the config.
+func getIRCClient(conf *config.IRCConfig, l log.Logger) *ircClient {
+	key := fmt.Sprintf("%s/%s/%t", conf.Server, conf.Nick, conf.UseTLS)
+
+	ircClientsMtx.Lock()
+	defer ircClientsMtx.Unlock()
+
+	c, ok := ircClients[key]
+	if !ok {
+		c = &ircClient{logger: l}
+		ircClients[key] = c
+	}
+	return c
+}
+
+// ircClient is a persistent connection to an IRC server. It is established
+// on first use and re-established after it broke.
+type ircClient struct {
+	logger log.Logger
+
+	mtx  sync.Mutex
+	conn net.Conn
+	// The nick the server registered the connection with.
+	nick   string
+	joined map[string]bool
+	// The JOINs waiting for the confirmation of the server by channel.
+	joins map[string]*ircJoin
+}
+
+// ircJoin is a JOIN of a channel waiting for the reply of the server.
+type ircJoin struct {
+	done chan struct{}
+	err  error
+}
+
+// send sends the message to the channel of the config, connecting and
+// joining the channel first if necessary.
+func (c *ircClient) send(ctx context.Context, conf *config.IRCConfig, msg string) error {
+	if err := c.join(ctx, conf); err != nil {
+		return err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	// The connection may have broken since the channel was joined.
+	if c.conn == nil || !c.joined[ircChannelKey(conf.Channel)] {
+		return errors.New("IRC connection closed")
+	}
+	conn := c.conn
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetWriteDeadline(deadline)
+	}
+	defer conn.SetWriteDeadline(time.Time{})
+
+	if err := c.write(fmt.Sprintf("PRIVMSG %s :%s", conf.Channel, msg)); err != nil {
+		c.close()
+		return err
+	}
+	return nil
+}
+
+// join connects to the server and joins the channel of the config unless
+// this happened already. It waits until the server confirms the JOIN.
+func (c *ircClient) join(ctx context.Context, conf *config.IRCConfig) error {
+	c.mtx.Lock()
+	if c.conn == nil {
+		if err := c.connect(ctx, conf); err != nil {
+			c.mtx.Unlock()
+			return err
+		}
+	}
+	key := ircChannelKey(conf.Channel)
+	if c.joined[key] {
+		c.mtx.Unlock()
+		return nil
+	}
+	j, ok := c.joins[key]
+	if !ok {
+		line := "JOIN " + conf.Channel
+		if conf.ChannelKey != "" {
+			line += " " + string(conf.ChannelKey)
+		}
+		conn := c.conn
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetWriteDeadline(deadline)
+		}
+		err := c.write(line)
+		conn.SetWriteDeadline(time.Time{})
+		if err != nil {
+			c.close()
+			c.mtx.Unlock()
+			return err
+		}
+		j = &ircJoin{done: make(chan struct{})}
+		c.joins[key] = j
+	}
+	c.mtx.Unlock()
+
+	select {
+	case <-j.done:
+		return j.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// connect connects to the server and registers the connection. It must be
+// called with the lock held.
+func (c *ircClient) connect(ctx context.Context, conf *config.IRCConfig) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", conf.Server)
+	if err != nil {
+		return err
+	}
+	if conf.UseTLS {
+		tlsConfig, err := commoncfg.NewTLSConfig(&conf.TLSConfig)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName, _, _ = net.SplitHostPort(conf.Server)
+		}
+		conn = tls.Client(conn, tlsConfig)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	c.conn = conn
+	c.joined = map[string]bool{}
+	c.joins = map[string]*ircJoin{}
+
+	var lines []string
+	if conf.Password != "" {
+		lines = append(lines, "PASS "+string(conf.Password))
+	}
+	lines = append(lines,
+		"NICK "+conf.Nick,
+		fmt.Sprintf("USER %s 0 * :Alertmanager", conf.Nick),
+	)
+	if err := c.write(lines...); err != nil {
+		c.close()
+		return err
+	}
+
+	// Wait for the welcome message, which confirms the registration.
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			c.close()
+			return fmt.Errorf("registering with IRC server: %s", err)
+		}
+		_, cmd, params := parseIRCLine(line)
+		if cmd == "PING" {
+			if err := c.write("PONG " + params); err != nil {
+				c.close()
+				return err
+			}
+			continue
+		}
+		if cmd == "001" {
+			// The welcome message is addressed to the registered nick.
+			c.nick = conf.Nick
+			if f := strings.Fields(params); len(f) > 0 {
+				c.nick = f[0]
+			}
+			break
+		}
+		// Numeric replies from 400 to 599 are errors.
+		if len(cmd) == 3 && (cmd[0] == '4' || cmd[0] == '5') {
+			c.close()
+			return fmt.Errorf("registering with IRC server: %s %s", cmd, params)
+		}
+	}
+	if conf.NickServPassword != "" {
+		if err := c.write("PRIVMSG NickServ :IDENTIFY " + string(conf.NickServPassword)); err != nil {
+			c.close()
+			return err
+		}
+	}
+	conn.SetDeadline(time.Time{})
+
+	go c.readLoop(conn, r)
+
+	return nil
+}
+
+// readLoop handles the lines received from the server until the connection
+// breaks.
+func (c *ircClient) readLoop(conn net.Conn, r *bufio.Reader) {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			level.Debug(c.logger).Log("msg", "IRC connection closed", "err", err)
+			c.mtx.Lock()
+			if c.conn == conn {
+				c.close()
+			}
+			c.mtx.Unlock()
+			return
+		}
+		source, cmd, params := parseIRCLine(line)
+		c.mtx.Lock()
+		if c.conn == conn {
+			c.handle(source, cmd, params)
+		}
+		c.mtx.Unlock()
+	}
+}
+
+// handle answers pings of the server and completes pending JOINs. It must be
+// called with the lock held.
+func (c *ircClient) handle(source, cmd, params string) {
+	switch cmd {
+	case "PING":
+		// The server drops the connection if the answer doesn't arrive, so
+		// close it right away if writing fails.
+		c.conn.SetWriteDeadline(time.Now().Add(ircWriteTimeout))
+		if err := c.write("PONG " + params); err != nil {
+			level.Debug(c.logger).Log("msg", "Answering IRC ping failed", "err", err)
+			c.close()
+			return
+		}
+		c.conn.SetWriteDeadline(time.Time{})
+	case "JOIN":
+		// The server confirms a JOIN by relaying it back. JOINs of other
+		// users are relayed as well.
+		if !strings.EqualFold(source, c.nick) {
+			return
+		}
+		if f := strings.Fields(strings.TrimPrefix(params, ":")); len(f) > 0 {
+			c.finishJoin(f[0], nil)
+		}
+	case "403", "405", "471", "473", "474", "475", "476", "477":
+		// Errors replying to a JOIN carry the nick and the channel.
+		if f := strings.SplitN(params, " ", 3); len(f) == 3 {
+			c.finishJoin(f[1], fmt.Errorf("joining IRC channel %s: %s %s", f[1], cmd, strings.TrimPrefix(f[2], ":")))
+		}
+	}
+}
+
+// finishJoin completes the pending JOIN of the channel, if any. The channel
+// is joined if err is nil. It must be called with the lock held.
+func (c *ircClient) finishJoin(channel string, err error) {
+	key := ircChannelKey(channel)
+	j, ok := c.joins[key]
+	if !ok {
+		return
+	}
+	delete(c.joins, key)
+	if err == nil {
+		c.joined[key] = true
+	}
+	j.err = err
+	close(j.done)
+}
+
+// write writes the lines to the connection. It must be called with the
+// lock held.
+func (c *ircClient) write(lines ...string) error {
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(l)
+		b.WriteString("\r\n")
+	}
+	_, err := c.conn.Write([]byte(b.String()))
+	return err
+}
+
+// close closes the connection and fails the pending JOINs. It must be called
+// with the lock held.
+func (c *ircClient) close() {
+	c.conn.Close()
+	c.conn = nil
+	for _, j := range c.joins {
+		j.err = errors.New("IRC connection closed")
+		close(j.done)
+	}
+	c.joins = nil
+}
+
+// ircChannelKey returns the key of the channel in the maps of a client, as
+// channel names are case-insensitive.
+func ircChannelKey(channel string) string {
+	return strings.ToLower(channel)
+}
+
+// parseIRCLine returns the source, the command and the parameters of a line
+// received from an IRC server. The source is the nick or the server name of
+// the prefix and empty if the line has none.
+func parseIRCLine(line string) (source, cmd, params string) {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, ":") {
+		i := strings.Index(line, " ")
+		if i < 0 {
+			return "", "", ""
+		}
+		// The prefix is either a server name or "nick!user@host".
+		source = line[1:i]
+		if j := strings.IndexAny(source, "!@"); j >= 0 {
+			source = source[:j]
+		}
+		line = line[i+1:]
+	}
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) == 1 {
+		return source, parts[0], ""
+	}
+	return source, parts[0], parts[1]
+}
@@ -0,0 +1,268 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	commoncfg "github.com/prometheus/common/config"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+// MQTT control packet types.
+// http://docs.oasis-open.org/mqtt/mqtt/v3.1.1/os/mqtt-v3.1.1-os.html
+const (
+	mqttConnect    = 1
+	mqttConnAck    = 2
+	mqttPublish    = 3
+	mqttPubAck     = 4
+	mqttPubRec     = 5
+	mqttPubRel     = 6
+	mqttPubComp    = 7
+	mqttDisconnect = 14
+)
+
+// mqttPacketID is the identifier of the only message published per
+// connection.
+const mqttPacketID = 1
+
+// mqttError is an error returned by the broker that isn't recoverable by
+// retrying.
+type mqttError struct {
+	msg string
+}
+
+func (e *mqttError) Error() string {
+	return e.msg
+}
+
+// Retryable implements the NotifyError interface.
+func (e *mqttError) Retryable() bool {
+	return false
+}
+
+// mqttConnectErrors are the reasons for refused connections by return code.
+var mqttConnectErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// mqttClientID returns a random client identifier. Brokers close the
+// connection of a client when another one connects with the same identifier,
+// so concurrent connections must not share it. It is 23 bytes long, the
+// maximum length brokers have to accept.
+func mqttClientID() string {
+	b := make([]byte, 5)
+	rand.Read(b)
+	return "alertmanager-" + hex.EncodeToString(b)
+}
+
+// mqttPacket is an MQTT control packet.
+type mqttPacket struct {
+	typ   byte
+	flags byte
+	body  []byte
+}
+
+// publishMQTT connects to the broker of the config, publishes the payload
+// and disconnects. The connection is not kept because notifications are
+// rare compared to the keep alive interval of brokers.
+func publishMQTT(ctx context.Context, conf *config.MQTTConfig, payload []byte) error {
+	conn, err := dialMQTT(ctx, conf)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	r := bufio.NewReader(conn)
+
+	// Connect with a clean session and a keep alive of 60 seconds.
+	var b bytes.Buffer
+	mqttWriteString(&b, "MQTT")
+	b.WriteByte(4)
+	flags := byte(0x02)
+	if conf.Username != "" {
+		flags |= 0x80
+	}
+	if conf.Password != "" {
+		flags |= 0x40
+	}
+	b.WriteByte(flags)
+	binary.Write(&b, binary.BigEndian, uint16(60))
+	clientID := conf.ClientID
+	if clientID == "" {
+		clientID = mqttClientID()
+	}
+	mqttWriteString(&b, clientID)
+	if conf.Username != "" {
+		mqttWriteString(&b, conf.Username)
+	}
+	if conf.Password != "" {
+		mqttWriteString(&b, string(conf.Password))
+	}
+	if err := mqttWritePacket(conn, mqttPacket{typ: mqttConnect, body: b.Bytes()}); err != nil {
+		return err
+	}
+	p, err := mqttReadPacket(r)
+	if err != nil {
+		return err
+	}
+	if p.typ != mqttConnAck || len(p.body) != 2 {
+		return fmt.Errorf("unexpected MQTT packet type %d, expected CONNACK", p.typ)
+	}
+	if rc := p.body[1]; rc != 0 {
+		msg := fmt.Sprintf("MQTT connection refused: %s", mqttConnectErrors[rc])
+		// An unavailable server is the only temporary failure.
+		if rc == 3 {
+			return errors.New(msg)
+		}
+		return &mqttError{msg: msg}
+	}
+
+	b.Reset()
+	mqttWriteString(&b, conf.Topic)
+	if conf.QoS > 0 {
+		binary.Write(&b, binary.BigEndian, uint16(mqttPacketID))
+	}
+	b.Write(payload)
+	flags = byte(conf.QoS) << 1
+	if conf.Retain {
+		flags |= 0x01
+	}
+	if err := mqttWritePacket(conn, mqttPacket{typ: mqttPublish, flags: flags, body: b.Bytes()}); err != nil {
+		return err
+	}
+
+	// QoS 1 is acknowledged with PUBACK, QoS 2 with PUBREC, PUBREL and PUBCOMP.
+	switch conf.QoS {
+	case 1:
+		if err := mqttExpectAck(r, mqttPubAck); err != nil {
+			return err
+		}
+	case 2:
+		if err := mqttExpectAck(r, mqttPubRec); err != nil {
+			return err
+		}
+		if err := mqttWritePacket(conn, mqttPacket{typ: mqttPubRel, flags: 0x02, body: []byte{0, mqttPacketID}}); err != nil {
+			return err
+		}
+		if err := mqttExpectAck(r, mqttPubComp); err != nil {
+			return err
+		}
+	}
+
+	return mqttWritePacket(conn, mqttPacket{typ: mqttDisconnect})
+}
+
+// dialMQTT connects to the broker. The schemes ssl, tls and mqtts use TLS.
+func dialMQTT(ctx context.Context, conf *config.MQTTConfig) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", conf.BrokerURL.Host)
+	if err != nil {
+		return nil, err
+	}
+	switch conf.BrokerURL.Scheme {
+	case "ssl", "tls", "mqtts":
+		tlsConfig, err := commoncfg.NewTLSConfig(&conf.TLSConfig)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = conf.BrokerURL.Hostname()
+		}
+		conn = tls.Client(conn, tlsConfig)
+	}
+	return conn, nil
+}
+
+// mqttExpectAck reads an acknowledgement of the given type for the
+// published message.
+func mqttExpectAck(r *bufio.Reader, typ byte) error {
+	p, err := mqttReadPacket(r)
+	if err != nil {
+		return err
+	}
+	if p.typ != typ || len(p.body) != 2 || binary.BigEndian.Uint16(p.body) != mqttPacketID {
+		return fmt.Errorf("unexpected MQTT packet type %d, expected %d", p.typ, typ)
+	}
+	return nil
+}
+
+func mqttWriteString(b *bytes.Buffer, s string) {
+	binary.Write(b, binary.BigEndian, uint16(len(s)))
+	b.WriteString(s)
+}
+
+func mqttWritePacket(w io.Writer, p mqttPacket) error {
+	b := []byte{p.typ<<4 | p.flags}
+	// The remaining length is encoded with 7 bits per byte.
+	n := len(p.body)
+	for {
+		d := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			d |= 0x80
+		}
+		b = append(b, d)
+		if n == 0 {
+			break
+		}
+	}
+	_, err := w.Write(append(b, p.body...))
+	return err
+}
+
+func mqttReadPacket(r *bufio.Reader) (mqttPacket, error) {
+	h, err := r.ReadByte()
+	if err != nil {
+		return mqttPacket{}, err
+	}
+	var n, shift uint
+	for i := 0; ; i++ {
+		if i == 4 {
+			return mqttPacket{}, fmt.Errorf("malformed MQTT remaining length")
+		}
+		d, err := r.ReadByte()
+		if err != nil {
+			return mqttPacket{}, err
+		}
+		n |= uint(d&0x7f) << shift
+		shift += 7
+		if d&0x80 == 0 {
+			break
+		}
+	}
+	p := mqttPacket{typ: h >> 4, flags: h & 0x0f, body: make([]byte, n)}
+	if _, err := io.ReadFull(r, p.body); err != nil {
+		return mqttPacket{}, err
+	}
+	return p, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// serveMQTT is a minimal MQTT broker answering the connection with the
+// given return code. It sends the received packets on the returned channel.
+func serveMQTT(ln net.Listener, returnCode byte) <-chan mqttPacket {
+	out := make(chan mqttPacket, 10)
+	go func() {
+		defer close(out)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+
+		for {
+			p, err := mqttReadPacket(r)
+			if err != nil {
+				return
+			}
+			out <- p
+
+			switch p.typ {
+			case mqttConnect:
+				mqttWritePacket(conn, mqttPacket{typ: mqttConnAck, body: []byte{0, returnCode}})
+			case mqttPublish:
+				qos := p.flags >> 1 & 0x03
+				if qos == 0 {
+					continue
+				}
+				// The packet identifier follows the topic.
+				n := binary.BigEndian.Uint16(p.body)
+				id := p.body[2+n : 4+n]
+				if qos == 1 {
+					mqttWritePacket(conn, mqttPacket{typ: mqttPubAck, body: id})
+				} else {
+					mqttWritePacket(conn, mqttPacket{typ: mqttPubRec, body: id})
+				}
+			case mqttPubRel:
+				mqttWritePacket(conn, mqttPacket{typ: mqttPubComp, body: p.body})
+			}
+		}
+	}()
+	return out
+}
+
+func TestMQTTPublish(t *testing.T) {
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+
+	for qos := 0; qos <= 2; qos++ {
+		t.Run(fmt.Sprintf("qos %d", qos), func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer ln.Close()
+			packets := serveMQTT(ln, 0)
+
+			var conf config.MQTTConfig
+			require.NoError(t, yaml.UnmarshalStrict([]byte(fmt.Sprintf(`
+broker_url: 'tcp://%s'
+username: 'am'
+password: 'secret'
+topic: 'alerts/ops'
+qos: %d
+retain: true
+`, ln.Addr(), qos)), &conf))
+			notifier := NewMQTT(&conf, createTmpl(t), log.NewNopLogger())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			ctx = WithGroupKey(ctx, "1")
+
+			retry, err := notifier.Notify(ctx, alert)
+			require.NoError(t, err)
+			require.False(t, retry)
+
+			var received []byte
+			for p := range packets {
+				received = append(received, p.typ)
+				switch p.typ {
+				case mqttConnect:
+					// Protocol name and level, flags and keep alive.
+					require.Equal(t, []byte{0, 4, 'M', 'Q', 'T', 'T', 4, 0xc2, 0, 60}, p.body[:10])
+					// Every connection gets its own client ID by default.
+					require.Regexp(t, "alertmanager-[0-9a-f]{10}", string(p.body))
+				case mqttPublish:
+					require.Equal(t, byte(qos)<<1|0x01, p.flags)
+					n := binary.BigEndian.Uint16(p.body)
+					require.Equal(t, "alerts/ops", string(p.body[2:2+n]))
+					payload := p.body[2+n:]
+					if qos > 0 {
+						payload = payload[2:]
+					}
+					var msg WebhookMessage
+					require.NoError(t, json.Unmarshal(payload, &msg))
+					require.Equal(t, "1", msg.GroupKey)
+					require.Equal(t, "HighLatency", msg.Alerts[0].Labels["alertname"])
+				}
+			}
+			expected := []byte{mqttConnect, mqttPublish}
+			if qos == 2 {
+				expected = append(expected, mqttPubRel)
+			}
+			expected = append(expected, mqttDisconnect)
+			require.Equal(t, expected, received)
+		})
+	}
+}
+
+func TestMQTTConnectionRefused(t *testing.T) {
+	for _, tc := range []struct {
+		returnCode byte
+		retry      bool
+		err        string
+	}{
+		{
+			returnCode: 3,
+			retry:      true,
+			err:        "MQTT connection refused: server unavailable",
+		},
+		{
+			returnCode: 4,
+			retry:      false,
+			err:        "MQTT connection refused: bad user name or password",
+		},
+	} {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+		serveMQTT(ln, tc.returnCode)
+
+		var conf config.MQTTConfig
+		require.NoError(t, yaml.UnmarshalStrict([]byte(`
+broker_url: 'tcp://`+ln.Addr().String()+`'
+topic: 'alerts'
+`), &conf))
+		notifier := NewMQTT(&conf, createTmpl(t), log.NewNopLogger())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		retry, err := notifier.Notify(ctx, &types.Alert{})
+		require.EqualError(t, err, tc.err)
+		require.Equal(t, tc.retry, retry)
+	}
+}
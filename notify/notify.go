@@ -31,6 +31,7 @@ import (
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/nflog"
 	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/pkg/timeinterval"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/types"
@@ -49,12 +50,30 @@ var (
 		Help:      "The total number of failed notifications.",
 	}, []string{"integration"})
 
+	numPermanentlyFailedNotifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "alertmanager",
+		Name:      "notifications_failed_permanently_total",
+		Help:      "The total number of notifications failed with an error that isn't retried.",
+	}, []string{"integration"})
+
+	numNotificationRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "alertmanager",
+		Name:      "notification_retries_total",
+		Help:      "The total number of retried notification attempts.",
+	}, []string{"integration"})
+
 	notificationLatencySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: "alertmanager",
 		Name:      "notification_latency_seconds",
 		Help:      "The latency of notifications in seconds.",
 		Buckets:   []float64{1, 5, 10, 15, 20},
 	}, []string{"integration"})
+
+	numRateLimitedNotifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "alertmanager",
+		Name:      "notifications_rate_limited_total",
+		Help:      "The total number of notifications exceeding the rate limit of their receiver.",
+	}, []string{"integration"})
 )
 
 func init() {
@@ -67,6 +86,24 @@ func init() {
 	numNotifications.WithLabelValues("opsgenie")
 	numNotifications.WithLabelValues("webhook")
 	numNotifications.WithLabelValues("victorops")
+	numNotifications.WithLabelValues("msteams")
+	numNotifications.WithLabelValues("telegram")
+	numNotifications.WithLabelValues("sns")
+	numNotifications.WithLabelValues("twilio")
+	numNotifications.WithLabelValues("discord")
+	numNotifications.WithLabelValues("matrix")
+	numNotifications.WithLabelValues("irc")
+	numNotifications.WithLabelValues("xmpp")
+	numNotifications.WithLabelValues("webex")
+	numNotifications.WithLabelValues("rocketchat")
+	numNotifications.WithLabelValues("googlechat")
+	numNotifications.WithLabelValues("exec")
+	numNotifications.WithLabelValues("mqtt")
+	numNotifications.WithLabelValues("kafka")
+	numNotifications.WithLabelValues("jira")
+	numNotifications.WithLabelValues("servicenow")
+	numNotifications.WithLabelValues("zendesk")
+	numNotifications.WithLabelValues("github")
 	numFailedNotifications.WithLabelValues("email")
 	numFailedNotifications.WithLabelValues("hipchat")
 	numFailedNotifications.WithLabelValues("pagerduty")
@@ -76,6 +113,78 @@ func init() {
 	numFailedNotifications.WithLabelValues("opsgenie")
 	numFailedNotifications.WithLabelValues("webhook")
 	numFailedNotifications.WithLabelValues("victorops")
+	numFailedNotifications.WithLabelValues("msteams")
+	numFailedNotifications.WithLabelValues("telegram")
+	numFailedNotifications.WithLabelValues("sns")
+	numFailedNotifications.WithLabelValues("twilio")
+	numFailedNotifications.WithLabelValues("discord")
+	numFailedNotifications.WithLabelValues("matrix")
+	numFailedNotifications.WithLabelValues("irc")
+	numFailedNotifications.WithLabelValues("xmpp")
+	numFailedNotifications.WithLabelValues("webex")
+	numFailedNotifications.WithLabelValues("rocketchat")
+	numFailedNotifications.WithLabelValues("googlechat")
+	numFailedNotifications.WithLabelValues("exec")
+	numFailedNotifications.WithLabelValues("mqtt")
+	numFailedNotifications.WithLabelValues("kafka")
+	numFailedNotifications.WithLabelValues("jira")
+	numFailedNotifications.WithLabelValues("servicenow")
+	numFailedNotifications.WithLabelValues("zendesk")
+	numFailedNotifications.WithLabelValues("github")
+	numPermanentlyFailedNotifications.WithLabelValues("email")
+	numPermanentlyFailedNotifications.WithLabelValues("hipchat")
+	numPermanentlyFailedNotifications.WithLabelValues("pagerduty")
+	numPermanentlyFailedNotifications.WithLabelValues("wechat")
+	numPermanentlyFailedNotifications.WithLabelValues("pushover")
+	numPermanentlyFailedNotifications.WithLabelValues("slack")
+	numPermanentlyFailedNotifications.WithLabelValues("opsgenie")
+	numPermanentlyFailedNotifications.WithLabelValues("webhook")
+	numPermanentlyFailedNotifications.WithLabelValues("victorops")
+	numPermanentlyFailedNotifications.WithLabelValues("msteams")
+	numPermanentlyFailedNotifications.WithLabelValues("telegram")
+	numPermanentlyFailedNotifications.WithLabelValues("sns")
+	numPermanentlyFailedNotifications.WithLabelValues("twilio")
+	numPermanentlyFailedNotifications.WithLabelValues("discord")
+	numPermanentlyFailedNotifications.WithLabelValues("matrix")
+	numPermanentlyFailedNotifications.WithLabelValues("irc")
+	numPermanentlyFailedNotifications.WithLabelValues("xmpp")
+	numPermanentlyFailedNotifications.WithLabelValues("webex")
+	numPermanentlyFailedNotifications.WithLabelValues("rocketchat")
+	numPermanentlyFailedNotifications.WithLabelValues("googlechat")
+	numPermanentlyFailedNotifications.WithLabelValues("exec")
+	numPermanentlyFailedNotifications.WithLabelValues("mqtt")
+	numPermanentlyFailedNotifications.WithLabelValues("kafka")
+	numPermanentlyFailedNotifications.WithLabelValues("jira")
+	numPermanentlyFailedNotifications.WithLabelValues("servicenow")
+	numPermanentlyFailedNotifications.WithLabelValues("zendesk")
+	numPermanentlyFailedNotifications.WithLabelValues("github")
+	numNotificationRetries.WithLabelValues("email")
+	numNotificationRetries.WithLabelValues("hipchat")
+	numNotificationRetries.WithLabelValues("pagerduty")
+	numNotificationRetries.WithLabelValues("wechat")
+	numNotificationRetries.WithLabelValues("pushover")
+	numNotificationRetries.WithLabelValues("slack")
+	numNotificationRetries.WithLabelValues("opsgenie")
+	numNotificationRetries.WithLabelValues("webhook")
+	numNotificationRetries.WithLabelValues("victorops")
+	numNotificationRetries.WithLabelValues("msteams")
+	numNotificationRetries.WithLabelValues("telegram")
+	numNotificationRetries.WithLabelValues("sns")
+	numNotificationRetries.WithLabelValues("twilio")
+	numNotificationRetries.WithLabelValues("discord")
+	numNotificationRetries.WithLabelValues("matrix")
+	numNotificationRetries.WithLabelValues("irc")
+	numNotificationRetries.WithLabelValues("xmpp")
+	numNotificationRetries.WithLabelValues("webex")
+	numNotificationRetries.WithLabelValues("rocketchat")
+	numNotificationRetries.WithLabelValues("googlechat")
+	numNotificationRetries.WithLabelValues("exec")
+	numNotificationRetries.WithLabelValues("mqtt")
+	numNotificationRetries.WithLabelValues("kafka")
+	numNotificationRetries.WithLabelValues("jira")
+	numNotificationRetries.WithLabelValues("servicenow")
+	numNotificationRetries.WithLabelValues("zendesk")
+	numNotificationRetries.WithLabelValues("github")
 	notificationLatencySeconds.WithLabelValues("email")
 	notificationLatencySeconds.WithLabelValues("hipchat")
 	notificationLatencySeconds.WithLabelValues("pagerduty")
@@ -85,10 +194,31 @@ func init() {
 	notificationLatencySeconds.WithLabelValues("opsgenie")
 	notificationLatencySeconds.WithLabelValues("webhook")
 	notificationLatencySeconds.WithLabelValues("victorops")
+	notificationLatencySeconds.WithLabelValues("msteams")
+	notificationLatencySeconds.WithLabelValues("telegram")
+	notificationLatencySeconds.WithLabelValues("sns")
+	notificationLatencySeconds.WithLabelValues("twilio")
+	notificationLatencySeconds.WithLabelValues("discord")
+	notificationLatencySeconds.WithLabelValues("matrix")
+	notificationLatencySeconds.WithLabelValues("irc")
+	notificationLatencySeconds.WithLabelValues("xmpp")
+	notificationLatencySeconds.WithLabelValues("webex")
+	notificationLatencySeconds.WithLabelValues("rocketchat")
+	notificationLatencySeconds.WithLabelValues("googlechat")
+	notificationLatencySeconds.WithLabelValues("exec")
+	notificationLatencySeconds.WithLabelValues("mqtt")
+	notificationLatencySeconds.WithLabelValues("kafka")
+	notificationLatencySeconds.WithLabelValues("jira")
+	notificationLatencySeconds.WithLabelValues("servicenow")
+	notificationLatencySeconds.WithLabelValues("zendesk")
+	notificationLatencySeconds.WithLabelValues("github")
 
 	prometheus.MustRegister(numNotifications)
 	prometheus.MustRegister(numFailedNotifications)
+	prometheus.MustRegister(numPermanentlyFailedNotifications)
+	prometheus.MustRegister(numNotificationRetries)
 	prometheus.MustRegister(notificationLatencySeconds)
+	prometheus.MustRegister(numRateLimitedNotifications)
 }
 
 type notifierConfig interface {
@@ -111,6 +241,8 @@ const (
 	keyFiringAlerts
 	keyResolvedAlerts
 	keyNow
+	keyMuteTimeIntervals
+	keyTruncatedAlerts
 )
 
 // WithReceiverName populates a context with a receiver name.
@@ -143,11 +275,22 @@ func WithNow(ctx context.Context, t time.Time) context.Context {
 	return context.WithValue(ctx, keyNow, t)
 }
 
+// WithMuteTimeIntervals populates a context with the names of mute time intervals.
+func WithMuteTimeIntervals(ctx context.Context, mt []string) context.Context {
+	return context.WithValue(ctx, keyMuteTimeIntervals, mt)
+}
+
 // WithRepeatInterval populates a context with a repeat interval.
 func WithRepeatInterval(ctx context.Context, t time.Duration) context.Context {
 	return context.WithValue(ctx, keyRepeatInterval, t)
 }
 
+// WithTruncatedAlerts populates a context with the number of alerts left
+// out of the notification.
+func WithTruncatedAlerts(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, keyTruncatedAlerts, n)
+}
+
 // RepeatInterval extracts a repeat interval from the context. Iff none exists, the
 // second argument is false.
 func RepeatInterval(ctx context.Context) (time.Duration, bool) {
@@ -155,6 +298,20 @@ func RepeatInterval(ctx context.Context) (time.Duration, bool) {
 	return v, ok
 }
 
+// MuteTimeIntervalNames extracts the names of mute time intervals from the
+// context. Iff none exists, the second argument is false.
+func MuteTimeIntervalNames(ctx context.Context) ([]string, bool) {
+	v, ok := ctx.Value(keyMuteTimeIntervals).([]string)
+	return v, ok
+}
+
+// TruncatedAlerts extracts the number of alerts left out of the notification
+// from the context. Iff none exists, the second argument is false.
+func TruncatedAlerts(ctx context.Context) (int, bool) {
+	v, ok := ctx.Value(keyTruncatedAlerts).(int)
+	return v, ok
+}
+
 // ReceiverName extracts a receiver name from the context. Iff none exists, the
 // second argument is false.
 func ReceiverName(ctx context.Context) (string, bool) {
@@ -234,6 +391,7 @@ type NotificationLog interface {
 // BuildPipeline builds a map of receivers to Stages.
 func BuildPipeline(
 	confs []*config.Receiver,
+	muteTimes map[string][]timeinterval.TimeInterval,
 	tmpl *template.Template,
 	wait func() time.Duration,
 	muter types.Muter,
@@ -241,38 +399,65 @@ func BuildPipeline(
 	notificationLog NotificationLog,
 	marker types.Marker,
 	peer *cluster.Peer,
+	maxRetries int,
+	deadLetters *DeadLetters,
+	history *NotificationHistory,
+	rateLimits *RateLimits,
 	logger log.Logger,
 ) RoutingStage {
 	rs := RoutingStage{}
 
+	if rateLimits == nil {
+		rateLimits = NewRateLimits()
+	}
+	rateLimits.retain(confs)
+
 	ms := NewGossipSettleStage(peer)
 	is := NewInhibitStage(muter)
+	tms := NewTimeMuteStage(muteTimes)
 	ss := NewSilenceStage(silences, marker)
 
+	integrations := map[string]Integration{}
 	for _, rc := range confs {
-		rs[rc.Name] = MultiStage{ms, is, ss, createStage(rc, tmpl, wait, notificationLog, logger)}
+		var fs FanoutStage
+		for _, i := range BuildReceiverIntegrations(rc, tmpl, logger) {
+			fs = append(fs, createStage(rc, i, wait, notificationLog, maxRetries, deadLetters, history, rateLimits))
+			integrations[integrationKey(rc.Name, i.name, i.idx)] = i
+		}
+		rs[rc.Name] = MultiStage{ms, is, tms, ss, fs}
+	}
+	if deadLetters != nil {
+		deadLetters.setIntegrations(integrations)
 	}
 	return rs
 }
 
-// createStage creates a pipeline of stages for a receiver.
-func createStage(rc *config.Receiver, tmpl *template.Template, wait func() time.Duration, notificationLog NotificationLog, logger log.Logger) Stage {
-	var fs FanoutStage
-	for _, i := range BuildReceiverIntegrations(rc, tmpl, logger) {
-		recv := &nflogpb.Receiver{
-			GroupName:   rc.Name,
-			Integration: i.name,
-			Idx:         uint32(i.idx),
-		}
-		var s MultiStage
-		s = append(s, NewWaitStage(wait))
-		s = append(s, NewDedupStage(i, notificationLog, recv))
-		s = append(s, NewRetryStage(i, rc.Name))
-		s = append(s, NewSetNotifiesStage(notificationLog, recv))
-
-		fs = append(fs, s)
+// createStage creates a pipeline of stages for an integration of a receiver.
+func createStage(rc *config.Receiver, i Integration, wait func() time.Duration, notificationLog NotificationLog, maxRetries int, deadLetters *DeadLetters, history *NotificationHistory, rateLimits *RateLimits) Stage {
+	recv := &nflogpb.Receiver{
+		GroupName:   rc.Name,
+		Integration: i.name,
+		Idx:         uint32(i.idx),
+	}
+	var s MultiStage
+	s = append(s, NewWaitStage(wait))
+	s = append(s, NewDedupStage(i, notificationLog, recv))
+	if rc.RateLimit != nil {
+		s = append(s, NewRateLimitStage(i, rc.Name, rc.RateLimit, rateLimits))
+	}
+	if rc.MaxAlerts > 0 {
+		s = append(s, NewTruncateStage(rc.MaxAlerts))
 	}
-	return fs
+	var send Stage = NewRetryStage(i, rc.Name, maxRetries)
+	if deadLetters != nil {
+		send = NewDeadLetterStage(send, deadLetters, rc.Name, i)
+	}
+	if history != nil {
+		send = NewHistoryStage(send, history, rc.Name, i)
+	}
+	s = append(s, send)
+	s = append(s, NewSetNotifiesStage(notificationLog, recv))
+	return s
 }
 
 // RoutingStage executes the inner stages based on the receiver specified in
@@ -384,6 +569,38 @@ func (n *InhibitStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.
 	return ctx, filtered, nil
 }
 
+// TimeMuteStage mutes notifications during the mute time intervals of a route.
+type TimeMuteStage struct {
+	muteTimes map[string][]timeinterval.TimeInterval
+}
+
+// NewTimeMuteStage returns a new TimeMuteStage.
+func NewTimeMuteStage(mt map[string][]timeinterval.TimeInterval) *TimeMuteStage {
+	return &TimeMuteStage{muteTimes: mt}
+}
+
+// Exec implements the Stage interface.
+func (n *TimeMuteStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	names, ok := MuteTimeIntervalNames(ctx)
+	if !ok || len(names) == 0 {
+		return ctx, alerts, nil
+	}
+	now, ok := Now(ctx)
+	if !ok {
+		return ctx, alerts, fmt.Errorf("missing now timestamp")
+	}
+
+	for _, name := range names {
+		for _, ti := range n.muteTimes[name] {
+			if ti.ContainsTime(now) {
+				level.Debug(l).Log("msg", "Notifications not sent, route is within mute time", "interval", name)
+				return ctx, nil, nil
+			}
+		}
+	}
+	return ctx, alerts, nil
+}
+
 // SilenceStage filters alerts through a silence muter.
 type SilenceStage struct {
 	silences *silence.Silences
@@ -598,18 +815,277 @@ func (n *DedupStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Al
 	return ctx, nil, nil
 }
 
+// tokenBucket is a token bucket refilled continuously at a fixed rate.
+type tokenBucket struct {
+	mtx    sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit int, interval time.Duration, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(limit) / interval.Seconds(),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+// refill adds the tokens accumulated since the last call. It must be called
+// with the lock held.
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+}
+
+// allow takes a token if one is available.
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.refill(now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// reserve takes a token and returns how long to wait until it is available.
+// The token must be given back with cancel if the caller doesn't wait.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a reserved token.
+func (b *tokenBucket) cancel() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.tokens++
+}
+
+// RateLimits keeps the token buckets of the receivers with a rate limit
+// across configuration reloads. The integrations of a receiver share its
+// bucket.
+type RateLimits struct {
+	mtx     sync.Mutex
+	buckets map[string]*rateLimitBucket
+}
+
+type rateLimitBucket struct {
+	conf   config.RateLimitConfig
+	bucket *tokenBucket
+}
+
+// NewRateLimits returns new RateLimits without any buckets.
+func NewRateLimits() *RateLimits {
+	return &RateLimits{buckets: map[string]*rateLimitBucket{}}
+}
+
+// bucket returns the bucket of the receiver. A new one is only created if
+// the receiver had none or its limit changed.
+func (r *RateLimits) bucket(receiver string, conf *config.RateLimitConfig) *tokenBucket {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	b, ok := r.buckets[receiver]
+	if !ok || b.conf.Limit != conf.Limit || b.conf.Interval != conf.Interval || b.conf.Burst != conf.Burst {
+		b = &rateLimitBucket{
+			conf:   *conf,
+			bucket: newTokenBucket(conf.Limit, time.Duration(conf.Interval), conf.Burst, time.Now()),
+		}
+		r.buckets[receiver] = b
+	}
+	return b.bucket
+}
+
+// retain drops the buckets of receivers which are gone or not rate limited
+// anymore.
+func (r *RateLimits) retain(confs []*config.Receiver) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	limited := map[string]struct{}{}
+	for _, rc := range confs {
+		if rc.RateLimit != nil {
+			limited[rc.Name] = struct{}{}
+		}
+	}
+	for receiver := range r.buckets {
+		if _, ok := limited[receiver]; !ok {
+			delete(r.buckets, receiver)
+		}
+	}
+}
+
+// RateLimitStage limits the rate of notifications sent by the integrations
+// of a receiver.
+type RateLimitStage struct {
+	integration string
+	overflow    string
+	bucket      *tokenBucket
+	now         func() time.Time
+
+	mtx sync.Mutex
+	// The alerts held back by the summarize overflow behavior by group key.
+	// They are only added to the next notification of their own group.
+	pending map[string]*heldBackAlerts
+}
+
+// heldBackAlerts are the alerts of a group held back by the rate limit.
+type heldBackAlerts struct {
+	alerts map[model.Fingerprint]*types.Alert
+	// Groups which still exist pass the stage at least once per repeat
+	// interval. The alerts are dropped if their group didn't until then.
+	expires time.Time
+}
+
+// NewRateLimitStage returns a new RateLimitStage for the integration of the
+// receiver, using the bucket of the receiver in the rate limits.
+func NewRateLimitStage(i Integration, receiver string, conf *config.RateLimitConfig, rateLimits *RateLimits) *RateLimitStage {
+	return &RateLimitStage{
+		integration: i.name,
+		overflow:    conf.Overflow,
+		bucket:      rateLimits.bucket(receiver, conf),
+		now:         time.Now,
+		pending:     map[string]*heldBackAlerts{},
+	}
+}
+
+// Exec implements the Stage interface.
+func (n *RateLimitStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	if n.overflow == config.RateLimitDelay {
+		wait := n.bucket.reserve(n.now())
+		if wait == 0 {
+			return ctx, alerts, nil
+		}
+		numRateLimitedNotifications.WithLabelValues(n.integration).Inc()
+		level.Debug(l).Log("msg", "Notification delayed by rate limit", "integration", n.integration, "wait", wait)
+		select {
+		case <-time.After(wait):
+			return ctx, alerts, nil
+		case <-ctx.Done():
+			n.bucket.cancel()
+			return ctx, nil, ctx.Err()
+		}
+	}
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	now := n.now()
+	for gkey, p := range n.pending {
+		if now.After(p.expires) {
+			delete(n.pending, gkey)
+		}
+	}
+
+	gkey, _ := GroupKey(ctx)
+	if !n.bucket.allow(now) {
+		numRateLimitedNotifications.WithLabelValues(n.integration).Inc()
+		if n.overflow == config.RateLimitSummarize {
+			level.Debug(l).Log("msg", "Notification held back by rate limit", "integration", n.integration)
+			pending, ok := n.pending[gkey]
+			if !ok {
+				pending = &heldBackAlerts{alerts: map[model.Fingerprint]*types.Alert{}}
+				n.pending[gkey] = pending
+			}
+			for _, a := range alerts {
+				pending.alerts[a.Fingerprint()] = a
+			}
+			repeatInterval, _ := RepeatInterval(ctx)
+			pending.expires = now.Add(2 * repeatInterval)
+		} else {
+			level.Debug(l).Log("msg", "Notification dropped by rate limit", "integration", n.integration)
+		}
+		return ctx, nil, nil
+	}
+
+	pending, ok := n.pending[gkey]
+	if !ok {
+		return ctx, alerts, nil
+	}
+	delete(n.pending, gkey)
+	// Add the alerts of the group held back to the notification, preferring
+	// the current state of alerts which are part of it.
+	merged := make(types.AlertSlice, 0, len(alerts)+len(pending.alerts))
+	for _, a := range alerts {
+		delete(pending.alerts, a.Fingerprint())
+		merged = append(merged, a)
+	}
+	for _, a := range pending.alerts {
+		merged = append(merged, a)
+	}
+	sort.Sort(merged)
+
+	return ctx, merged, nil
+}
+
+// TruncateStage limits the number of alerts in a notification. Firing
+// alerts are kept in preference to resolved ones.
+type TruncateStage struct {
+	max int
+}
+
+// NewTruncateStage returns a new TruncateStage keeping up to max alerts.
+func NewTruncateStage(max int) *TruncateStage {
+	return &TruncateStage{max: max}
+}
+
+// Exec implements the Stage interface.
+func (n *TruncateStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	if len(alerts) <= n.max {
+		return ctx, alerts, nil
+	}
+
+	kept := make([]*types.Alert, 0, n.max)
+	for _, a := range alerts {
+		if len(kept) < n.max && !a.Resolved() {
+			kept = append(kept, a)
+		}
+	}
+	for _, a := range alerts {
+		if len(kept) < n.max && a.Resolved() {
+			kept = append(kept, a)
+		}
+	}
+	level.Debug(l).Log("msg", "Truncated alerts of notification", "kept", len(kept), "truncated", len(alerts)-len(kept))
+
+	return WithTruncatedAlerts(ctx, len(alerts)-len(kept)), kept, nil
+}
+
 // RetryStage notifies via passed integration with exponential backoff until it
 // succeeds. It aborts if the context is canceled or timed out.
 type RetryStage struct {
 	integration Integration
 	groupName   string
+	// The maximum number of retries after the first attempt. If zero,
+	// retries continue until the context is done.
+	maxRetries int
 }
 
 // NewRetryStage returns a new instance of a RetryStage.
-func NewRetryStage(i Integration, groupName string) *RetryStage {
+func NewRetryStage(i Integration, groupName string, maxRetries int) *RetryStage {
 	return &RetryStage{
 		integration: i,
 		groupName:   groupName,
+		maxRetries:  maxRetries,
 	}
 }
 
@@ -660,15 +1136,23 @@ func (r RetryStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Ale
 
 		select {
 		case <-tick.C:
+			if i > 1 {
+				numNotificationRetries.WithLabelValues(r.integration.name).Inc()
+			}
 			now := time.Now()
 			retry, err := r.integration.Notify(ctx, sent...)
 			notificationLatencySeconds.WithLabelValues(r.integration.name).Observe(time.Since(now).Seconds())
 			if err != nil {
 				numFailedNotifications.WithLabelValues(r.integration.name).Inc()
-				level.Debug(l).Log("msg", "Notify attempt failed", "attempt", i, "integration", r.integration.name, "receiver", r.groupName, "err", err)
-				if !retry {
+				if !isRetryable(err, retry) {
+					numPermanentlyFailedNotifications.WithLabelValues(r.integration.name).Inc()
+					level.Warn(l).Log("msg", "Notify attempt failed permanently", "attempt", i, "integration", r.integration.name, "receiver", r.groupName, "err", err)
 					return ctx, alerts, fmt.Errorf("cancelling notify retry for %q due to unrecoverable error: %s", r.integration.name, err)
 				}
+				level.Debug(l).Log("msg", "Notify attempt failed", "attempt", i, "integration", r.integration.name, "receiver", r.groupName, "err", err)
+				if r.maxRetries > 0 && i > r.maxRetries {
+					return ctx, nil, fmt.Errorf("giving up notify retry for %q after %d attempts: %s", r.integration.name, i, err)
+				}
 
 				// Save this error to be able to return the last seen error by an
 				// integration upon context timeout.
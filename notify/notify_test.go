@@ -25,8 +25,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 
+	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/nflog"
 	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/pkg/timeinterval"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
 	"github.com/prometheus/alertmanager/types"
@@ -371,6 +373,185 @@ func TestRoutingStage(t *testing.T) {
 	}
 }
 
+func TestRateLimitStage(t *testing.T) {
+	now := time.Now()
+	newStage := func(overflow string) *RateLimitStage {
+		s := NewRateLimitStage(Integration{name: "test"}, "test", &config.RateLimitConfig{
+			Limit:    1,
+			Interval: model.Duration(time.Minute),
+			Burst:    1,
+			Overflow: overflow,
+		}, NewRateLimits())
+		s.bucket = newTokenBucket(1, time.Minute, 1, now)
+		s.now = func() time.Time { return now }
+		return s
+	}
+	newAlert := func(name string) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels: model.LabelSet{"alertname": model.LabelValue(name)},
+				EndsAt: now.Add(time.Hour),
+			},
+		}
+	}
+	a1, a2, a3 := newAlert("a1"), newAlert("a2"), newAlert("a3")
+	ctx := WithRepeatInterval(context.Background(), time.Hour)
+
+	// Notifications exceeding the limit are dropped.
+	s := newStage(config.RateLimitDrop)
+	_, res, err := s.Exec(ctx, log.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1}, res)
+	_, res, err = s.Exec(ctx, log.NewNopLogger(), a2)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	now = now.Add(time.Minute)
+	_, res, err = s.Exec(ctx, log.NewNopLogger(), a2)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a2}, res)
+
+	// Notifications exceeding the limit are added to the next one.
+	s = newStage(config.RateLimitSummarize)
+	_, res, err = s.Exec(ctx, log.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1}, res)
+	_, res, err = s.Exec(ctx, log.NewNopLogger(), a2)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	_, res, err = s.Exec(ctx, log.NewNopLogger(), a3, a1)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	now = now.Add(time.Minute)
+	_, res, err = s.Exec(ctx, log.NewNopLogger(), a3)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1, a2, a3}, res)
+	now = now.Add(time.Minute)
+	_, res, err = s.Exec(ctx, log.NewNopLogger(), a3)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a3}, res)
+
+	// Alerts held back are only added to the next notification of their
+	// own group.
+	s = newStage(config.RateLimitSummarize)
+	ctx1, ctx2 := WithGroupKey(ctx, "1"), WithGroupKey(ctx, "2")
+	_, res, err = s.Exec(ctx1, log.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1}, res)
+	_, res, err = s.Exec(ctx1, log.NewNopLogger(), a2)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	now = now.Add(time.Minute)
+	_, res, err = s.Exec(ctx2, log.NewNopLogger(), a3)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a3}, res)
+	now = now.Add(time.Minute)
+	_, res, err = s.Exec(ctx1, log.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1, a2}, res)
+
+	// Alerts held back are dropped if their group doesn't notify again
+	// within twice the repeat interval, as it is gone then.
+	s = newStage(config.RateLimitSummarize)
+	_, res, err = s.Exec(ctx1, log.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1}, res)
+	_, res, err = s.Exec(ctx1, log.NewNopLogger(), a2)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	now = now.Add(2*time.Hour + time.Minute)
+	_, res, err = s.Exec(ctx2, log.NewNopLogger(), a3)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a3}, res)
+	require.Empty(t, s.pending)
+
+	// Notifications exceeding the limit wait for a token until the context
+	// is done.
+	s = newStage(config.RateLimitDelay)
+	_, res, err = s.Exec(ctx, log.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1}, res)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, res, err = s.Exec(timeoutCtx, log.NewNopLogger(), a2)
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Nil(t, res)
+	// The token of the cancelled notification is given back.
+	now = now.Add(time.Minute - 20*time.Millisecond)
+	start := time.Now()
+	_, res, err = s.Exec(ctx, log.NewNopLogger(), a2)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a2}, res)
+	require.True(t, time.Since(start) >= 15*time.Millisecond)
+}
+
+func TestRateLimits(t *testing.T) {
+	conf := &config.RateLimitConfig{
+		Limit:    1,
+		Interval: model.Duration(time.Minute),
+		Burst:    1,
+		Overflow: config.RateLimitDrop,
+	}
+	receivers := []*config.Receiver{{Name: "limited", RateLimit: conf}}
+	rateLimits := NewRateLimits()
+
+	// The integrations of a receiver share its bucket.
+	s1 := NewRateLimitStage(Integration{name: "email"}, "limited", conf, rateLimits)
+	s2 := NewRateLimitStage(Integration{name: "webhook"}, "limited", conf, rateLimits)
+	require.True(t, s1.bucket == s2.bucket)
+
+	// The bucket is kept across reloads unless the limit changes.
+	rateLimits.retain(receivers)
+	s3 := NewRateLimitStage(Integration{name: "email"}, "limited", conf, rateLimits)
+	require.True(t, s1.bucket == s3.bucket)
+
+	changed := *conf
+	changed.Limit = 2
+	s4 := NewRateLimitStage(Integration{name: "email"}, "limited", &changed, rateLimits)
+	require.False(t, s1.bucket == s4.bucket)
+
+	// Buckets of receivers which aren't rate limited anymore are dropped.
+	rateLimits.retain([]*config.Receiver{{Name: "limited"}})
+	require.Empty(t, rateLimits.buckets)
+}
+
+func TestTruncateStage(t *testing.T) {
+	now := time.Now()
+	newAlert := func(name string, resolved bool) *types.Alert {
+		a := &types.Alert{
+			Alert: model.Alert{
+				Labels: model.LabelSet{"alertname": model.LabelValue(name)},
+				EndsAt: now.Add(time.Hour),
+			},
+		}
+		if resolved {
+			a.EndsAt = now.Add(-time.Minute)
+		}
+		return a
+	}
+	r1, f1, f2, r2, f3 := newAlert("r1", true), newAlert("f1", false), newAlert("f2", false), newAlert("r2", true), newAlert("f3", false)
+
+	s := NewTruncateStage(3)
+	ctx, res, err := s.Exec(context.Background(), log.NewNopLogger(), r1, f1, f2)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{r1, f1, f2}, res)
+	_, ok := TruncatedAlerts(ctx)
+	require.False(t, ok)
+
+	// Firing alerts are kept first.
+	ctx, res, err = s.Exec(context.Background(), log.NewNopLogger(), r1, f1, f2, r2, f3)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{f1, f2, f3}, res)
+	n, _ := TruncatedAlerts(ctx)
+	require.Equal(t, 2, n)
+
+	s = NewTruncateStage(2)
+	ctx, res, err = s.Exec(context.Background(), log.NewNopLogger(), r1, f1, r2)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{f1, r1}, res)
+	n, _ = TruncatedAlerts(ctx)
+	require.Equal(t, 1, n)
+}
+
 func TestRetryStageWithError(t *testing.T) {
 	fail, retry := true, true
 	sent := []*types.Alert{}
@@ -416,6 +597,72 @@ func TestRetryStageWithError(t *testing.T) {
 	require.NotNil(t, resctx)
 }
 
+func TestRetryStageMaxRetries(t *testing.T) {
+	attempts := 0
+	i := Integration{
+		name: "test",
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			attempts++
+			return true, errors.New("fail to deliver notification")
+		}),
+		conf: notifierConfigFunc(func() bool { return false }),
+	}
+	r := NewRetryStage(i, "", 1)
+
+	alerts := []*types.Alert{
+		&types.Alert{
+			Alert: model.Alert{
+				EndsAt: time.Now().Add(time.Hour),
+			},
+		},
+	}
+
+	ctx := context.Background()
+	ctx = WithFiringAlerts(ctx, []uint64{0})
+
+	_, res, err := r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.EqualError(t, err, `giving up notify retry for "test" after 2 attempts: fail to deliver notification`)
+	require.Nil(t, res)
+	require.Equal(t, 2, attempts)
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return false }
+
+func TestRetryStageErrorClassification(t *testing.T) {
+	for _, tc := range []struct {
+		retry    bool
+		err      error
+		attempts int
+	}{
+		{retry: true, err: errors.New("server error"), attempts: 2},
+		{retry: false, err: errors.New("bad request"), attempts: 1},
+		{retry: true, err: NewPermanentError(errors.New("bad request")), attempts: 1},
+		{retry: false, err: NewRetryableError(errors.New("server error")), attempts: 2},
+		{retry: false, err: timeoutError{}, attempts: 2},
+		{retry: true, err: &mqttError{msg: "not authorized"}, attempts: 1},
+	} {
+		attempts := 0
+		i := Integration{
+			name: "test",
+			notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+				attempts++
+				return tc.retry, tc.err
+			}),
+			conf: notifierConfigFunc(func() bool { return false }),
+		}
+		r := NewRetryStage(i, "", 1)
+
+		ctx := WithFiringAlerts(context.Background(), []uint64{0})
+		_, _, err := r.Exec(ctx, log.NewNopLogger(), &types.Alert{})
+		require.Error(t, err)
+		require.Equal(t, tc.attempts, attempts, "error %q", tc.err)
+	}
+}
+
 func TestRetryStageNoResolved(t *testing.T) {
 	sent := []*types.Alert{}
 	i := Integration{
@@ -582,7 +829,7 @@ func TestSilenceStage(t *testing.T) {
 	if _, err := silences.Set(&silencepb.Silence{
 		EndsAt:   utcNow().Add(time.Hour),
 		Matchers: []*silencepb.Matcher{{Name: "mute", Pattern: "me"}},
-	}); err != nil {
+	}, ""); err != nil {
 		t.Fatal(err)
 	}
 
@@ -679,3 +926,43 @@ func TestInhibitStage(t *testing.T) {
 		t.Fatalf("Muting failed, expected: %v\ngot %v", out, got)
 	}
 }
+
+func TestTimeMuteStage(t *testing.T) {
+	// Monday to Friday, 09:00 to 17:00.
+	businessHours := timeinterval.TimeInterval{
+		Times:    []timeinterval.TimeRange{{StartMinute: 9 * 60, EndMinute: 17 * 60}},
+		Weekdays: []timeinterval.WeekdayRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 1, End: 5}}},
+	}
+	stage := NewTimeMuteStage(map[string][]timeinterval.TimeInterval{
+		"business-hours": {businessHours},
+	})
+
+	alerts := []*types.Alert{{Alert: model.Alert{Labels: model.LabelSet{"foo": "bar"}}}}
+
+	for _, tc := range []struct {
+		now   string
+		names []string
+		muted bool
+	}{
+		// 2018-11-05 is a Monday.
+		{now: "2018-11-05T10:00:00Z", names: []string{"business-hours"}, muted: true},
+		{now: "2018-11-05T18:00:00Z", names: []string{"business-hours"}, muted: false},
+		{now: "2018-11-10T10:00:00Z", names: []string{"business-hours"}, muted: false},
+		{now: "2018-11-05T10:00:00Z", names: nil, muted: false},
+		{now: "2018-11-05T10:00:00Z", names: []string{"unknown"}, muted: false},
+	} {
+		now, err := time.Parse(time.RFC3339, tc.now)
+		require.NoError(t, err)
+
+		ctx := WithNow(context.Background(), now)
+		ctx = WithMuteTimeIntervals(ctx, tc.names)
+
+		_, out, err := stage.Exec(ctx, log.NewNopLogger(), alerts...)
+		require.NoError(t, err)
+		if tc.muted {
+			require.Empty(t, out, "expected alerts to be muted at %s", tc.now)
+		} else {
+			require.Equal(t, alerts, out, "expected alerts not to be muted at %s", tc.now)
+		}
+	}
+}
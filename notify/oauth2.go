@@ -0,0 +1,181 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+// oauth2ExpiryDelta is subtracted from the lifetime of access tokens so
+// they are renewed before they expire during a request.
+const oauth2ExpiryDelta = 10 * time.Second
+
+// oauth2Token caches the access token a notifier obtained with the OAuth 2.0
+// client credentials grant until it expires or is rejected. It is shared by
+// the HTTP clients built for the notifications of the notifier.
+type oauth2Token struct {
+	conf *config.OAuth2Config
+	now  func() time.Time
+
+	mtx    sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// newOAuth2Token returns a new token cache for the HTTP client configuration
+// or nil if it doesn't use OAuth2.
+func newOAuth2Token(conf *config.HTTPClientConfig) *oauth2Token {
+	if conf == nil || conf.OAuth2 == nil {
+		return nil
+	}
+	return &oauth2Token{
+		conf: conf.OAuth2,
+		now:  time.Now,
+	}
+}
+
+// oauth2RoundTripper authenticates requests with the access token of an
+// oauth2Token.
+type oauth2RoundTripper struct {
+	tok *oauth2Token
+	rt  http.RoundTripper
+}
+
+func newOAuth2RoundTripper(tok *oauth2Token, rt http.RoundTripper) *oauth2RoundTripper {
+	return &oauth2RoundTripper{
+		tok: tok,
+		rt:  rt,
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *oauth2RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.tok.get(req.Context(), t.rt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Round trippers must not modify the request.
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := t.rt.RoundTrip(r)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		// The token may have been revoked, request a new one on the next
+		// attempt.
+		t.tok.invalidate(token)
+	}
+	return resp, err
+}
+
+// invalidate drops the cached token if it is still the given one.
+func (t *oauth2Token) invalidate(token string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+// get returns the cached access token or requests a new one using the
+// round tripper.
+func (t *oauth2Token) get(ctx context.Context, rt http.RoundTripper) (string, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.token != "" && (t.expiry.IsZero() || t.now().Before(t.expiry)) {
+		return t.token, nil
+	}
+
+	secret := string(t.conf.ClientSecret)
+	if t.conf.ClientSecretFile != "" {
+		b, err := ioutil.ReadFile(t.conf.ClientSecretFile)
+		if err != nil {
+			return "", fmt.Errorf("reading OAuth2 client secret: %s", err)
+		}
+		secret = strings.TrimSpace(string(b))
+	}
+
+	params := url.Values{}
+	for k, v := range t.conf.EndpointParams {
+		params.Set(k, v)
+	}
+	params.Set("grant_type", "client_credentials")
+	if len(t.conf.Scopes) > 0 {
+		params.Set("scope", strings.Join(t.conf.Scopes, " "))
+	}
+	req, err := http.NewRequest("POST", t.conf.TokenURL.String(), strings.NewReader(params.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// The credentials are form encoded before they are used for basic
+	// authentication.
+	// https://tools.ietf.org/html/rfc6749#section-2.3.1
+	req.SetBasicAuth(url.QueryEscape(t.conf.ClientID), url.QueryEscape(secret))
+
+	now := t.now()
+	resp, err := ctxhttp.Do(ctx, &http.Client{Transport: rt}, req)
+	if err != nil {
+		return "", fmt.Errorf("requesting OAuth2 token: %s", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("requesting OAuth2 token: %s", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("requesting OAuth2 token: unexpected status code %v: %s", resp.StatusCode, truncateBytes(string(body), 1024))
+	}
+
+	var tr struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return "", fmt.Errorf("decoding OAuth2 token: %s", err)
+	}
+	if tr.AccessToken == "" {
+		return "", fmt.Errorf("missing access_token in OAuth2 token response")
+	}
+	if tr.TokenType != "" && !strings.EqualFold(tr.TokenType, "bearer") {
+		return "", fmt.Errorf("unsupported OAuth2 token type %q", tr.TokenType)
+	}
+
+	t.token = tr.AccessToken
+	t.expiry = time.Time{}
+	if tr.ExpiresIn > 0 {
+		t.expiry = now.Add(time.Duration(tr.ExpiresIn)*time.Second - oauth2ExpiryDelta)
+	}
+	return t.token, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestOAuth2RoundTripper(t *testing.T) {
+	var (
+		issued   int
+		rejected = map[string]bool{}
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		id, secret, _ := r.BasicAuth()
+		if id != "alertmanager" || secret != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		require.Equal(t, "alerts:write incidents", r.PostForm.Get("scope"))
+		require.Equal(t, "https://api.example.com", r.PostForm.Get("audience"))
+		issued++
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":60}`, issued)
+	})
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if rejected[auth] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, auth)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	f, err := ioutil.TempFile("", "client_secret")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("s3cr3t\n")
+	require.NoError(t, err)
+	f.Close()
+
+	u, err := url.Parse(srv.URL + "/token")
+	require.NoError(t, err)
+	tok := newOAuth2Token(&config.HTTPClientConfig{
+		OAuth2: &config.OAuth2Config{
+			ClientID:         "alertmanager",
+			ClientSecretFile: f.Name(),
+			Scopes:           []string{"alerts:write", "incidents"},
+			TokenURL:         &config.URL{URL: u},
+			EndpointParams:   map[string]string{"audience": "https://api.example.com"},
+		},
+	})
+	now := time.Now()
+	tok.now = func() time.Time { return now }
+	c := &http.Client{Transport: newOAuth2RoundTripper(tok, http.DefaultTransport)}
+
+	get := func() (int, string) {
+		resp, err := c.Get(srv.URL + "/api")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(b)
+	}
+
+	// The token is cached until it expires.
+	_, auth := get()
+	require.Equal(t, "Bearer token-1", auth)
+	_, auth = get()
+	require.Equal(t, "Bearer token-1", auth)
+	now = now.Add(time.Minute)
+	_, auth = get()
+	require.Equal(t, "Bearer token-2", auth)
+
+	// A rejected token is renewed on the next request.
+	rejected["Bearer token-2"] = true
+	code, _ := get()
+	require.Equal(t, http.StatusUnauthorized, code)
+	_, auth = get()
+	require.Equal(t, "Bearer token-3", auth)
+
+	// Errors of the token endpoint are returned.
+	tok.conf.ClientSecretFile = ""
+	tok.conf.ClientSecret = "wrong"
+	tok.token = ""
+	_, err = c.Get(srv.URL + "/api")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "requesting OAuth2 token: unexpected status code 401")
+}
+
+func TestWebhookOAuth2(t *testing.T) {
+	var (
+		issued int
+		auths  []string
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		issued++
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":60}`, issued)
+	})
+	mux.HandleFunc("/hook", func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tokenURL, err := url.Parse(srv.URL + "/token")
+	require.NoError(t, err)
+	hookURL, err := url.Parse(srv.URL + "/hook")
+	require.NoError(t, err)
+	notifier := NewWebhook(&config.WebhookConfig{
+		URL: &config.URL{URL: hookURL},
+		HTTPConfig: &config.HTTPClientConfig{
+			OAuth2: &config.OAuth2Config{
+				ClientID:     "alertmanager",
+				ClientSecret: "s3cr3t",
+				TokenURL:     &config.URL{URL: tokenURL},
+			},
+		},
+	}, createTmpl(t), log.NewNopLogger())
+
+	// The token is requested once and reused by later notifications.
+	ctx := WithGroupKey(context.Background(), "1")
+	for i := 0; i < 2; i++ {
+		_, err = notifier.Notify(ctx, &types.Alert{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, issued)
+	require.Equal(t, []string{"Bearer token-1", "Bearer token-1"}, auths)
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// TestResult is the result of a test notification sent through an
+// integration of a receiver.
+type TestResult struct {
+	Integration string `json:"integration"`
+	Index       int    `json:"index"`
+	Error       string `json:"error,omitempty"`
+}
+
+// NewTestAlert returns a synthetic firing alert for test notifications to
+// the receiver.
+func NewTestAlert(receiver string, now time.Time) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{
+				model.AlertNameLabel: "TestAlert",
+				"receiver":           model.LabelValue(receiver),
+			},
+			Annotations: model.LabelSet{
+				"summary":     "Test notification",
+				"description": "This is a test notification sent through the Alertmanager API.",
+			},
+			StartsAt: now,
+			EndsAt:   now.Add(5 * time.Minute),
+		},
+		UpdatedAt: now,
+	}
+}
+
+// TestReceiver sends the alert once through every integration of the
+// receiver, without retrying, and returns the results in the order of the
+// integrations.
+func TestReceiver(ctx context.Context, rc *config.Receiver, tmpl *template.Template, alert *types.Alert, l log.Logger) []TestResult {
+	groupLabels := model.LabelSet{model.AlertNameLabel: alert.Labels[model.AlertNameLabel]}
+	ctx = WithReceiverName(ctx, rc.Name)
+	ctx = WithGroupKey(ctx, fmt.Sprintf("{}/test:%s", groupLabels))
+	ctx = WithGroupLabels(ctx, groupLabels)
+	ctx = WithFiringAlerts(ctx, []uint64{hashAlert(alert)})
+	ctx = WithResolvedAlerts(ctx, []uint64{})
+	ctx = WithNow(ctx, time.Now())
+
+	integrations := BuildReceiverIntegrations(rc, tmpl, l)
+	results := make([]TestResult, len(integrations))
+
+	var wg sync.WaitGroup
+	for n, i := range integrations {
+		wg.Add(1)
+		go func(n int, i Integration) {
+			defer wg.Done()
+
+			results[n] = TestResult{Integration: i.name, Index: i.idx}
+			if _, err := i.Notify(ctx, alert); err != nil {
+				numFailedNotifications.WithLabelValues(i.name).Inc()
+				results[n].Error = err.Error()
+				return
+			}
+			numNotifications.WithLabelValues(i.name).Inc()
+		}(n, i)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,340 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	commoncfg "github.com/prometheus/common/config"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+const (
+	xmppNSStream = "http://etherx.jabber.org/streams"
+	xmppNSTLS    = "urn:ietf:params:xml:ns:xmpp-tls"
+	xmppNSSASL   = "urn:ietf:params:xml:ns:xmpp-sasl"
+	xmppNSBind   = "urn:ietf:params:xml:ns:xmpp-bind"
+	xmppNSMUC    = "http://jabber.org/protocol/muc"
+)
+
+// xmppFeatures are the stream features announced by the server.
+type xmppFeatures struct {
+	XMLName    xml.Name  `xml:"http://etherx.jabber.org/streams features"`
+	StartTLS   *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-tls starttls"`
+	Mechanisms []string  `xml:"urn:ietf:params:xml:ns:xmpp-sasl mechanisms>mechanism"`
+	Bind       *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-bind bind"`
+}
+
+// xmppError is an error reported by the server. Authentication failures
+// and stanza errors are not recoverable by retrying.
+type xmppError struct {
+	msg string
+}
+
+func (e *xmppError) Error() string {
+	return e.msg
+}
+
+// xmppSession is an authenticated XMPP client stream.
+// https://xmpp.org/rfcs/rfc6120.html
+type xmppSession struct {
+	conf   *config.XMPPConfig
+	domain string
+	conn   net.Conn
+	dec    *xml.Decoder
+}
+
+// dialXMPP connects to the server of the config, secures the stream,
+// authenticates with SASL PLAIN and binds a resource.
+func dialXMPP(ctx context.Context, conf *config.XMPPConfig) (*xmppSession, error) {
+	local, domain, resource := splitJID(conf.JID)
+	addr := conf.Server
+	if addr == "" {
+		addr = net.JoinHostPort(domain, "5222")
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &xmppSession{conf: conf, domain: domain, conn: conn}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	secure := false
+	if conf.DirectTLS {
+		if err := s.startTLS(addr); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		secure = true
+	}
+
+	features, err := s.openStream()
+	if err != nil {
+		s.conn.Close()
+		return nil, err
+	}
+	if !secure && features.StartTLS != nil {
+		if err := s.write("<starttls xmlns='%s'/>", xmppNSTLS); err != nil {
+			s.conn.Close()
+			return nil, err
+		}
+		if err := s.expect(xml.Name{Space: xmppNSTLS, Local: "proceed"}); err != nil {
+			s.conn.Close()
+			return nil, err
+		}
+		if err := s.startTLS(addr); err != nil {
+			s.conn.Close()
+			return nil, err
+		}
+		secure = true
+		if features, err = s.openStream(); err != nil {
+			s.conn.Close()
+			return nil, err
+		}
+	}
+	if !secure && !conf.AllowPlaintext {
+		s.conn.Close()
+		return nil, &xmppError{msg: "XMPP server does not support TLS"}
+	}
+
+	if err := s.authenticate(features, local); err != nil {
+		s.conn.Close()
+		return nil, err
+	}
+	if features, err = s.openStream(); err != nil {
+		s.conn.Close()
+		return nil, err
+	}
+	if features.Bind == nil {
+		s.conn.Close()
+		return nil, &xmppError{msg: "XMPP server does not support resource binding"}
+	}
+	if err := s.bind(resource); err != nil {
+		s.conn.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// startTLS wraps the connection with TLS.
+func (s *xmppSession) startTLS(addr string) error {
+	tlsConfig, err := commoncfg.NewTLSConfig(&s.conf.TLSConfig)
+	if err != nil {
+		return err
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = s.domain
+	}
+	tlsConn := tls.Client(s.conn, tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		return err
+	}
+	s.conn = tlsConn
+	return nil
+}
+
+// openStream opens a new stream and returns the features of the server.
+func (s *xmppSession) openStream() (*xmppFeatures, error) {
+	err := s.write(
+		"<?xml version='1.0'?><stream:stream to='%s' xmlns='jabber:client' xmlns:stream='%s' version='1.0'>",
+		xmlEscape(s.domain), xmppNSStream,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.dec = xml.NewDecoder(s.conn)
+
+	se, err := s.next()
+	if err != nil {
+		return nil, err
+	}
+	if se.Name.Space != xmppNSStream || se.Name.Local != "stream" {
+		return nil, fmt.Errorf("unexpected XMPP element <%s>", se.Name.Local)
+	}
+	if se, err = s.next(); err != nil {
+		return nil, err
+	}
+	var f xmppFeatures
+	if err := s.dec.DecodeElement(&f, &se); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// authenticate authenticates with SASL PLAIN.
+// https://tools.ietf.org/html/rfc4616
+func (s *xmppSession) authenticate(f *xmppFeatures, user string) error {
+	plain := false
+	for _, m := range f.Mechanisms {
+		if m == "PLAIN" {
+			plain = true
+		}
+	}
+	if !plain {
+		return &xmppError{msg: fmt.Sprintf("XMPP server does not support SASL PLAIN, supported mechanisms: %s", strings.Join(f.Mechanisms, ", "))}
+	}
+
+	creds := base64.StdEncoding.EncodeToString([]byte("\x00" + user + "\x00" + string(s.conf.Password)))
+	if err := s.write("<auth xmlns='%s' mechanism='PLAIN'>%s</auth>", xmppNSSASL, creds); err != nil {
+		return err
+	}
+	se, err := s.next()
+	if err != nil {
+		return err
+	}
+	if err := s.dec.Skip(); err != nil {
+		return err
+	}
+	if se.Name.Space != xmppNSSASL || se.Name.Local != "success" {
+		return &xmppError{msg: "XMPP authentication failed"}
+	}
+	return nil
+}
+
+// bind binds the resource of the session.
+func (s *xmppSession) bind(resource string) error {
+	var res string
+	if resource != "" {
+		res = "<resource>" + xmlEscape(resource) + "</resource>"
+	}
+	if err := s.write("<iq type='set' id='bind'><bind xmlns='%s'>%s</bind></iq>", xmppNSBind, res); err != nil {
+		return err
+	}
+	se, err := s.next()
+	if err != nil {
+		return err
+	}
+	if err := s.dec.Skip(); err != nil {
+		return err
+	}
+	if se.Name.Local != "iq" || xmlAttr(se, "type") != "result" {
+		return &xmppError{msg: "XMPP resource binding failed"}
+	}
+	return nil
+}
+
+// send sends the message to the recipients and rooms of the config. Rooms
+// are joined before sending and left afterwards.
+func (s *xmppSession) send(msg string) error {
+	body := xmlEscape(msg)
+	for _, to := range s.conf.To {
+		if err := s.write("<message to='%s' type='chat'><body>%s</body></message>", xmlEscape(to), body); err != nil {
+			return err
+		}
+	}
+	for _, room := range s.conf.Rooms {
+		occupant := xmlEscape(room + "/" + s.conf.RoomNick)
+		// Don't request the history of the room, it would be discarded anyway.
+		err := s.write("<presence to='%s'><x xmlns='%s'><history maxchars='0'/></x></presence>", occupant, xmppNSMUC)
+		if err != nil {
+			return err
+		}
+		if err := s.write("<message to='%s' type='groupchat'><body>%s</body></message>", xmlEscape(room), body); err != nil {
+			return err
+		}
+		if err := s.write("<presence to='%s' type='unavailable'/>", occupant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close closes the stream and the connection.
+func (s *xmppSession) close() error {
+	s.write("</stream:stream>")
+	return s.conn.Close()
+}
+
+// expect reads the next element and checks its name.
+func (s *xmppSession) expect(name xml.Name) error {
+	se, err := s.next()
+	if err != nil {
+		return err
+	}
+	if err := s.dec.Skip(); err != nil {
+		return err
+	}
+	if se.Name != name {
+		return fmt.Errorf("unexpected XMPP element <%s>, expected <%s>", se.Name.Local, name.Local)
+	}
+	return nil
+}
+
+// next returns the next start element of the stream.
+func (s *xmppSession) next() (xml.StartElement, error) {
+	for {
+		t, err := s.dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return xml.StartElement{}, err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			if t.Name.Space == xmppNSStream && t.Name.Local == "error" {
+				s.dec.Skip()
+				return t, fmt.Errorf("XMPP stream error")
+			}
+			return t, nil
+		case xml.EndElement:
+			return xml.StartElement{}, fmt.Errorf("XMPP stream closed by server")
+		}
+	}
+}
+
+func (s *xmppSession) write(format string, args ...interface{}) error {
+	_, err := fmt.Fprintf(s.conn, format, args...)
+	return err
+}
+
+// splitJID splits a JID into its local, domain and resource parts.
+func splitJID(jid string) (local, domain, resource string) {
+	if i := strings.Index(jid, "/"); i >= 0 {
+		jid, resource = jid[:i], jid[i+1:]
+	}
+	if i := strings.Index(jid, "@"); i >= 0 {
+		local, jid = jid[:i], jid[i+1:]
+	}
+	return local, jid, resource
+}
+
+func xmlAttr(se xml.StartElement, name string) string {
+	for _, a := range se.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,177 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+type xmppTestStanza struct {
+	XMLName xml.Name
+	To      string `xml:"to,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:"body"`
+}
+
+// serveXMPP is a minimal XMPP server accepting SASL PLAIN authentication
+// without TLS. It sends the credentials and the received stanzas on the
+// returned channel.
+func serveXMPP(ln net.Listener) <-chan string {
+	out := make(chan string, 10)
+	go func() {
+		defer close(out)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		header := "<stream:stream xmlns='jabber:client' xmlns:stream='http://etherx.jabber.org/streams' version='1.0'>"
+		next := func(dec *xml.Decoder) (xml.StartElement, bool) {
+			for {
+				tok, err := dec.Token()
+				if err != nil {
+					return xml.StartElement{}, false
+				}
+				switch tok := tok.(type) {
+				case xml.StartElement:
+					return tok, true
+				case xml.EndElement:
+					return xml.StartElement{}, false
+				}
+			}
+		}
+
+		dec := xml.NewDecoder(conn)
+		if _, ok := next(dec); !ok {
+			return
+		}
+		fmt.Fprintf(conn, "%s<stream:features><mechanisms xmlns='urn:ietf:params:xml:ns:xmpp-sasl'><mechanism>PLAIN</mechanism></mechanisms></stream:features>", header)
+
+		se, ok := next(dec)
+		if !ok {
+			return
+		}
+		var auth string
+		if err := dec.DecodeElement(&auth, &se); err != nil {
+			return
+		}
+		creds, _ := base64.StdEncoding.DecodeString(auth)
+		out <- string(creds)
+		fmt.Fprint(conn, "<success xmlns='urn:ietf:params:xml:ns:xmpp-sasl'/>")
+
+		dec = xml.NewDecoder(conn)
+		if _, ok := next(dec); !ok {
+			return
+		}
+		fmt.Fprintf(conn, "%s<stream:features><bind xmlns='urn:ietf:params:xml:ns:xmpp-bind'/></stream:features>", header)
+
+		for {
+			se, ok := next(dec)
+			if !ok {
+				return
+			}
+			var s xmppTestStanza
+			if err := dec.DecodeElement(&s, &se); err != nil {
+				return
+			}
+			if s.XMLName.Local == "iq" {
+				fmt.Fprint(conn, "<iq type='result' id='bind'/>")
+				continue
+			}
+			out <- fmt.Sprintf("%s to=%s type=%s body=%s", s.XMLName.Local, s.To, s.Type, s.Body)
+		}
+	}()
+	return out
+}
+
+func TestXMPPMessage(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	stanzas := serveXMPP(ln)
+
+	var conf config.XMPPConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+jid: 'am@example.org/alerts'
+password: 'secret'
+server: '`+ln.Addr().String()+`'
+allow_plaintext: true
+to: ['oncall@example.org']
+rooms: ['ops@conference.example.org']
+message: '{{ .Status }} <{{ .CommonLabels.alertname }}>'
+`), &conf))
+	notifier := NewXMPP(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	retry, err := notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	var received []string
+	for s := range stanzas {
+		received = append(received, s)
+	}
+	require.Equal(t, []string{
+		"\x00am\x00secret",
+		"message to=oncall@example.org type=chat body=firing <HighLatency>",
+		"presence to=ops@conference.example.org/alertmanager type= body=",
+		"message to=ops@conference.example.org type=groupchat body=firing <HighLatency>",
+		"presence to=ops@conference.example.org/alertmanager type=unavailable body=",
+	}, received)
+}
+
+func TestXMPPRequiresTLS(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	serveXMPP(ln)
+
+	var conf config.XMPPConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+jid: 'am@example.org'
+password: 'secret'
+server: '`+ln.Addr().String()+`'
+to: ['oncall@example.org']
+`), &conf))
+	notifier := NewXMPP(&conf, createTmpl(t), log.NewNopLogger())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	retry, err := notifier.Notify(ctx, &types.Alert{})
+	require.EqualError(t, err, "XMPP server does not support TLS")
+	require.False(t, retry)
+}
@@ -0,0 +1,332 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timeinterval provides recurring time intervals, such as business
+// hours, that can be checked for containing a point in time.
+package timeinterval
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeInterval describes intervals of time. An empty list of times,
+// weekdays, days of month or months matches any value of the respective
+// unit. A point in time is contained in the interval if all units match.
+type TimeInterval struct {
+	Times       []TimeRange       `yaml:"times,omitempty" json:"times,omitempty"`
+	Weekdays    []WeekdayRange    `yaml:"weekdays,flow,omitempty" json:"weekdays,omitempty"`
+	DaysOfMonth []DayOfMonthRange `yaml:"days_of_month,flow,omitempty" json:"days_of_month,omitempty"`
+	Months      []MonthRange      `yaml:"months,flow,omitempty" json:"months,omitempty"`
+	// The location the interval is evaluated in. Defaults to UTC.
+	Location *Location `yaml:"location,omitempty" json:"location,omitempty"`
+}
+
+// ContainsTime returns whether t falls into the interval.
+func (ti TimeInterval) ContainsTime(t time.Time) bool {
+	if ti.Location != nil {
+		t = t.In(ti.Location.Location)
+	} else {
+		t = t.UTC()
+	}
+
+	if len(ti.Times) > 0 {
+		m := t.Hour()*60 + t.Minute()
+		var ok bool
+		for _, tr := range ti.Times {
+			if m >= tr.StartMinute && m < tr.EndMinute {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if !containsValue(ti.Weekdays, int(t.Weekday())) {
+		return false
+	}
+	if !containsValue(ti.DaysOfMonth, t.Day()) {
+		return false
+	}
+	return containsValue(ti.Months, int(t.Month()))
+}
+
+// containsValue returns whether v is in one of the ranges of the given
+// range slice. An empty slice contains all values.
+func containsValue(rs interface{}, v int) bool {
+	var ranges []InclusiveRange
+	switch rs := rs.(type) {
+	case []WeekdayRange:
+		for _, r := range rs {
+			ranges = append(ranges, r.InclusiveRange)
+		}
+	case []DayOfMonthRange:
+		for _, r := range rs {
+			ranges = append(ranges, r.InclusiveRange)
+		}
+	case []MonthRange:
+		for _, r := range rs {
+			ranges = append(ranges, r.InclusiveRange)
+		}
+	}
+	if len(ranges) == 0 {
+		return true
+	}
+	for _, r := range ranges {
+		if v >= r.Begin && v <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeRange is a range of minutes within a day. The end is exclusive.
+type TimeRange struct {
+	StartMinute int
+	EndMinute   int
+}
+
+type yamlTimeRange struct {
+	StartTime string `yaml:"start_time" json:"start_time"`
+	EndTime   string `yaml:"end_time" json:"end_time"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (tr *TimeRange) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var y yamlTimeRange
+	if err := unmarshal(&y); err != nil {
+		return err
+	}
+	start, err := parseTime(y.StartTime)
+	if err != nil {
+		return fmt.Errorf("invalid start time %q: %s", y.StartTime, err)
+	}
+	end, err := parseTime(y.EndTime)
+	if err != nil {
+		return fmt.Errorf("invalid end time %q: %s", y.EndTime, err)
+	}
+	if start >= end {
+		return fmt.Errorf("start time %s must be before end time %s", y.StartTime, y.EndTime)
+	}
+	tr.StartMinute, tr.EndMinute = start, end
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (tr TimeRange) MarshalYAML() (interface{}, error) {
+	return tr.yaml(), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (tr TimeRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tr.yaml())
+}
+
+func (tr TimeRange) yaml() yamlTimeRange {
+	return yamlTimeRange{
+		StartTime: formatTime(tr.StartMinute),
+		EndTime:   formatTime(tr.EndMinute),
+	}
+}
+
+// parseTime parses a time of day in the format HH:MM into minutes since
+// midnight. 24:00 denotes the end of the day.
+func parseTime(s string) (int, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("expected format HH:MM")
+	}
+	h, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, err
+	}
+	if h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("out of range")
+	}
+	return h*60 + m, nil
+}
+
+func formatTime(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
+
+// InclusiveRange is a range of integers including both ends.
+type InclusiveRange struct {
+	Begin int
+	End   int
+}
+
+// WeekdayRange is an inclusive range of weekdays, e.g. "monday:friday".
+// Sunday is 0.
+type WeekdayRange struct {
+	InclusiveRange
+}
+
+// DayOfMonthRange is an inclusive range of days of the month, e.g. "1:7".
+type DayOfMonthRange struct {
+	InclusiveRange
+}
+
+// MonthRange is an inclusive range of months, e.g. "january:march" or "1:3".
+type MonthRange struct {
+	InclusiveRange
+}
+
+var (
+	weekdays = map[string]int{
+		"sunday": 0, "monday": 1, "tuesday": 2, "wednesday": 3,
+		"thursday": 4, "friday": 5, "saturday": 6,
+	}
+	months = map[string]int{
+		"january": 1, "february": 2, "march": 3, "april": 4,
+		"may": 5, "june": 6, "july": 7, "august": 8,
+		"september": 9, "october": 10, "november": 11, "december": 12,
+	}
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *WeekdayRange) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalRange(unmarshal, &r.InclusiveRange, 0, 6, weekdays)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (r WeekdayRange) MarshalYAML() (interface{}, error) {
+	return r.format(weekdays), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r WeekdayRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.format(weekdays))
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *DayOfMonthRange) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalRange(unmarshal, &r.InclusiveRange, 1, 31, nil)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (r DayOfMonthRange) MarshalYAML() (interface{}, error) {
+	return r.format(nil), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r DayOfMonthRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.format(nil))
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *MonthRange) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalRange(unmarshal, &r.InclusiveRange, 1, 12, months)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (r MonthRange) MarshalYAML() (interface{}, error) {
+	return r.format(months), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r MonthRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.format(months))
+}
+
+// unmarshalRange parses a single value or a range of two values separated
+// by a colon. Values may be given by name if names is set.
+func unmarshalRange(unmarshal func(interface{}) error, r *InclusiveRange, min, max int, names map[string]int) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 2 {
+		return fmt.Errorf("invalid range %q", s)
+	}
+	var err error
+	if r.Begin, err = parseRangeValue(parts[0], names); err != nil {
+		return err
+	}
+	r.End = r.Begin
+	if len(parts) == 2 {
+		if r.End, err = parseRangeValue(parts[1], names); err != nil {
+			return err
+		}
+	}
+	if r.Begin < min || r.End > max || r.Begin > r.End {
+		return fmt.Errorf("invalid range %q", s)
+	}
+	return nil
+}
+
+func parseRangeValue(s string, names map[string]int) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if v, ok := names[s]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+// format returns the range in the format it is parsed from.
+func (r InclusiveRange) format(names map[string]int) string {
+	name := func(v int) string {
+		for n, nv := range names {
+			if nv == v {
+				return n
+			}
+		}
+		return strconv.Itoa(v)
+	}
+	if r.Begin == r.End {
+		return name(r.Begin)
+	}
+	return name(r.Begin) + ":" + name(r.End)
+}
+
+// Location wraps a time.Location and makes it YAML and JSON marshalable.
+type Location struct {
+	*time.Location
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (l *Location) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return err
+	}
+	l.Location = loc
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (l Location) MarshalYAML() (interface{}, error) {
+	return l.String(), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (l Location) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.String())
+}
@@ -0,0 +1,115 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func mustParseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestTimeIntervalContainsTime(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		contains []string
+		excludes []string
+	}{
+		{
+			in: `
+times:
+- start_time: '09:00'
+  end_time: '17:00'
+weekdays: ['monday:friday']
+`,
+			// 2018-11-05 is a Monday.
+			contains: []string{"2018-11-05T09:00:00Z", "2018-11-09T16:59:59Z"},
+			excludes: []string{"2018-11-05T08:59:59Z", "2018-11-05T17:00:00Z", "2018-11-10T12:00:00Z"},
+		},
+		{
+			in: `
+months: ['december', 'january:february']
+days_of_month: ['1:7']
+`,
+			contains: []string{"2018-12-01T00:00:00Z", "2019-02-07T23:59:59Z"},
+			excludes: []string{"2018-12-08T00:00:00Z", "2019-03-01T00:00:00Z"},
+		},
+		{
+			in: `
+times:
+- start_time: '00:00'
+  end_time: '06:00'
+location: 'Europe/Berlin'
+`,
+			contains: []string{"2018-11-04T23:00:00Z", "2018-11-05T04:59:59Z"},
+			excludes: []string{"2018-11-05T05:00:00Z", "2018-11-04T22:59:59Z"},
+		},
+		{
+			in:       `{}`,
+			contains: []string{"2018-11-05T09:00:00Z"},
+		},
+	} {
+		var ti TimeInterval
+		require.NoError(t, yaml.Unmarshal([]byte(tc.in), &ti))
+
+		for _, s := range tc.contains {
+			require.True(t, ti.ContainsTime(mustParseTime(s)), "%s should contain %s", tc.in, s)
+		}
+		for _, s := range tc.excludes {
+			require.False(t, ti.ContainsTime(mustParseTime(s)), "%s should not contain %s", tc.in, s)
+		}
+	}
+}
+
+func TestTimeIntervalUnmarshalErrors(t *testing.T) {
+	for _, in := range []string{
+		`times: [{start_time: '17:00', end_time: '09:00'}]`,
+		`times: [{start_time: '9:00', end_time: '17:00'}]`,
+		`times: [{start_time: '09:00', end_time: '24:01'}]`,
+		`weekdays: ['friday:monday']`,
+		`weekdays: ['someday']`,
+		`days_of_month: ['0:5']`,
+		`months: ['1:13']`,
+		`location: 'Mars/Olympus_Mons'`,
+	} {
+		var ti TimeInterval
+		require.Error(t, yaml.Unmarshal([]byte(in), &ti), in)
+	}
+}
+
+func TestTimeIntervalMarshalYAML(t *testing.T) {
+	in := `times:
+- start_time: "09:00"
+  end_time: "24:00"
+weekdays: ['monday:friday', sunday]
+days_of_month: ["1:7"]
+months: [january]
+location: Europe/Berlin
+`
+	var ti TimeInterval
+	require.NoError(t, yaml.Unmarshal([]byte(in), &ti))
+
+	out, err := yaml.Marshal(ti)
+	require.NoError(t, err)
+	require.Equal(t, in, string(out))
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wal implements an append-only write-ahead log of opaque records.
+// Changes are logged before they are acknowledged and replayed on top of the
+// latest snapshot on startup. The log is truncated once a snapshot contains
+// all of its records.
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+)
+
+// Every record is prefixed with its length and the CRC32 checksum of its
+// data, so records torn by a crash are detected on replay.
+const headerSize = 8
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// WAL is a write-ahead log stored in a single file.
+type WAL struct {
+	mtx  sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// Open opens the log in the given file, creating it if it doesn't exist, and
+// calls replay with every record in the order they were logged. A torn or
+// corrupted record at the end of the log, left by a crash while writing,
+// is discarded along with everything after it.
+func Open(filename string, replay func([]byte) error) (*WAL, error) {
+	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	size, err := readRecords(f, fi.Size(), replay)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("replaying write-ahead log %s: %s", filename, err)
+	}
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(size, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &WAL{f: f, size: size}, nil
+}
+
+// readRecords calls replay with the valid records of the reader holding n
+// bytes and returns the number of bytes they take up.
+func readRecords(r io.Reader, n int64, replay func([]byte) error) (int64, error) {
+	var (
+		br     = bufio.NewReader(r)
+		size   int64
+		header [headerSize]byte
+	)
+	for {
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			// A partial header is a torn write.
+			return size, nil
+		}
+		l := int64(binary.BigEndian.Uint32(header[:4]))
+		// The length of a torn header can be anything, so don't allocate
+		// more than what is left to read.
+		if l > n-size-headerSize {
+			return size, nil
+		}
+		data := make([]byte, l)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return size, nil
+		}
+		if crc32.Checksum(data, castagnoli) != binary.BigEndian.Uint32(header[4:]) {
+			return size, nil
+		}
+		if err := replay(data); err != nil {
+			return size, err
+		}
+		size += headerSize + l
+	}
+}
+
+// Log appends the records to the log and syncs it to disk. The records are
+// durable once it returns without error.
+func (w *WAL) Log(records ...[]byte) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var buf []byte
+	for _, rec := range records {
+		var header [headerSize]byte
+		binary.BigEndian.PutUint32(header[:4], uint32(len(rec)))
+		binary.BigEndian.PutUint32(header[4:], crc32.Checksum(rec, castagnoli))
+		buf = append(buf, header[:]...)
+		buf = append(buf, rec...)
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if _, err := w.f.Write(buf); err != nil {
+		// Drop the partially written records so later ones aren't logged
+		// after them.
+		w.f.Truncate(w.size)
+		w.f.Seek(w.size, io.SeekStart)
+		return err
+	}
+	w.size += int64(len(buf))
+	return w.f.Sync()
+}
+
+// Truncate removes all records from the log. It must only be called once a
+// snapshot containing all logged changes was written.
+func (w *WAL) Truncate() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if err := w.f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w.size = 0
+	return w.f.Sync()
+}
+
+// Size returns the size of the log in bytes.
+func (w *WAL) Size() int64 {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.size
+}
+
+// Close closes the log file.
+func (w *WAL) Close() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.f.Close()
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fn := filepath.Join(dir, "wal")
+
+	var replayed []string
+	replay := func(b []byte) error {
+		replayed = append(replayed, string(b))
+		return nil
+	}
+
+	w, err := Open(fn, replay)
+	require.NoError(t, err)
+	require.Empty(t, replayed)
+	require.NoError(t, w.Log([]byte("a"), []byte("bc")))
+	require.NoError(t, w.Log([]byte("")))
+	require.NoError(t, w.Close())
+
+	w, err = Open(fn, replay)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "bc", ""}, replayed)
+	size := w.Size()
+	require.Equal(t, int64(3*headerSize+3), size)
+	require.NoError(t, w.Close())
+
+	// A torn record at the end of the log is discarded and later records
+	// are appended after the last complete one.
+	f, err := os.OpenFile(fn, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 5, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	replayed = nil
+	w, err = Open(fn, replay)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "bc", ""}, replayed)
+	require.Equal(t, size, w.Size())
+	require.NoError(t, w.Log([]byte("d")))
+	require.NoError(t, w.Close())
+
+	// Records with a wrong checksum end the log as well.
+	b, err := ioutil.ReadFile(fn)
+	require.NoError(t, err)
+	b[len(b)-1] = 'e'
+	require.NoError(t, ioutil.WriteFile(fn, b, 0666))
+
+	replayed = nil
+	w, err = Open(fn, replay)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "bc", ""}, replayed)
+
+	require.NoError(t, w.Truncate())
+	require.Equal(t, int64(0), w.Size())
+	require.NoError(t, w.Log([]byte("f")))
+	require.NoError(t, w.Close())
+
+	replayed = nil
+	w, err = Open(fn, replay)
+	require.NoError(t, err)
+	require.Equal(t, []string{"f"}, replayed)
+	require.NoError(t, w.Close())
+
+	// A garbage record length larger than the rest of the log ends the log
+	// without allocating a buffer for it.
+	f, err = os.OpenFile(fn, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, 'g'})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	replayed = nil
+	w, err = Open(fn, replay)
+	require.NoError(t, err)
+	require.Equal(t, []string{"f"}, replayed)
+	require.Equal(t, int64(headerSize+1), w.Size())
+	require.NoError(t, w.Close())
+
+	// Replay errors are returned.
+	_, err = Open(fn, func([]byte) error { return errors.New("invalid record") })
+	require.EqualError(t, err, "replaying write-ahead log "+fn+": invalid record")
+}
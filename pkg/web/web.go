@@ -0,0 +1,369 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package web configures the HTTP server of the Alertmanager.
+package web
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the configuration of the HTTP server read from the web
+// configuration file.
+type Config struct {
+	// Users allowed to authenticate with basic auth, mapped to the bcrypt
+	// hash of their password.
+	BasicAuthUsers map[string]string `yaml:"basic_auth_users,omitempty"`
+	// Bcrypt hashes of the bearer tokens allowed to authenticate by the
+	// name of the user they authenticate as. Requests send them as
+	// "Authorization: Bearer <user>:<token>".
+	BearerTokens map[string]string `yaml:"bearer_tokens,omitempty"`
+	// TLS configuration of the listener. Plain HTTP is served if unset.
+	TLSServerConfig *TLSServerConfig `yaml:"tls_server_config,omitempty"`
+	// Customization of the web UI.
+	UI *UIConfig `yaml:"ui,omitempty"`
+
+	// Credentials which were verified already by a hash of the credentials,
+	// mapped to the user, as bcrypt is deliberately slow.
+	mtx      sync.Mutex
+	verified map[[sha256.Size]byte]string
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Config
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	for u, h := range c.BasicAuthUsers {
+		if u == "" {
+			return fmt.Errorf("empty user name in basic_auth_users")
+		}
+		if _, err := bcrypt.Cost([]byte(h)); err != nil {
+			return fmt.Errorf("password of user %q is not a bcrypt hash", u)
+		}
+	}
+	for u, h := range c.BearerTokens {
+		if u == "" {
+			return fmt.Errorf("empty user name in bearer_tokens")
+		}
+		if _, err := bcrypt.Cost([]byte(h)); err != nil {
+			return fmt.Errorf("bearer token of user %q is not a bcrypt hash", u)
+		}
+	}
+	return nil
+}
+
+var (
+	tlsClientAuthTypes = map[string]tls.ClientAuthType{
+		"NoClientCert":               tls.NoClientCert,
+		"RequestClientCert":          tls.RequestClientCert,
+		"RequireAnyClientCert":       tls.RequireAnyClientCert,
+		"VerifyClientCertIfGiven":    tls.VerifyClientCertIfGiven,
+		"RequireAndVerifyClientCert": tls.RequireAndVerifyClientCert,
+	}
+	tlsVersions = map[string]uint16{
+		"TLS10": tls.VersionTLS10,
+		"TLS11": tls.VersionTLS11,
+		"TLS12": tls.VersionTLS12,
+	}
+	// The cipher suites implemented by crypto/tls by name.
+	tlsCipherSuites = map[string]uint16{
+		"TLS_RSA_WITH_RC4_128_SHA":                tls.TLS_RSA_WITH_RC4_128_SHA,
+		"TLS_RSA_WITH_3DES_EDE_CBC_SHA":           tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
+		"TLS_RSA_WITH_AES_128_CBC_SHA":            tls.TLS_RSA_WITH_AES_128_CBC_SHA,
+		"TLS_RSA_WITH_AES_256_CBC_SHA":            tls.TLS_RSA_WITH_AES_256_CBC_SHA,
+		"TLS_RSA_WITH_AES_128_CBC_SHA256":         tls.TLS_RSA_WITH_AES_128_CBC_SHA256,
+		"TLS_RSA_WITH_AES_128_GCM_SHA256":         tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+		"TLS_RSA_WITH_AES_256_GCM_SHA384":         tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+		"TLS_ECDHE_ECDSA_WITH_RC4_128_SHA":        tls.TLS_ECDHE_ECDSA_WITH_RC4_128_SHA,
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+		"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+		"TLS_ECDHE_RSA_WITH_RC4_128_SHA":          tls.TLS_ECDHE_RSA_WITH_RC4_128_SHA,
+		"TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA":     tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA,
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+		"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256": tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256,
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256":   tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256,
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":    tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":  tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	}
+)
+
+// TLSServerConfig configures TLS termination of the HTTP server.
+type TLSServerConfig struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// CA certificates to verify client certificates with.
+	ClientCAFile string `yaml:"client_ca_file,omitempty"`
+	// Whether client certificates are requested and verified, using the
+	// names of the tls.ClientAuthType constants. Defaults to
+	// RequireAndVerifyClientCert if a client CA is set.
+	ClientAuthType string `yaml:"client_auth_type,omitempty"`
+	// Minimum TLS version, TLS10 to TLS12. Defaults to TLS12.
+	MinVersion string `yaml:"min_version,omitempty"`
+	// Allowed cipher suites of TLS 1.2 and lower by name. The Go defaults
+	// are used if empty.
+	CipherSuites []string `yaml:"cipher_suites,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *TLSServerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain TLSServerConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return fmt.Errorf("cert_file and key_file are required in tls_server_config")
+	}
+	if c.ClientAuthType == "" {
+		c.ClientAuthType = "NoClientCert"
+		if c.ClientCAFile != "" {
+			c.ClientAuthType = "RequireAndVerifyClientCert"
+		}
+	}
+	auth, ok := tlsClientAuthTypes[c.ClientAuthType]
+	if !ok {
+		return fmt.Errorf("unknown client_auth_type %q in tls_server_config", c.ClientAuthType)
+	}
+	if (auth == tls.VerifyClientCertIfGiven || auth == tls.RequireAndVerifyClientCert) && c.ClientCAFile == "" {
+		return fmt.Errorf("client_ca_file is required for client_auth_type %q in tls_server_config", c.ClientAuthType)
+	}
+	if c.MinVersion == "" {
+		c.MinVersion = "TLS12"
+	}
+	if _, ok := tlsVersions[c.MinVersion]; !ok {
+		return fmt.Errorf("unknown min_version %q in tls_server_config", c.MinVersion)
+	}
+	for _, name := range c.CipherSuites {
+		if _, ok := tlsCipherSuites[name]; !ok {
+			return fmt.Errorf("unknown cipher suite %q in tls_server_config", name)
+		}
+	}
+	return nil
+}
+
+// TLSConfig loads the certificates and returns the TLS configuration of the
+// server.
+func (c *TLSServerConfig) TLSConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading server certificate: %s", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tlsClientAuthTypes[c.ClientAuthType],
+		MinVersion:   tlsVersions[c.MinVersion],
+	}
+	for _, name := range c.CipherSuites {
+		cfg.CipherSuites = append(cfg.CipherSuites, tlsCipherSuites[name])
+	}
+	if c.ClientCAFile != "" {
+		b, err := ioutil.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client CA: %s", err)
+		}
+		cfg.ClientCAs = x509.NewCertPool()
+		if !cfg.ClientCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", c.ClientCAFile)
+		}
+	}
+	return cfg, nil
+}
+
+// UIConfig customizes the header of the web UI so it fits into the
+// navigation of other internal tools.
+type UIConfig struct {
+	OrganizationName string   `yaml:"organization_name,omitempty" json:"organizationName,omitempty"`
+	LogoURL          string   `yaml:"logo_url,omitempty" json:"logoURL,omitempty"`
+	Links            []UILink `yaml:"links,omitempty" json:"links"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *UIConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain UIConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.LogoURL != "" {
+		if _, err := url.Parse(c.LogoURL); err != nil {
+			return fmt.Errorf("invalid logo_url in ui config: %s", err)
+		}
+	}
+	if c.Links == nil {
+		c.Links = []UILink{}
+	}
+	return nil
+}
+
+// UILink is an external link rendered in the header of the web UI.
+type UILink struct {
+	Name string `yaml:"name" json:"name"`
+	URL  string `yaml:"url" json:"url"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (l *UILink) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain UILink
+	if err := unmarshal((*plain)(l)); err != nil {
+		return err
+	}
+	if l.Name == "" || l.URL == "" {
+		return fmt.Errorf("name and url are required for links in ui config")
+	}
+	if _, err := url.Parse(l.URL); err != nil {
+		return fmt.Errorf("invalid url of link %q in ui config: %s", l.Name, err)
+	}
+	return nil
+}
+
+// LoadConfigFile parses the given YAML file into a Config.
+func LoadConfigFile(filename string) (*Config, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, fmt.Errorf("parsing web config file %s: %s", filename, err)
+	}
+	return c, nil
+}
+
+type contextKey int
+
+const userKey contextKey = 0
+
+// UserFromContext returns the user authenticated by the web server, if
+// authentication is enabled.
+func UserFromContext(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(userKey).(string)
+	return user, ok
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// compareDummyHash takes as long as comparing a password with the hash of
+// an existing user, so the response time doesn't reveal which users exist.
+func compareDummyHash(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy"), bcrypt.DefaultCost)
+	})
+	bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
+
+// AuthEnabled reports whether requests must be authenticated.
+func (c *Config) AuthEnabled() bool {
+	return c != nil && (len(c.BasicAuthUsers) > 0 || len(c.BearerTokens) > 0)
+}
+
+// authenticate returns the user authenticated by the credentials of the
+// request. Bearer tokens are given as "<user>:<token>", so at most one hash
+// has to be compared.
+func (c *Config) authenticate(r *http.Request) (string, bool) {
+	user, password, basic := r.BasicAuth()
+	auth := r.Header.Get("Authorization")
+	if !basic && !strings.HasPrefix(auth, "Bearer ") {
+		return "", false
+	}
+	var key [sha256.Size]byte
+	hashes := c.BasicAuthUsers
+	if basic {
+		key = sha256.Sum256([]byte("basic\x00" + user + "\x00" + password))
+	} else {
+		token := strings.TrimPrefix(auth, "Bearer ")
+		i := strings.Index(token, ":")
+		if i < 0 {
+			return "", false
+		}
+		user, password = token[:i], token[i+1:]
+		key = sha256.Sum256([]byte("bearer\x00" + user + "\x00" + password))
+		hashes = c.BearerTokens
+	}
+
+	c.mtx.Lock()
+	verified, ok := c.verified[key]
+	c.mtx.Unlock()
+	if ok {
+		return verified, true
+	}
+
+	hash, found := hashes[user]
+	if !found {
+		compareDummyHash(password)
+		return "", false
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return "", false
+	}
+
+	c.mtx.Lock()
+	if c.verified == nil {
+		c.verified = map[[sha256.Size]byte]string{}
+	}
+	c.verified[key] = user
+	c.mtx.Unlock()
+	return user, true
+}
+
+// Handler wraps the handler to reject requests without valid credentials
+// and adds the authenticated user to the request context. Requests aren't
+// authenticated if neither users nor tokens are configured.
+func (c *Config) Handler(h http.Handler) http.Handler {
+	if !c.AuthEnabled() {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := c.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="Alertmanager"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userKey, user)))
+	})
+}
+
+// ListenAndServe serves the handler on the address, using TLS and
+// authenticating requests as configured.
+func ListenAndServe(addr string, c *Config, h http.Handler) error {
+	srv := &http.Server{Addr: addr, Handler: c.Handler(h)}
+	if c == nil || c.TLSServerConfig == nil {
+		return srv.ListenAndServe()
+	}
+	tlsConfig, err := c.TLSServerConfig.TLSConfig()
+	if err != nil {
+		return err
+	}
+	srv.TLSConfig = tlsConfig
+	return srv.ListenAndServeTLS("", "")
+}
@@ -0,0 +1,294 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Bcrypt hashes of "secret" and "token".
+	secretHash = "$2a$04$8oCa.qESilQ5i9Oy8zINbuNSdwnFpUzWAKxJdv.P5VM86zEjzVCbu"
+	tokenHash  = "$2a$04$39dx3toTaxAtcm9.EvHWS..bZii4X8arpVGqtSjJufuKNRf..etH2"
+)
+
+func TestConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in: `
+basic_auth_users:
+  alice: ` + secretHash + `
+bearer_tokens:
+  ci: ` + tokenHash + `
+`,
+		},
+		{
+			in: `
+basic_auth_users:
+  alice: secret
+`,
+			err: `password of user "alice" is not a bcrypt hash`,
+		},
+		{
+			in: `
+bearer_tokens:
+  ci: token
+`,
+			err: `bearer token of user "ci" is not a bcrypt hash`,
+		},
+		{
+			in: `
+ui:
+  organization_name: Example
+  logo_url: https://example.com/logo.svg
+  links:
+  - name: Runbooks
+    url: https://runbooks.example.com
+`,
+		},
+		{
+			in: `
+ui:
+  links:
+  - name: Runbooks
+`,
+			err: "name and url are required for links in ui config",
+		},
+		{
+			in: `
+ui:
+  logo_url: "http://[::1"
+`,
+			err: `invalid logo_url in ui config: parse "http://[::1": missing ']' in host`,
+		},
+	} {
+		var c Config
+		err := yaml.UnmarshalStrict([]byte(tc.in), &c)
+		if tc.err == "" {
+			require.NoError(t, err)
+			continue
+		}
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	c := &Config{
+		BasicAuthUsers: map[string]string{"alice": secretHash},
+		BearerTokens:   map[string]string{"ci": tokenHash},
+	}
+	var authenticated string
+	h := c.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authenticated, _ = UserFromContext(r.Context())
+	}))
+
+	// Verified credentials are cached, repeated requests hit the cache.
+	for _, tc := range []struct {
+		user, password, token string
+		code                  int
+		authenticated         string
+	}{
+		{code: http.StatusUnauthorized},
+		{user: "alice", password: "secret", code: http.StatusOK, authenticated: "alice"},
+		{user: "alice", password: "secret", code: http.StatusOK, authenticated: "alice"},
+		{user: "alice", password: "wrong", code: http.StatusUnauthorized},
+		{user: "bob", password: "secret", code: http.StatusUnauthorized},
+		{token: "ci:token", code: http.StatusOK, authenticated: "ci"},
+		{token: "ci:token", code: http.StatusOK, authenticated: "ci"},
+		{token: "ci:wrong", code: http.StatusUnauthorized},
+		{token: "alice:token", code: http.StatusUnauthorized},
+		{token: "token", code: http.StatusUnauthorized},
+		{user: "ci", password: "token", code: http.StatusUnauthorized},
+	} {
+		authenticated = ""
+		r := httptest.NewRequest("GET", "/api/v1/status", nil)
+		if tc.user != "" {
+			r.SetBasicAuth(tc.user, tc.password)
+		}
+		if tc.token != "" {
+			r.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, tc.code, w.Code, "%+v", tc)
+		require.Equal(t, tc.authenticated, authenticated, "%+v", tc)
+		if tc.code == http.StatusUnauthorized {
+			require.Equal(t, `Basic realm="Alertmanager"`, w.Header().Get("WWW-Authenticate"))
+		}
+	}
+
+	// Requests aren't authenticated without users and tokens.
+	h = (&Config{}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestTLSServerConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in:  `key_file: key.pem`,
+			err: "cert_file and key_file are required in tls_server_config",
+		},
+		{
+			in: `
+cert_file: cert.pem
+key_file: key.pem
+client_auth_type: Always
+`,
+			err: `unknown client_auth_type "Always" in tls_server_config`,
+		},
+		{
+			in: `
+cert_file: cert.pem
+key_file: key.pem
+client_auth_type: RequireAndVerifyClientCert
+`,
+			err: `client_ca_file is required for client_auth_type "RequireAndVerifyClientCert" in tls_server_config`,
+		},
+		{
+			in: `
+cert_file: cert.pem
+key_file: key.pem
+min_version: SSL3
+`,
+			err: `unknown min_version "SSL3" in tls_server_config`,
+		},
+		{
+			in: `
+cert_file: cert.pem
+key_file: key.pem
+cipher_suites: [TLS_RSA_WITH_RC5]
+`,
+			err: `unknown cipher suite "TLS_RSA_WITH_RC5" in tls_server_config`,
+		},
+	} {
+		var c TLSServerConfig
+		require.EqualError(t, yaml.UnmarshalStrict([]byte(tc.in), &c), tc.err)
+	}
+
+	var c TLSServerConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+cert_file: cert.pem
+key_file: key.pem
+client_ca_file: ca.pem
+cipher_suites: [TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256]
+`), &c))
+	require.Equal(t, "RequireAndVerifyClientCert", c.ClientAuthType)
+	require.Equal(t, "TLS12", c.MinVersion)
+}
+
+// writeCertificate writes a self-signed certificate valid for server and
+// client authentication on localhost and its key to the directory.
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string, cert tls.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, keyPEM, 0600))
+
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return certFile, keyFile, cert
+}
+
+func TestTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "web")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile, cert := writeCertificate(t, dir)
+
+	c := &TLSServerConfig{
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		ClientCAFile:   certFile,
+		ClientAuthType: "RequireAndVerifyClientCert",
+		MinVersion:     "TLS12",
+	}
+	tlsConfig, err := c.TLSConfig()
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = tlsConfig
+	srv.StartTLS()
+	defer srv.Close()
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+	get := func(certs ...tls.Certificate) error {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+		}}}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	require.NoError(t, get(cert))
+	// Clients without a certificate are rejected.
+	require.Error(t, get())
+
+	c.KeyFile = filepath.Join(dir, "missing.pem")
+	_, err = c.TLSConfig()
+	require.Error(t, err)
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"time"
+
+	"github.com/go-kit/kit/log/level"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// expiryTracker remembers the silences that were reported as expiring,
+// so each silence is only reported once per end time.
+type expiryTracker struct {
+	notified map[string]*pb.Silence
+}
+
+func newExpiryTracker() *expiryTracker {
+	return &expiryTracker{notified: map[string]*pb.Silence{}}
+}
+
+// update returns the silences that were not reported with their current
+// end time yet and the reported silences that are no longer expiring, as
+// they were extended or expired. The latter are forgotten.
+func (t *expiryTracker) update(sils []*pb.Silence) (expiring, forgotten []*pb.Silence) {
+	current := make(map[string]*pb.Silence, len(sils))
+	for _, sil := range sils {
+		prev, ok := t.notified[sil.Id]
+		// A silence that was extended is reported again.
+		if ok && prev.EndsAt.Equal(sil.EndsAt) {
+			current[sil.Id] = prev
+			continue
+		}
+		current[sil.Id] = sil
+		expiring = append(expiring, sil)
+	}
+	for id, sil := range t.notified {
+		if _, ok := current[id]; !ok {
+			forgotten = append(forgotten, sil)
+		}
+	}
+	t.notified = current
+	return expiring, forgotten
+}
+
+// Expiring returns the active silences that end within the given duration.
+func (s *Silences) Expiring(d time.Duration) ([]*pb.Silence, error) {
+	q := &query{}
+	if err := QState(types.SilenceStateActive)(q); err != nil {
+		return nil, err
+	}
+	q.filters = append(q.filters, func(sil *pb.Silence, _ *Silences, now time.Time) (bool, error) {
+		return !sil.EndsAt.After(now.Add(d)), nil
+	})
+	return s.query(q, s.now())
+}
+
+// NotifyExpiring checks at the given interval for active silences ending
+// within the given duration and passes the ones not passed before to f,
+// along with the ones passed before which stopped expiring since.
+// It runs until stopc is closed.
+func (s *Silences) NotifyExpiring(interval, before time.Duration, f func(expiring, forgotten []*pb.Silence), stopc <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	tracker := newExpiryTracker()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			sils, err := s.Expiring(before)
+			if err != nil {
+				level.Error(s.logger).Log("msg", "Querying expiring silences failed", "err", err)
+				continue
+			}
+			expiring, forgotten := tracker.update(sils)
+			if len(expiring) > 0 || len(forgotten) > 0 {
+				f(expiring, forgotten)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSilencesExpiring(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.now = func() time.Time { return now }
+
+	m := &pb.Matcher{Type: pb.Matcher_EQUAL, Name: "a", Pattern: "b"}
+	s.st = state{
+		"ending": &pb.MeshSilence{Silence: &pb.Silence{
+			Id:       "ending",
+			Matchers: []*pb.Matcher{m},
+			StartsAt: now.Add(-time.Hour),
+			EndsAt:   now.Add(10 * time.Minute),
+		}},
+		"running": &pb.MeshSilence{Silence: &pb.Silence{
+			Id:       "running",
+			Matchers: []*pb.Matcher{m},
+			StartsAt: now.Add(-time.Hour),
+			EndsAt:   now.Add(time.Hour),
+		}},
+		"pending": &pb.MeshSilence{Silence: &pb.Silence{
+			Id:       "pending",
+			Matchers: []*pb.Matcher{m},
+			StartsAt: now.Add(time.Minute),
+			EndsAt:   now.Add(10 * time.Minute),
+		}},
+		"expired": &pb.MeshSilence{Silence: &pb.Silence{
+			Id:       "expired",
+			Matchers: []*pb.Matcher{m},
+			StartsAt: now.Add(-time.Hour),
+			EndsAt:   now.Add(-time.Minute),
+		}},
+	}
+
+	sils, err := s.Expiring(15 * time.Minute)
+	require.NoError(t, err)
+	require.Len(t, sils, 1)
+	require.Equal(t, "ending", sils[0].Id)
+}
+
+func TestExpiryTracker(t *testing.T) {
+	now := utcNow()
+	a := &pb.Silence{Id: "a", EndsAt: now}
+	b := &pb.Silence{Id: "b", EndsAt: now}
+
+	tr := newExpiryTracker()
+	update := func(sils ...*pb.Silence) []*pb.Silence {
+		expiring, forgotten := tr.update(sils)
+		require.Empty(t, forgotten)
+		return expiring
+	}
+	require.Equal(t, []*pb.Silence{a}, update(a))
+	require.Equal(t, []*pb.Silence{b}, update(a, b))
+	require.Nil(t, update(a, b))
+
+	// Extended silences are reported again.
+	extended := &pb.Silence{Id: "a", EndsAt: now.Add(time.Hour)}
+	require.Equal(t, []*pb.Silence{extended}, update(extended, b))
+
+	// Silences that stopped expiring are forgotten and returned as such.
+	expiring, forgotten := tr.update([]*pb.Silence{b})
+	require.Nil(t, expiring)
+	require.Equal(t, []*pb.Silence{extended}, forgotten)
+	require.Equal(t, []*pb.Silence{extended}, update(extended, b))
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/common/model"
+)
+
+// indexKey is the label pair a silence is indexed by. The zero value
+// denotes silences that are not indexed by any label.
+type indexKey struct {
+	name, value string
+}
+
+// silenceIndex is an inverted index of silence IDs by a label pair that
+// alerts must have to be matched by the silence. It narrows down the
+// silences that have to be evaluated for a label set.
+type silenceIndex struct {
+	byLabel map[indexKey]map[string]struct{}
+	keys    map[string]indexKey
+}
+
+func newSilenceIndex() *silenceIndex {
+	return &silenceIndex{
+		byLabel: map[indexKey]map[string]struct{}{},
+		keys:    map[string]indexKey{},
+	}
+}
+
+// keyOf returns the label pair required by the first equality matcher of
+// the silence. Matchers on empty values also match absent labels and
+// thus cannot be used.
+func keyOf(sil *pb.Silence) indexKey {
+	for _, m := range sil.Matchers {
+		if m.Type == pb.Matcher_EQUAL && m.Pattern != "" {
+			return indexKey{name: m.Name, value: m.Pattern}
+		}
+	}
+	return indexKey{}
+}
+
+// add indexes the silence, replacing a previous entry with the same ID.
+func (idx *silenceIndex) add(sil *pb.Silence) {
+	idx.remove(sil.Id)
+
+	k := keyOf(sil)
+	ids, ok := idx.byLabel[k]
+	if !ok {
+		ids = map[string]struct{}{}
+		idx.byLabel[k] = ids
+	}
+	ids[sil.Id] = struct{}{}
+	idx.keys[sil.Id] = k
+}
+
+// remove drops the silence with the given ID from the index.
+func (idx *silenceIndex) remove(id string) {
+	k, ok := idx.keys[id]
+	if !ok {
+		return
+	}
+	delete(idx.keys, id)
+
+	ids := idx.byLabel[k]
+	delete(ids, id)
+	if len(ids) == 0 {
+		delete(idx.byLabel, k)
+	}
+}
+
+// candidates returns the IDs of all silences that may match the label set.
+func (idx *silenceIndex) candidates(lset model.LabelSet) []string {
+	var res []string
+
+	for id := range idx.byLabel[indexKey{}] {
+		res = append(res, id)
+	}
+	for name, value := range lset {
+		for id := range idx.byLabel[indexKey{name: string(name), value: string(value)}] {
+			res = append(res, id)
+		}
+	}
+	return res
+}
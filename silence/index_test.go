@@ -0,0 +1,117 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSilenceIndex(t *testing.T) {
+	idx := newSilenceIndex()
+
+	idx.add(&pb.Silence{Id: "eq", Matchers: []*pb.Matcher{
+		{Name: "job", Pattern: "a.*", Type: pb.Matcher_REGEXP},
+		{Name: "env", Pattern: "prod", Type: pb.Matcher_EQUAL},
+	}})
+	idx.add(&pb.Silence{Id: "re", Matchers: []*pb.Matcher{
+		{Name: "job", Pattern: "a.*", Type: pb.Matcher_REGEXP},
+	}})
+	idx.add(&pb.Silence{Id: "neq", Matchers: []*pb.Matcher{
+		{Name: "env", Pattern: "prod", Type: pb.Matcher_NOT_EQUAL},
+	}})
+	idx.add(&pb.Silence{Id: "empty", Matchers: []*pb.Matcher{
+		{Name: "env", Pattern: "", Type: pb.Matcher_EQUAL},
+	}})
+
+	candidates := func(lset model.LabelSet) []string {
+		ids := idx.candidates(lset)
+		sort.Strings(ids)
+		return ids
+	}
+	require.Equal(t, []string{"empty", "eq", "neq", "re"}, candidates(model.LabelSet{"env": "prod"}))
+	require.Equal(t, []string{"empty", "neq", "re"}, candidates(model.LabelSet{"env": "dev"}))
+
+	// Re-adding a silence with different matchers moves it in the index.
+	idx.add(&pb.Silence{Id: "eq", Matchers: []*pb.Matcher{
+		{Name: "env", Pattern: "dev", Type: pb.Matcher_EQUAL},
+	}})
+	require.Equal(t, []string{"empty", "neq", "re"}, candidates(model.LabelSet{"env": "prod"}))
+	require.Equal(t, []string{"empty", "eq", "neq", "re"}, candidates(model.LabelSet{"env": "dev"}))
+
+	idx.remove("eq")
+	idx.remove("re")
+	idx.remove("unknown")
+	require.Equal(t, []string{"empty", "neq"}, candidates(model.LabelSet{"env": "dev"}))
+	require.Len(t, idx.keys, 2)
+}
+
+func TestSilencesQueryMatchesIndexed(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.now = func() time.Time { return now }
+
+	ids := map[string]string{}
+	for _, env := range []string{"prod", "dev"} {
+		id, err := s.Set(&pb.Silence{
+			Matchers: []*pb.Matcher{{Name: "env", Pattern: env, Type: pb.Matcher_EQUAL}},
+			StartsAt: now,
+			EndsAt:   now.Add(time.Hour),
+		}, "")
+		require.NoError(t, err)
+		ids[env] = id
+	}
+	// Silences received from peers are indexed as well.
+	b, err := marshalMeshSilence(&pb.MeshSilence{
+		Silence: &pb.Silence{
+			Id:        "peer",
+			Matchers:  []*pb.Matcher{{Name: "env", Pattern: "p.*", Type: pb.Matcher_REGEXP}},
+			StartsAt:  now,
+			EndsAt:    now.Add(time.Hour),
+			UpdatedAt: now,
+		},
+		ExpiresAt: now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Merge(b))
+
+	queryIDs := func(lset model.LabelSet) []string {
+		sils, err := s.Query(QMatches(lset))
+		require.NoError(t, err)
+		res := []string{}
+		for _, sil := range sils {
+			res = append(res, sil.Id)
+		}
+		sort.Strings(res)
+		return res
+	}
+	want := []string{ids["prod"], "peer"}
+	sort.Strings(want)
+	require.Equal(t, want, queryIDs(model.LabelSet{"env": "prod"}))
+	require.Equal(t, []string{ids["dev"]}, queryIDs(model.LabelSet{"env": "dev"}))
+
+	// Garbage collected silences are removed from the index.
+	now = now.Add(2 * time.Hour)
+	_, err = s.GC()
+	require.NoError(t, err)
+	require.Empty(t, queryIDs(model.LabelSet{"env": "prod"}))
+	require.Empty(t, s.idx.keys)
+}
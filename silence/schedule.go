@@ -0,0 +1,313 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+// maxRecurrenceDuration is the maximum length of a single window of a
+// recurring silence.
+const maxRecurrenceDuration = 7 * 24 * time.Hour
+
+// schedule is a parsed cron expression in the standard five-field format:
+// minute, hour, day of month, month and day of week.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	// Like in cron, if both day of month and day of week are restricted,
+	// a day matches if either of them matches.
+	domStar, dowStar bool
+}
+
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	weekdayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// parseSchedule parses a cron expression.
+func parseSchedule(s string) (*schedule, error) {
+	s = strings.TrimSpace(s)
+	if m, ok := scheduleMacros[strings.ToLower(s)]; ok {
+		s = m
+	}
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields but got %d", len(fields))
+	}
+	var (
+		sched schedule
+		err   error
+	)
+	if sched.minute, err = parseScheduleField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute %q: %s", fields[0], err)
+	}
+	if sched.hour, err = parseScheduleField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour %q: %s", fields[1], err)
+	}
+	if sched.dom, err = parseScheduleField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid day of month %q: %s", fields[2], err)
+	}
+	if sched.month, err = parseScheduleField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month %q: %s", fields[3], err)
+	}
+	if sched.dow, err = parseScheduleField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("invalid day of week %q: %s", fields[4], err)
+	}
+	// Both 0 and 7 denote Sunday.
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1
+	}
+	sched.domStar = fields[2] == "*" || fields[2] == "?"
+	sched.dowStar = fields[4] == "*" || fields[4] == "?"
+
+	return &sched, nil
+}
+
+// parseScheduleField parses a comma-separated list of values, ranges
+// and steps into a bit set.
+func parseScheduleField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		var (
+			rng  = part
+			step = 1
+			err  error
+		)
+		if i := strings.Index(part, "/"); i >= 0 {
+			rng = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rng == "*" || rng == "?":
+			lo, hi = min, max
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+			if lo, err = parseScheduleValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			if hi, err = parseScheduleValue(bounds[1], names); err != nil {
+				return 0, err
+			}
+		default:
+			if lo, err = parseScheduleValue(rng, names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			// A single value with a step runs up to the maximum.
+			if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value out of range [%d, %d]", min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseScheduleValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+// matches returns whether the schedule fires in the minute of t.
+func (s *schedule) matches(t time.Time) bool {
+	if s.minute&(1<<uint(t.Minute())) == 0 ||
+		s.hour&(1<<uint(t.Hour())) == 0 ||
+		s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	return s.matchesDay(t)
+}
+
+// matchesDay returns whether the schedule fires on the day of t.
+func (s *schedule) matchesDay(t time.Time) bool {
+	var (
+		domMatch = s.dom&(1<<uint(t.Day())) != 0
+		dowMatch = s.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// firedWithin returns whether the schedule fired in the period of length d
+// ending at t.
+func (s *schedule) firedWithin(t time.Time, d time.Duration) bool {
+	_, ok := s.lastFire(t, t.Add(-d))
+	return ok
+}
+
+// lastFire returns the latest minute not after t and after start in which
+// the schedule fires. Instead of checking every minute, it skips whole
+// months, days and hours that don't match. The search moves back in
+// absolute time, so minutes skipped or repeated by DST changes are handled
+// like the wall clock of t's location shows them.
+func (s *schedule) lastFire(t, start time.Time) (time.Time, bool) {
+	for m := t.Truncate(time.Minute); m.After(start); {
+		var (
+			y, mo, d = m.Date()
+			h, min   = m.Hour(), m.Minute()
+			next     time.Time
+		)
+		switch {
+		case s.month&(1<<uint(mo)) == 0:
+			next = time.Date(y, mo, 1, 0, 0, 0, 0, m.Location()).Add(-time.Minute)
+		case !s.matchesDay(m):
+			next = time.Date(y, mo, d, 0, 0, 0, 0, m.Location()).Add(-time.Minute)
+		case s.hour&(1<<uint(h)) == 0:
+			next = m.Add(-time.Duration(min+1) * time.Minute)
+		default:
+			// The latest matching minute of the hour that isn't after m.
+			mins := s.minute & (1<<uint(min+1) - 1)
+			if mins == 0 {
+				next = m.Add(-time.Duration(min+1) * time.Minute)
+				break
+			}
+			last := bits.Len64(mins) - 1
+			if last == min {
+				return m, true
+			}
+			next = m.Add(-time.Duration(min-last) * time.Minute)
+		}
+		// Fall back to the previous minute should a DST change make the
+		// computed time not precede m.
+		if !next.Before(m) {
+			next = m.Add(-time.Minute)
+		}
+		m = next
+	}
+	return time.Time{}, false
+}
+
+func validateRecurrence(r *pb.Recurrence) error {
+	if _, err := parseSchedule(r.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule %q: %s", r.Schedule, err)
+	}
+	if r.Duration <= 0 {
+		return fmt.Errorf("duration must be positive")
+	}
+	if r.Duration > maxRecurrenceDuration {
+		return fmt.Errorf("duration must not exceed %s", maxRecurrenceDuration)
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: %s", r.Timezone, err)
+	}
+	return nil
+}
+
+// recurrence is a parsed pb.Recurrence with its timezone resolved.
+type recurrence struct {
+	sched    *schedule
+	loc      *time.Location
+	duration time.Duration
+}
+
+func newRecurrence(r *pb.Recurrence) (*recurrence, error) {
+	sched, err := parseSchedule(r.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	// An empty timezone loads UTC.
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	return &recurrence{
+		sched:    sched,
+		loc:      loc,
+		duration: r.Duration,
+	}, nil
+}
+
+// contains returns whether ts falls into one of the windows of the
+// recurrence. A nil recurrence covers all times. The schedule is evaluated
+// in the recurrence's timezone, so windows follow DST changes.
+func (r *recurrence) contains(ts time.Time) bool {
+	if r == nil {
+		return true
+	}
+	return r.sched.firedWithin(ts.In(r.loc), r.duration)
+}
+
+// recurrenceCache caches the parsed recurrences of silences so their
+// schedules aren't parsed again on every evaluation.
+type recurrenceCache map[*pb.Silence]*recurrence
+
+// Get returns the parsed recurrence of the silence, which is nil if the
+// silence doesn't recur. The recurrence is parsed and added to the cache
+// on a miss.
+func (c recurrenceCache) Get(s *pb.Silence) (*recurrence, error) {
+	if s.Recurrence == nil {
+		return nil, nil
+	}
+	if r, ok := c[s]; ok {
+		return r, nil
+	}
+	r, err := newRecurrence(s.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	c[s] = r
+	return r, nil
+}
+
+// inRecurrence returns whether ts falls into one of the windows defined
+// by the recurrence. A nil recurrence covers all times.
+func inRecurrence(r *pb.Recurrence, ts time.Time) bool {
+	if r == nil {
+		return true
+	}
+	rec, err := newRecurrence(r)
+	if err != nil {
+		return false
+	}
+	return rec.contains(ts)
+}
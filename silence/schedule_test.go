@@ -0,0 +1,162 @@
+// Copyright 2018 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	cases := []struct {
+		in  string
+		err bool
+	}{
+		{in: "0 2 * * *"},
+		{in: "*/15 9-17 * * mon-fri"},
+		{in: "30 1 1,15 jan,jul *"},
+		{in: "0 0 * * 7"},
+		{in: "@daily"},
+		{in: "0 2 * *", err: true},
+		{in: "60 2 * * *", err: true},
+		{in: "0 24 * * *", err: true},
+		{in: "0 2 0 * *", err: true},
+		{in: "0 2 * 13 *", err: true},
+		{in: "0 2 * * foo", err: true},
+		{in: "*/0 2 * * *", err: true},
+		{in: "0 5-2 * * *", err: true},
+	}
+	for _, c := range cases {
+		_, err := parseSchedule(c.in)
+		if c.err {
+			require.Error(t, err, "expected error for %q", c.in)
+		} else {
+			require.NoError(t, err, "unexpected error for %q", c.in)
+		}
+	}
+}
+
+func TestScheduleMatches(t *testing.T) {
+	// 2018-06-04 is a Monday.
+	monday := time.Date(2018, 6, 4, 9, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		sched string
+		ts    time.Time
+		match bool
+	}{
+		{sched: "30 9 * * *", ts: monday, match: true},
+		{sched: "31 9 * * *", ts: monday, match: false},
+		{sched: "*/15 9-17 * * mon-fri", ts: monday, match: true},
+		{sched: "*/15 9-17 * * sat,sun", ts: monday, match: false},
+		{sched: "30 9 * * 1", ts: monday, match: true},
+		{sched: "30 9 * * 7", ts: monday.AddDate(0, 0, 6), match: true},
+		{sched: "30 9 4 jun *", ts: monday, match: true},
+		{sched: "30 9 5 * *", ts: monday, match: false},
+		// Day of month and day of week are OR'd if both are restricted.
+		{sched: "30 9 5 * mon", ts: monday, match: true},
+		{sched: "30 9 4 * sun", ts: monday, match: true},
+	}
+	for _, c := range cases {
+		sched, err := parseSchedule(c.sched)
+		require.NoError(t, err)
+		require.Equal(t, c.match, sched.matches(c.ts), "unexpected match result for %q at %s", c.sched, c.ts)
+	}
+}
+
+func TestScheduleLastFire(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// lastFire must find the same minute as checking every minute.
+	scan := func(s *schedule, t, start time.Time) (time.Time, bool) {
+		for m := t.Truncate(time.Minute); m.After(start); m = m.Add(-time.Minute) {
+			if s.matches(m) {
+				return m, true
+			}
+		}
+		return time.Time{}, false
+	}
+	scheds := []string{
+		"0 2 * * *",
+		"*/15 9-17 * * mon-fri",
+		"30 1 1,15 jan,jul *",
+		"59 23 * * sun",
+		"0 0 29 2 *",
+		"30 2 * * *",
+		"15 2 * * *",
+		"0-5 1-3 * mar,oct *",
+	}
+	// Cover the DST changes in Berlin on 2018-03-25 and 2018-10-28.
+	ends := []time.Time{
+		time.Date(2018, 3, 25, 3, 10, 0, 0, berlin),
+		time.Date(2018, 10, 28, 0, 30, 0, 0, time.UTC).In(berlin),
+		time.Date(2018, 10, 28, 1, 30, 0, 0, time.UTC).In(berlin),
+		time.Date(2018, 6, 4, 9, 30, 42, 0, berlin),
+		time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, sc := range scheds {
+		sched, err := parseSchedule(sc)
+		require.NoError(t, err)
+		for _, end := range ends {
+			start := end.Add(-maxRecurrenceDuration)
+			exp, expOK := scan(sched, end, start)
+			got, ok := sched.lastFire(end, start)
+			require.Equal(t, expOK, ok, "unexpected result for %q at %s", sc, end)
+			require.True(t, exp.Equal(got), "expected %s but got %s for %q at %s", exp, got, sc, end)
+		}
+	}
+}
+
+func TestInRecurrence(t *testing.T) {
+	r := &pb.Recurrence{
+		Schedule: "0 2 * * *",
+		Duration: 30 * time.Minute,
+	}
+	day := time.Date(2018, 6, 4, 0, 0, 0, 0, time.UTC)
+
+	require.True(t, inRecurrence(nil, day))
+
+	require.False(t, inRecurrence(r, day.Add(time.Hour+59*time.Minute)))
+	require.True(t, inRecurrence(r, day.Add(2*time.Hour)))
+	require.True(t, inRecurrence(r, day.Add(2*time.Hour+29*time.Minute)))
+	require.False(t, inRecurrence(r, day.Add(2*time.Hour+30*time.Minute)))
+	require.True(t, inRecurrence(r, day.AddDate(0, 0, 1).Add(2*time.Hour+10*time.Minute)))
+}
+
+func TestInRecurrenceTimezone(t *testing.T) {
+	r := &pb.Recurrence{
+		Schedule: "0 2 * * sat",
+		Duration: time.Hour,
+		Timezone: "Europe/Berlin",
+	}
+
+	// 02:00 in Berlin is 00:00 UTC in summer and 01:00 UTC in winter.
+	summer := time.Date(2018, 6, 2, 0, 0, 0, 0, time.UTC)
+	require.False(t, inRecurrence(r, summer.Add(-time.Minute)))
+	require.True(t, inRecurrence(r, summer))
+	require.True(t, inRecurrence(r, summer.Add(59*time.Minute)))
+	require.False(t, inRecurrence(r, summer.Add(time.Hour)))
+
+	winter := time.Date(2018, 12, 1, 1, 0, 0, 0, time.UTC)
+	require.False(t, inRecurrence(r, winter.Add(-time.Minute)))
+	require.True(t, inRecurrence(r, winter))
+	require.False(t, inRecurrence(r, winter.Add(time.Hour)))
+
+	r.Timezone = "Mars/Olympus_Mons"
+	require.False(t, inRecurrence(r, summer))
+}
@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net/url"
 	"os"
 	"reflect"
 	"regexp"
+	"sort"
 	"sync"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/matttproud/golang_protobuf_extensions/pbutil"
 	"github.com/pkg/errors"
 	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/pkg/wal"
 	pb "github.com/prometheus/alertmanager/silence/silencepb"
 	"github.com/prometheus/alertmanager/types"
 	"github.com/prometheus/client_golang/prometheus"
@@ -44,6 +47,9 @@ var ErrNotFound = fmt.Errorf("silence not found")
 // ErrInvalidState is returned if the state isn't valid.
 var ErrInvalidState = fmt.Errorf("invalid state")
 
+// maxHistory is the maximum number of edits kept in the history of a silence.
+const maxHistory = 50
+
 func utcNow() time.Time {
 	return time.Now().UTC()
 }
@@ -78,6 +84,11 @@ func (c matcherCache) add(s *pb.Silence) (types.Matchers, error) {
 			mt.IsRegex = false
 		case pb.Matcher_REGEXP:
 			mt.IsRegex = true
+		case pb.Matcher_NOT_EQUAL:
+			mt.IsNegative = true
+		case pb.Matcher_NOT_REGEXP:
+			mt.IsRegex = true
+			mt.IsNegative = true
 		}
 		err := mt.Init()
 		if err != nil {
@@ -98,11 +109,21 @@ type Silences struct {
 	metrics   *metrics
 	now       func() time.Time
 	retention time.Duration
+	// Maximum number of expired silences kept, zero means no limit.
+	maxExpired int
+	// Duration of silences set without end time and the maximum duration
+	// of silences. Zero disables the respective policy.
+	defaultDuration time.Duration
+	maxDuration     time.Duration
+	// If set, modifications are logged to it before they're applied.
+	wal *wal.WAL
 
 	mtx       sync.RWMutex
 	st        state
 	broadcast func([]byte)
 	mc        matcherCache
+	rc        recurrenceCache
+	idx       *silenceIndex
 }
 
 type metrics struct {
@@ -197,10 +218,28 @@ type Options struct {
 	SnapshotFile   string
 	SnapshotReader io.Reader
 
+	// A write-ahead log file to which every modification, made locally or
+	// by a peer, is appended before it's acknowledged, so no changes are
+	// lost on a crash. It's replayed on top of the snapshot on startup and
+	// truncated whenever Maintenance writes a snapshot.
+	WALFile string
+
 	// Retention time for newly created Silences. Silences may be
 	// garbage collected after the given duration after they ended.
 	Retention time.Duration
 
+	// Maximum number of expired silences to keep. If exceeded, the silences
+	// that ended the longest time ago are garbage collected before their
+	// retention time passed. Zero means no limit.
+	MaxExpired int
+
+	// Duration of silences that are set without an end time. If unset,
+	// silences must have an end time.
+	DefaultDuration time.Duration
+	// Maximum duration for which a silence can be in effect from the time
+	// it is set. Zero means no limit.
+	MaxDuration time.Duration
+
 	// A logger used by background processing.
 	Logger  log.Logger
 	Metrics prometheus.Registerer
@@ -210,6 +249,18 @@ func (o *Options) validate() error {
 	if o.SnapshotFile != "" && o.SnapshotReader != nil {
 		return fmt.Errorf("only one of SnapshotFile and SnapshotReader must be set")
 	}
+	if o.WALFile != "" && o.SnapshotFile == "" {
+		return fmt.Errorf("SnapshotFile must be set for WALFile")
+	}
+	if o.MaxExpired < 0 {
+		return fmt.Errorf("MaxExpired must not be negative")
+	}
+	if o.DefaultDuration < 0 || o.MaxDuration < 0 {
+		return fmt.Errorf("DefaultDuration and MaxDuration must not be negative")
+	}
+	if o.MaxDuration > 0 && o.DefaultDuration > o.MaxDuration {
+		return fmt.Errorf("DefaultDuration must not exceed MaxDuration")
+	}
 	return nil
 }
 
@@ -228,12 +279,18 @@ func New(o Options) (*Silences, error) {
 		}
 	}
 	s := &Silences{
-		mc:        matcherCache{},
-		logger:    log.NewNopLogger(),
-		retention: o.Retention,
-		now:       utcNow,
-		broadcast: func([]byte) {},
-		st:        state{},
+		mc:         matcherCache{},
+		rc:         recurrenceCache{},
+		idx:        newSilenceIndex(),
+		logger:     log.NewNopLogger(),
+		retention:  o.Retention,
+		maxExpired: o.MaxExpired,
+		now:        utcNow,
+		broadcast:  func([]byte) {},
+		st:         state{},
+
+		defaultDuration: o.DefaultDuration,
+		maxDuration:     o.MaxDuration,
 	}
 	s.metrics = newMetrics(o.Metrics, s)
 
@@ -245,11 +302,20 @@ func New(o Options) (*Silences, error) {
 			return s, err
 		}
 	}
+	if o.WALFile != "" {
+		w, err := wal.Open(o.WALFile, s.replay)
+		if err != nil {
+			return s, err
+		}
+		s.wal = w
+		s.reindex()
+	}
 	return s, nil
 }
 
 // Maintenance garbage collects the silence state at the given interval. If the snapshot
-// file is set, a snapshot is written to it afterwards.
+// file is set, a snapshot is written to it afterwards and the write-ahead log is
+// truncated.
 // Terminates on receiving from stopc.
 func (s *Silences) Maintenance(interval time.Duration, snapf string, stopc <-chan struct{}) {
 	t := time.NewTicker(interval)
@@ -271,14 +337,9 @@ func (s *Silences) Maintenance(interval time.Duration, snapf string, stopc <-cha
 		if snapf == "" {
 			return nil
 		}
-		f, err := openReplace(snapf)
-		if err != nil {
-			return err
-		}
-		if size, err = s.Snapshot(f); err != nil {
-			return err
-		}
-		return f.Close()
+		var err error
+		size, err = s.checkpoint(snapf)
+		return err
 	}
 
 Loop:
@@ -302,7 +363,8 @@ Loop:
 }
 
 // GC runs a garbage collection that removes silences that have ended longer
-// than the configured retention time ago.
+// than the configured retention time ago. If more expired silences than
+// configured remain afterwards, the ones that ended first are removed as well.
 func (s *Silences) GC() (int, error) {
 	start := time.Now()
 	defer func() { s.metrics.gcDuration.Observe(time.Since(start).Seconds()) }()
@@ -313,6 +375,8 @@ func (s *Silences) GC() (int, error) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
+	var expired []*pb.Silence
+
 	for id, sil := range s.st {
 		if sil.ExpiresAt.IsZero() {
 			return n, errors.New("unexpected zero expiration timestamp")
@@ -320,10 +384,33 @@ func (s *Silences) GC() (int, error) {
 		if !sil.ExpiresAt.After(now) {
 			delete(s.st, id)
 			delete(s.mc, sil.Silence)
+			delete(s.rc, sil.Silence)
+			s.unindex(id)
 			n++
+			continue
+		}
+		if sil.Silence != nil && getRangeState(sil.Silence, now) == types.SilenceStateExpired {
+			expired = append(expired, sil.Silence)
 		}
 	}
 
+	if s.maxExpired == 0 || len(expired) <= s.maxExpired {
+		return n, nil
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		if expired[i].EndsAt.Equal(expired[j].EndsAt) {
+			return expired[i].Id < expired[j].Id
+		}
+		return expired[i].EndsAt.Before(expired[j].EndsAt)
+	})
+	for _, sil := range expired[:len(expired)-s.maxExpired] {
+		delete(s.st, sil.Id)
+		delete(s.mc, sil)
+		delete(s.rc, sil)
+		s.unindex(sil.Id)
+		n++
+	}
+
 	return n, nil
 }
 
@@ -332,11 +419,11 @@ func validateMatcher(m *pb.Matcher) error {
 		return fmt.Errorf("invalid label name %q", m.Name)
 	}
 	switch m.Type {
-	case pb.Matcher_EQUAL:
+	case pb.Matcher_EQUAL, pb.Matcher_NOT_EQUAL:
 		if !model.LabelValue(m.Pattern).IsValid() {
 			return fmt.Errorf("invalid label value %q", m.Pattern)
 		}
-	case pb.Matcher_REGEXP:
+	case pb.Matcher_REGEXP, pb.Matcher_NOT_REGEXP:
 		if _, err := regexp.Compile(m.Pattern); err != nil {
 			return fmt.Errorf("invalid regular expression %q: %s", m.Pattern, err)
 		}
@@ -370,6 +457,30 @@ func validateSilence(s *pb.Silence) error {
 	if s.UpdatedAt.IsZero() {
 		return errors.New("invalid zero update timestamp")
 	}
+	if s.Recurrence != nil {
+		if err := validateRecurrence(s.Recurrence); err != nil {
+			return fmt.Errorf("invalid recurrence: %s", err)
+		}
+	}
+	if s.TicketUrl != "" {
+		if err := validateTicketURL(s.TicketUrl); err != nil {
+			return fmt.Errorf("invalid ticket URL %q: %s", s.TicketUrl, err)
+		}
+	}
+	return nil
+}
+
+func validateTicketURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return errors.New("host missing")
+	}
 	return nil
 }
 
@@ -402,8 +513,13 @@ func (s *Silences) setSilence(sil *pb.Silence) error {
 	if err != nil {
 		return err
 	}
+	if err := s.logChanges(b); err != nil {
+		return err
+	}
 
-	s.st.merge(msil)
+	if s.st.merge(msil) {
+		s.index(sil)
+	}
 	s.broadcast(b)
 
 	return nil
@@ -411,28 +527,111 @@ func (s *Silences) setSilence(sil *pb.Silence) error {
 
 // Set the specified silence. If a silence with the ID already exists and the modification
 // modifies history, the old silence gets expired and a new one is created.
-func (s *Silences) Set(sil *pb.Silence) (string, error) {
+// The editor is recorded in the history of modified silences.
+func (s *Silences) Set(sil *pb.Silence, editor string) (string, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	id, err := s.set(sil, editor, s.now())
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// SetAll sets all given silences like Set. If any of the silences cannot be
+// set, none of them are.
+func (s *Silences) SetAll(sils []*pb.Silence, editor string) ([]string, error) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
 	now := s.now()
+	seen := map[string]struct{}{}
+
+	for i, sil := range sils {
+		if sil.Id != "" {
+			if _, ok := seen[sil.Id]; ok {
+				return nil, errors.Errorf("silence %d: duplicate silence ID %q", i, sil.Id)
+			}
+			seen[sil.Id] = struct{}{}
+		}
+		if err := s.checkSet(sil, now); err != nil {
+			return nil, errors.Wrapf(err, "silence %d", i)
+		}
+	}
+
+	ids := make([]string, 0, len(sils))
+	for _, sil := range sils {
+		id, err := s.set(sil, editor, now)
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// checkSet returns the error setting the silence would fail with without
+// modifying any state.
+func (s *Silences) checkSet(sil *pb.Silence, now time.Time) error {
+	prev, ok := s.getSilence(sil.Id)
+	if sil.Id != "" && !ok {
+		return ErrNotFound
+	}
+	c := cloneSilence(sil)
+	c.UpdatedAt = now
+
+	s.applyDefaultDuration(c, now)
+	if err := s.checkDuration(c, now); err != nil {
+		return err
+	}
+
+	if !ok || !canUpdate(prev, c, now) {
+		// A new silence would be created. Its ID is not yet known.
+		c.Id = "new"
+		if c.StartsAt.Before(now) {
+			c.StartsAt = now
+		}
+	}
+	if err := validateSilence(c); err != nil {
+		return errors.Wrap(err, "silence invalid")
+	}
+	return nil
+}
+
+func (s *Silences) set(sil *pb.Silence, editor string, now time.Time) (string, error) {
 	prev, ok := s.getSilence(sil.Id)
 
 	if sil.Id != "" && !ok {
 		return "", ErrNotFound
 	}
+	// The history is maintained by the silences and never taken as given.
+	sil.History = nil
+
+	s.applyDefaultDuration(sil, now)
+	if err := s.checkDuration(sil, now); err != nil {
+		return "", err
+	}
+
 	if ok {
+		// The owner cannot be changed by modifications.
+		sil.Owner = prev.Owner
+		addEdit(sil, prev, editor, now)
+
 		if canUpdate(prev, sil, now) {
 			return sil.Id, s.setSilence(sil)
 		}
-		if getState(prev, s.now()) != types.SilenceStateExpired {
+		if getRangeState(prev, now) != types.SilenceStateExpired {
 			// We cannot update the silence, expire the old one.
-			if err := s.expire(prev.Id); err != nil {
+			if err := s.expire(prev.Id, editor); err != nil {
 				return "", errors.Wrap(err, "expire previous silence")
 			}
 		}
 	}
-	// If we got here it's either a new silence or a replacing one.
+	// If we got here it's either a new silence or a replacing one. A replacing
+	// silence carries on the history of the one it replaces.
 	sil.Id = uuid.NewV4().String()
 
 	if sil.StartsAt.Before(now) {
@@ -442,14 +641,46 @@ func (s *Silences) Set(sil *pb.Silence) (string, error) {
 	return sil.Id, s.setSilence(sil)
 }
 
+// applyDefaultDuration sets the end of a silence without end time to the
+// default duration after its start.
+func (s *Silences) applyDefaultDuration(sil *pb.Silence, now time.Time) {
+	if !sil.EndsAt.IsZero() || s.defaultDuration == 0 {
+		return
+	}
+	start := sil.StartsAt
+	if start.Before(now) {
+		start = now
+	}
+	sil.EndsAt = start.Add(s.defaultDuration)
+}
+
+// checkDuration returns an error if the silence would be in effect for
+// longer than the maximum duration from now on.
+func (s *Silences) checkDuration(sil *pb.Silence, now time.Time) error {
+	if s.maxDuration == 0 {
+		return nil
+	}
+	start := sil.StartsAt
+	if start.Before(now) {
+		start = now
+	}
+	if d := sil.EndsAt.Sub(start); d > s.maxDuration {
+		return errors.Errorf("silence duration %s exceeds the maximum of %s", d, s.maxDuration)
+	}
+	return nil
+}
+
 // canUpdate returns true if silence a can be updated to b without
 // affecting the historic view of silencing.
 func canUpdate(a, b *pb.Silence, now time.Time) bool {
 	if !reflect.DeepEqual(a.Matchers, b.Matchers) {
 		return false
 	}
+	if !reflect.DeepEqual(a.Recurrence, b.Recurrence) {
+		return false
+	}
 	// Allowed timestamp modifications depend on the current time.
-	switch st := getState(a, now); st {
+	switch st := getRangeState(a, now); st {
 	case types.SilenceStateActive:
 		if !b.StartsAt.Equal(a.StartsAt) {
 			return false
@@ -469,23 +700,106 @@ func canUpdate(a, b *pb.Silence, now time.Time) bool {
 	return true
 }
 
-// Expire the silence with the given ID immediately.
-func (s *Silences) Expire(id string) error {
+// addEdit records in the history of sil that it was modified from prev.
+func addEdit(sil, prev *pb.Silence, editor string, now time.Time) {
+	before := cloneSilence(prev)
+	before.History = nil
+
+	history := make([]*pb.Edit, 0, len(prev.History)+1)
+	history = append(history, prev.History...)
+	history = append(history, &pb.Edit{
+		Timestamp: now,
+		Editor:    editor,
+		Previous:  before,
+	})
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	sil.History = history
+}
+
+// Extend moves the end of the silence with the given ID by d and records
+// the given editor in its history. Expired silences cannot be extended.
+func (s *Silences) Extend(id string, d time.Duration, editor string) error {
+	if d <= 0 {
+		return errors.New("extension must be positive")
+	}
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
-	return s.expire(id)
+
+	prev, ok := s.getSilence(id)
+	if !ok {
+		return ErrNotFound
+	}
+	now := s.now()
+	if getRangeState(prev, now) == types.SilenceStateExpired {
+		return errors.Errorf("silence %s already expired", id)
+	}
+	sil := cloneSilence(prev)
+	sil.EndsAt = sil.EndsAt.Add(d)
+	if err := s.checkDuration(sil, now); err != nil {
+		return err
+	}
+	addEdit(sil, prev, editor, now)
+
+	return s.setSilence(sil)
+}
+
+// Expire the silence with the given ID immediately and records the editor in
+// its history.
+func (s *Silences) Expire(id, editor string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.expire(id, editor)
+}
+
+// ExpireAll expires the silences with the given IDs immediately like Expire.
+// If any of the silences cannot be expired, none of them are.
+func (s *Silences) ExpireAll(ids []string, editor string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var (
+		now  = s.now()
+		uniq = make([]string, 0, len(ids))
+		seen = map[string]struct{}{}
+	)
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		uniq = append(uniq, id)
+
+		sil, ok := s.getSilence(id)
+		if !ok {
+			return errors.Wrapf(ErrNotFound, "silence %s", id)
+		}
+		if getRangeState(sil, now) == types.SilenceStateExpired {
+			return errors.Errorf("silence %s already expired", id)
+		}
+	}
+	for _, id := range uniq {
+		if err := s.expire(id, editor); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Expire the silence with the given ID immediately.
-func (s *Silences) expire(id string) error {
-	sil, ok := s.getSilence(id)
+func (s *Silences) expire(id, editor string) error {
+	prev, ok := s.getSilence(id)
 	if !ok {
 		return ErrNotFound
 	}
-	sil = cloneSilence(sil)
+	sil := cloneSilence(prev)
 	now := s.now()
+	addEdit(sil, prev, editor, now)
 
-	switch getState(sil, now) {
+	switch getRangeState(sil, now) {
 	case types.SilenceStateExpired:
 		return errors.Errorf("silence %s already expired", id)
 	case types.SilenceStateActive:
@@ -499,12 +813,52 @@ func (s *Silences) expire(id string) error {
 	return s.setSilence(sil)
 }
 
+// Delete removes the expired silence with the given ID from the state
+// entirely. Unlike expiring a silence, this removes all trace of it and is
+// meant for administrative cleanup. Peers are told to drop the silence as well.
+func (s *Silences) Delete(id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	prev, ok := s.getSilence(id)
+	if !ok {
+		return ErrNotFound
+	}
+	now := s.now()
+	if getRangeState(prev, now) != types.SilenceStateExpired {
+		return errors.Errorf("silence %s must be expired before it can be deleted", id)
+	}
+
+	// Gossip the silence as expiring immediately, so that peers replace
+	// their copy and drop it.
+	sil := cloneSilence(prev)
+	sil.UpdatedAt = now
+	b, err := marshalMeshSilence(&pb.MeshSilence{Silence: sil, ExpiresAt: now})
+	if err != nil {
+		return err
+	}
+	if err := s.logChanges(b); err != nil {
+		return err
+	}
+
+	delete(s.st, id)
+	delete(s.mc, prev)
+	delete(s.rc, prev)
+	s.unindex(id)
+	s.broadcast(b)
+
+	return nil
+}
+
 // QueryParam expresses parameters along which silences are queried.
 type QueryParam func(*query) error
 
 type query struct {
 	ids     []string
 	filters []silenceFilter
+	// If set, only silences that may match the label set according
+	// to the index are evaluated.
+	lset model.LabelSet
 }
 
 // silenceFilter is a function that returns true if a silence
@@ -541,12 +895,38 @@ func QMatches(set model.LabelSet) QueryParam {
 			return m.Match(set), nil
 		}
 		q.filters = append(q.filters, f)
+		q.lset = set
 		return nil
 	}
 }
 
-// getState returns a silence's SilenceState at the given timestamp.
-func getState(sil *pb.Silence, ts time.Time) types.SilenceState {
+// State returns a silence's effective SilenceState at the given timestamp.
+// A recurring silence is only active during one of its windows and
+// pending in between.
+func State(sil *pb.Silence, ts time.Time) types.SilenceState {
+	st := getRangeState(sil, ts)
+	if st == types.SilenceStateActive && !inRecurrence(sil.Recurrence, ts) {
+		return types.SilenceStatePending
+	}
+	return st
+}
+
+// state is like State but uses the cached recurrence of the stored silence.
+// It must be called with the lock held.
+func (s *Silences) state(sil *pb.Silence, ts time.Time) types.SilenceState {
+	st := getRangeState(sil, ts)
+	if st != types.SilenceStateActive {
+		return st
+	}
+	if r, err := s.rc.Get(sil); err != nil || !r.contains(ts) {
+		return types.SilenceStatePending
+	}
+	return st
+}
+
+// getRangeState returns a silence's SilenceState at the given timestamp
+// based on its time range only.
+func getRangeState(sil *pb.Silence, ts time.Time) types.SilenceState {
 	if ts.Before(sil.StartsAt) {
 		return types.SilenceStatePending
 	}
@@ -559,11 +939,11 @@ func getState(sil *pb.Silence, ts time.Time) types.SilenceState {
 // QState filters queried silences by the given states.
 func QState(states ...types.SilenceState) QueryParam {
 	return func(q *query) error {
-		f := func(sil *pb.Silence, _ *Silences, now time.Time) (bool, error) {
-			s := getState(sil, now)
+		f := func(sil *pb.Silence, s *Silences, now time.Time) (bool, error) {
+			st := s.state(sil, now)
 
 			for _, ps := range states {
-				if s == ps {
+				if st == ps {
 					return true, nil
 				}
 			}
@@ -618,6 +998,35 @@ func (s *Silences) CountState(states ...types.SilenceState) (int, error) {
 	return len(sils), nil
 }
 
+// PreviewUpdate returns a function reporting whether a label set is
+// silenced at the given time by the stored silence with the ID of sil and
+// by sil replacing it. This shows which alerts start and stop being
+// silenced by an edit before it is saved.
+func (s *Silences) PreviewUpdate(sil *pb.Silence, now time.Time) (func(model.LabelSet) (before, after bool), error) {
+	s.mtx.Lock()
+	prev, ok := s.getSilence(sil.Id)
+	if !ok {
+		s.mtx.Unlock()
+		return nil, ErrNotFound
+	}
+	prevMatchers, err := s.mc.Get(prev)
+	prevActive := s.state(prev, now) == types.SilenceStateActive
+	s.mtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	// Don't cache the matchers of the edited silence as it isn't stored.
+	matchers, err := matcherCache{}.add(sil)
+	if err != nil {
+		return nil, err
+	}
+
+	active := State(sil, now) == types.SilenceStateActive
+	return func(lset model.LabelSet) (bool, bool) {
+		return prevActive && prevMatchers.Match(lset), active && matchers.Match(lset)
+	}, nil
+}
+
 func (s *Silences) query(q *query, now time.Time) ([]*pb.Silence, error) {
 	// If we have an ID constraint, all silences are our base set.
 	// This and the use of post-filter functions is the
@@ -633,6 +1042,12 @@ func (s *Silences) query(q *query, now time.Time) ([]*pb.Silence, error) {
 				res = append(res, s.Silence)
 			}
 		}
+	} else if q.lset != nil && s.idx != nil {
+		for _, id := range s.idx.candidates(q.lset) {
+			if s, ok := s.st[id]; ok {
+				res = append(res, s.Silence)
+			}
+		}
 	} else {
 		for _, sil := range s.st {
 			res = append(res, sil.Silence)
@@ -660,6 +1075,29 @@ func (s *Silences) query(q *query, now time.Time) ([]*pb.Silence, error) {
 	return resf, nil
 }
 
+// index adds the silence to the label index, if there is one.
+func (s *Silences) index(sil *pb.Silence) {
+	if s.idx != nil {
+		s.idx.add(sil)
+	}
+}
+
+// unindex removes the silence with the given ID from the label index,
+// if there is one.
+func (s *Silences) unindex(id string) {
+	if s.idx != nil {
+		s.idx.remove(id)
+	}
+}
+
+// reindex rebuilds the label index from the current state.
+func (s *Silences) reindex() {
+	s.idx = newSilenceIndex()
+	for _, e := range s.st {
+		s.idx.add(e.Silence)
+	}
+}
+
 // loadSnapshot loads a snapshot generated by Snapshot() into the state.
 // Any previous state is wiped.
 func (s *Silences) loadSnapshot(r io.Reader) error {
@@ -678,6 +1116,7 @@ func (s *Silences) loadSnapshot(r io.Reader) error {
 	}
 	s.mtx.Lock()
 	s.st = st
+	s.reindex()
 	s.mtx.Unlock()
 
 	return nil
@@ -700,6 +1139,73 @@ func (s *Silences) Snapshot(w io.Writer) (int64, error) {
 	return io.Copy(w, bytes.NewReader(b))
 }
 
+// logChanges writes modified silences, encoded as for gossip, to the
+// write-ahead log, if any. It must be called before the modifications are
+// applied to the state, which must be left untouched if it fails.
+// The caller must hold the lock.
+func (s *Silences) logChanges(records ...[]byte) error {
+	if s.wal == nil || len(records) == 0 {
+		return nil
+	}
+	if err := s.wal.Log(records...); err != nil {
+		return errors.Wrap(err, "write to write-ahead log")
+	}
+	return nil
+}
+
+// replay applies a record of the write-ahead log to the state like a
+// message from a peer. The state must be reindexed afterwards.
+func (s *Silences) replay(b []byte) error {
+	st, err := decodeState(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, e := range st {
+		if e.ExpiresAt.After(now) {
+			s.st.merge(e)
+		} else if prev, ok := s.st[e.Silence.Id]; ok && prev.Silence.UpdatedAt.Before(e.Silence.UpdatedAt) {
+			delete(s.st, e.Silence.Id)
+		}
+	}
+	return nil
+}
+
+// checkpoint writes a snapshot of the state to the file and truncates the
+// write-ahead log, as the snapshot contains all logged modifications.
+func (s *Silences) checkpoint(filename string) (int64, error) {
+	start := time.Now()
+	defer func() { s.metrics.snapshotDuration.Observe(time.Since(start).Seconds()) }()
+
+	// Modifications must not be logged between writing the snapshot and
+	// truncating the log.
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	b, err := s.st.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	f, err := openReplace(filename)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.File.Close()
+		os.Remove(f.File.Name())
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	if s.wal != nil {
+		if err := s.wal.Truncate(); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(b)), nil
+}
+
 // MarshalBinary serializes all silences.
 func (s *Silences) MarshalBinary() ([]byte, error) {
 	s.mtx.Lock()
@@ -717,8 +1223,49 @@ func (s *Silences) Merge(b []byte) error {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
+	now := s.now()
+
+	// Silences newer than the local ones are merged, or removed if they were
+	// deleted by a peer. They are logged before any of them is applied.
+	var (
+		updates []*pb.MeshSilence
+		records [][]byte
+	)
 	for _, e := range st {
-		if merged := s.st.merge(e); merged && !cluster.OversizedMessage(b) {
+		prev, ok := s.st[e.Silence.Id]
+		if ok && !prev.Silence.UpdatedAt.Before(e.Silence.UpdatedAt) {
+			continue
+		}
+		if !ok && !e.ExpiresAt.After(now) {
+			continue
+		}
+		updates = append(updates, e)
+		if s.wal != nil {
+			// Log the silence on its own as the message may contain
+			// silences which aren't merged.
+			mb, err := marshalMeshSilence(e)
+			if err != nil {
+				return err
+			}
+			records = append(records, mb)
+		}
+	}
+	if err := s.logChanges(records...); err != nil {
+		return err
+	}
+
+	for _, e := range updates {
+		if e.ExpiresAt.After(now) {
+			s.st.merge(e)
+			s.index(e.Silence)
+		} else {
+			prev := s.st[e.Silence.Id]
+			delete(s.st, e.Silence.Id)
+			delete(s.mc, prev.Silence)
+			delete(s.rc, prev.Silence)
+			s.unindex(e.Silence.Id)
+		}
+		if !cluster.OversizedMessage(b) {
 			// If this is the first we've seen the message and it's
 			// not oversized, gossip it to other nodes. We don't
 			// propagate oversized messages because they're sent to
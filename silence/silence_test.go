@@ -15,14 +15,17 @@ package silence
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/matttproud/golang_protobuf_extensions/pbutil"
+	"github.com/pkg/errors"
 	pb "github.com/prometheus/alertmanager/silence/silencepb"
 	"github.com/prometheus/alertmanager/types"
 	"github.com/prometheus/common/model"
@@ -51,6 +54,44 @@ func TestOptionsValidate(t *testing.T) {
 			},
 			err: "only one of SnapshotFile and SnapshotReader must be set",
 		},
+		{
+			options: &Options{
+				SnapshotFile: "test.bkp",
+				WALFile:      "test.wal",
+			},
+		},
+		{
+			options: &Options{
+				SnapshotReader: &bytes.Buffer{},
+				WALFile:        "test.wal",
+			},
+			err: "SnapshotFile must be set for WALFile",
+		},
+		{
+			options: &Options{
+				MaxExpired: -1,
+			},
+			err: "MaxExpired must not be negative",
+		},
+		{
+			options: &Options{
+				DefaultDuration: time.Hour,
+				MaxDuration:     2 * time.Hour,
+			},
+		},
+		{
+			options: &Options{
+				MaxDuration: -time.Hour,
+			},
+			err: "DefaultDuration and MaxDuration must not be negative",
+		},
+		{
+			options: &Options{
+				DefaultDuration: 2 * time.Hour,
+				MaxDuration:     time.Hour,
+			},
+			err: "DefaultDuration must not exceed MaxDuration",
+		},
 	}
 
 	for _, c := range cases {
@@ -96,6 +137,48 @@ func TestSilencesGC(t *testing.T) {
 	require.Equal(t, want, s.st)
 }
 
+func TestSilencesGCMaxExpired(t *testing.T) {
+	s, err := New(Options{MaxExpired: 2})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.now = func() time.Time { return now }
+
+	newSilence := func(id string, end time.Time) *pb.MeshSilence {
+		return &pb.MeshSilence{
+			Silence: &pb.Silence{
+				Id:       id,
+				StartsAt: now.Add(-time.Hour),
+				EndsAt:   end,
+			},
+			ExpiresAt: now.Add(time.Hour),
+		}
+	}
+	s.st = state{
+		"1": newSilence("1", now.Add(-3*time.Minute)),
+		"2": newSilence("2", now.Add(-2*time.Minute)),
+		"3": newSilence("3", now.Add(-time.Minute)),
+		"4": newSilence("4", now.Add(-4*time.Minute)),
+		"5": newSilence("5", now.Add(time.Minute)),
+		"6": {Silence: &pb.Silence{Id: "6"}, ExpiresAt: now.Add(-time.Second)},
+	}
+
+	n, err := s.GC()
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+
+	var ids []string
+	for id := range s.st {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	require.Equal(t, []string{"2", "3", "5"}, ids)
+
+	n, err = s.GC()
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+}
+
 func TestSilencesSnapshot(t *testing.T) {
 	// Check whether storing and loading the snapshot is symmetric.
 	now := utcNow()
@@ -130,6 +213,22 @@ func TestSilencesSnapshot(t *testing.T) {
 					},
 					ExpiresAt: now.Add(24 * time.Hour),
 				},
+				{
+					Silence: &pb.Silence{
+						Id: "0f2d4b6e-61b8-4fd3-a9be-4f4d5a1b6c2e",
+						Matchers: []*pb.Matcher{
+							{Name: "job", Pattern: "batch", Type: pb.Matcher_EQUAL},
+						},
+						StartsAt:  now,
+						EndsAt:    now.Add(24 * time.Hour),
+						UpdatedAt: now,
+						Recurrence: &pb.Recurrence{
+							Schedule: "0 2 * * *",
+							Duration: 30 * time.Minute,
+						},
+					},
+					ExpiresAt: now.Add(48 * time.Hour),
+				},
 			},
 		},
 	}
@@ -161,6 +260,93 @@ func TestSilencesSnapshot(t *testing.T) {
 	}
 }
 
+func TestSilencesWAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "silences")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		snapf = filepath.Join(dir, "silences")
+		walf  = filepath.Join(dir, "silences.wal")
+		opts  = Options{SnapshotFile: snapf, WALFile: walf, Retention: time.Hour}
+	)
+	s1, err := New(opts)
+	require.NoError(t, err)
+
+	// Deletions are replayed based on the current time, the clock must not
+	// advance past it.
+	now := utcNow().Add(-10 * time.Minute)
+	s1.now = func() time.Time { return now }
+
+	id, err := s1.Set(&pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now.Add(time.Minute),
+		EndsAt:   now.Add(time.Hour),
+	}, "")
+	require.NoError(t, err)
+
+	// The silence must be recoverable without any snapshot.
+	_, err = os.Stat(snapf)
+	require.True(t, os.IsNotExist(err))
+	s2, err := New(opts)
+	require.NoError(t, err)
+	require.Equal(t, s1.st, s2.st)
+	sils, err := s2.Query(QMatches(model.LabelSet{"a": "b"}))
+	require.NoError(t, err)
+	require.Len(t, sils, 1)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, s1.Expire(id, ""))
+
+	s3, err := New(opts)
+	require.NoError(t, err)
+	require.Equal(t, s1.st, s3.st)
+
+	// State received from peers is logged as well.
+	b, err := marshalMeshSilence(&pb.MeshSilence{
+		Silence: &pb.Silence{
+			Id:        "peer",
+			Matchers:  []*pb.Matcher{{Name: "a", Pattern: "c"}},
+			StartsAt:  now,
+			EndsAt:    now.Add(time.Hour),
+			UpdatedAt: now,
+		},
+		ExpiresAt: now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s1.Merge(b))
+
+	s4, err := New(opts)
+	require.NoError(t, err)
+	require.Len(t, s4.st, 2)
+	require.Equal(t, s1.st, s4.st)
+
+	// Snapshots truncate the log and modifications after them are
+	// replayed on top.
+	_, err = s1.checkpoint(snapf)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), s1.wal.Size())
+	now = now.Add(time.Minute)
+	require.NoError(t, s1.Delete(id))
+
+	s5, err := New(opts)
+	require.NoError(t, err)
+	require.Len(t, s5.st, 1)
+	require.Equal(t, s1.st, s5.st)
+
+	// Modifications which cannot be logged fail and aren't applied.
+	require.NoError(t, s1.wal.Close())
+	_, err = s1.Set(&pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "d"}},
+		StartsAt: now.Add(time.Minute),
+		EndsAt:   now.Add(time.Hour),
+	}, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "write to write-ahead log")
+	require.Error(t, s1.Expire("peer", ""))
+	require.Equal(t, s5.st, s1.st)
+}
+
 func TestSilencesSetSilence(t *testing.T) {
 	s, err := New(Options{
 		Retention: time.Minute,
@@ -228,7 +414,7 @@ func TestSilenceSet(t *testing.T) {
 		StartsAt: now.Add(2 * time.Minute),
 		EndsAt:   now.Add(5 * time.Minute),
 	}
-	id1, err := s.Set(sil1)
+	id1, err := s.Set(sil1, "")
 	require.NoError(t, err)
 	require.NotEqual(t, id1, "")
 
@@ -254,7 +440,7 @@ func TestSilenceSet(t *testing.T) {
 		Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
 		EndsAt:   now.Add(1 * time.Minute),
 	}
-	id2, err := s.Set(sil2)
+	id2, err := s.Set(sil2, "")
 	require.NoError(t, err)
 	require.NotEqual(t, id2, "")
 
@@ -280,10 +466,20 @@ func TestSilenceSet(t *testing.T) {
 	sil3 := cloneSilence(sil2)
 	sil3.EndsAt = now.Add(100 * time.Minute)
 
-	id3, err := s.Set(sil3)
+	id3, err := s.Set(sil3, "")
 	require.NoError(t, err)
 	require.Equal(t, id2, id3)
 
+	edit3 := &pb.Edit{
+		Timestamp: now3,
+		Previous: &pb.Silence{
+			Id:        id2,
+			Matchers:  []*pb.Matcher{{Name: "a", Pattern: "b"}},
+			StartsAt:  now2,
+			EndsAt:    now2.Add(1 * time.Minute),
+			UpdatedAt: now2,
+		},
+	}
 	want = state{
 		id1: want[id1],
 		id2: &pb.MeshSilence{
@@ -293,6 +489,7 @@ func TestSilenceSet(t *testing.T) {
 				StartsAt:  now2,
 				EndsAt:    now3.Add(100 * time.Minute),
 				UpdatedAt: now3,
+				History:   []*pb.Edit{edit3},
 			},
 			ExpiresAt: now3.Add(100*time.Minute + s.retention),
 		},
@@ -306,10 +503,20 @@ func TestSilenceSet(t *testing.T) {
 	sil4 := cloneSilence(sil3)
 	sil4.Matchers = []*pb.Matcher{{Name: "a", Pattern: "c"}}
 
-	id4, err := s.Set(sil4)
+	id4, err := s.Set(sil4, "")
 	require.NoError(t, err)
 	require.NotEqual(t, id2, id4)
 
+	edit4 := &pb.Edit{
+		Timestamp: now4,
+		Previous: &pb.Silence{
+			Id:        id2,
+			Matchers:  []*pb.Matcher{{Name: "a", Pattern: "b"}},
+			StartsAt:  now2,
+			EndsAt:    now3.Add(100 * time.Minute),
+			UpdatedAt: now3,
+		},
+	}
 	want = state{
 		id1: want[id1],
 		id2: &pb.MeshSilence{
@@ -319,6 +526,7 @@ func TestSilenceSet(t *testing.T) {
 				StartsAt:  now2,
 				EndsAt:    now4,
 				UpdatedAt: now4,
+				History:   []*pb.Edit{edit3, edit4},
 			},
 			ExpiresAt: now4.Add(s.retention),
 		},
@@ -329,6 +537,7 @@ func TestSilenceSet(t *testing.T) {
 				StartsAt:  now4,
 				EndsAt:    now3.Add(100 * time.Minute),
 				UpdatedAt: now4,
+				History:   []*pb.Edit{edit3, edit4},
 			},
 			ExpiresAt: now3.Add(100*time.Minute + s.retention),
 		},
@@ -343,10 +552,20 @@ func TestSilenceSet(t *testing.T) {
 	sil5.StartsAt = now
 	sil5.EndsAt = now.Add(5 * time.Minute)
 
-	id5, err := s.Set(sil5)
+	id5, err := s.Set(sil5, "")
 	require.NoError(t, err)
 	require.NotEqual(t, id2, id4)
 
+	edit5 := &pb.Edit{
+		Timestamp: now5,
+		Previous: &pb.Silence{
+			Id:        id2,
+			Matchers:  []*pb.Matcher{{Name: "a", Pattern: "b"}},
+			StartsAt:  now2,
+			EndsAt:    now4,
+			UpdatedAt: now4,
+		},
+	}
 	want = state{
 		id1: want[id1],
 		id2: want[id2],
@@ -358,6 +577,7 @@ func TestSilenceSet(t *testing.T) {
 				StartsAt:  now5,
 				EndsAt:    now5.Add(5 * time.Minute),
 				UpdatedAt: now5,
+				History:   []*pb.Edit{edit3, edit4, edit5},
 			},
 			ExpiresAt: now5.Add(5*time.Minute + s.retention),
 		},
@@ -365,6 +585,174 @@ func TestSilenceSet(t *testing.T) {
 	require.Equal(t, want, s.st, "unexpected state after silence creation")
 }
 
+func TestSilenceSetKeepsOwner(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.now = func() time.Time { return now }
+
+	id, err := s.Set(&pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+		Owner:    "alice",
+	}, "")
+	require.NoError(t, err)
+
+	// Updating in place.
+	now = now.Add(time.Minute)
+	upd := cloneSilence(s.st[id].Silence)
+	upd.EndsAt = now.Add(2 * time.Hour)
+	upd.Owner = "bob"
+	id2, err := s.Set(upd, "")
+	require.NoError(t, err)
+	require.Equal(t, id, id2)
+	require.Equal(t, "alice", s.st[id].Silence.Owner)
+
+	// Replacing with a new silence.
+	now = now.Add(time.Minute)
+	upd = cloneSilence(s.st[id].Silence)
+	upd.Matchers = []*pb.Matcher{{Name: "a", Pattern: "c"}}
+	upd.Owner = "bob"
+	id3, err := s.Set(upd, "")
+	require.NoError(t, err)
+	require.NotEqual(t, id, id3)
+	require.Equal(t, "alice", s.st[id3].Silence.Owner)
+}
+
+func TestSilenceSetHistoryLimit(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.now = func() time.Time { return now }
+
+	sil := &pb.Silence{
+		Matchers:  []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt:  now,
+		EndsAt:    now.Add(time.Hour),
+		CreatedBy: "bob",
+	}
+	id, err := s.Set(sil, "bob")
+	require.NoError(t, err)
+
+	endsAt := sil.EndsAt
+
+	// The given editor is recorded, not the creator of the silence.
+	for i := 1; i <= maxHistory+5; i++ {
+		now = now.Add(time.Second)
+		sil = cloneSilence(s.st[id].Silence)
+		sil.EndsAt = sil.EndsAt.Add(time.Minute)
+
+		_, err = s.Set(sil, "alice")
+		require.NoError(t, err)
+	}
+
+	history := s.st[id].Silence.History
+	require.Len(t, history, maxHistory)
+	require.Equal(t, "alice", history[len(history)-1].Editor)
+	require.Equal(t, endsAt.Add((maxHistory+4)*time.Minute), history[len(history)-1].Previous.EndsAt)
+	require.Nil(t, history[len(history)-1].Previous.History)
+}
+
+func TestSilencesSetAll(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.now = func() time.Time { return now }
+
+	newSilence := func(v string) *pb.Silence {
+		return &pb.Silence{
+			Matchers: []*pb.Matcher{{Name: "a", Pattern: v}},
+			StartsAt: now,
+			EndsAt:   now.Add(time.Hour),
+		}
+	}
+
+	// A single invalid silence must prevent all silences from being set.
+	invalid := newSilence("c")
+	invalid.EndsAt = now.Add(-time.Hour)
+
+	_, err = s.SetAll([]*pb.Silence{newSilence("a"), newSilence("b"), invalid}, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "silence 2")
+	require.Len(t, s.st, 0)
+
+	_, err = s.SetAll([]*pb.Silence{newSilence("a"), {Id: "unknown"}}, "")
+	require.Equal(t, ErrNotFound, errors.Cause(err))
+	require.Len(t, s.st, 0)
+
+	ids, err := s.SetAll([]*pb.Silence{newSilence("a"), newSilence("b")}, "")
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	require.Len(t, s.st, 2)
+
+	// Updates and new silences can be mixed.
+	now = now.Add(time.Minute)
+	upd := cloneSilence(s.st[ids[0]].Silence)
+	upd.EndsAt = now.Add(2 * time.Hour)
+
+	ids2, err := s.SetAll([]*pb.Silence{upd, newSilence("c")}, "")
+	require.NoError(t, err)
+	require.Equal(t, ids[0], ids2[0])
+	require.Len(t, s.st, 3)
+
+	_, err = s.SetAll([]*pb.Silence{upd, upd}, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "duplicate")
+}
+
+func TestSilencesExpireAll(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.now = func() time.Time { return now }
+
+	m := &pb.Matcher{Type: pb.Matcher_EQUAL, Name: "a", Pattern: "b"}
+
+	s.st = state{
+		"active": &pb.MeshSilence{Silence: &pb.Silence{
+			Id:        "active",
+			Matchers:  []*pb.Matcher{m},
+			StartsAt:  now.Add(-time.Minute),
+			EndsAt:    now.Add(time.Hour),
+			UpdatedAt: now.Add(-time.Hour),
+		}},
+		"pending": &pb.MeshSilence{Silence: &pb.Silence{
+			Id:        "pending",
+			Matchers:  []*pb.Matcher{m},
+			StartsAt:  now.Add(time.Minute),
+			EndsAt:    now.Add(time.Hour),
+			UpdatedAt: now.Add(-time.Hour),
+		}},
+		"expired": &pb.MeshSilence{Silence: &pb.Silence{
+			Id:        "expired",
+			Matchers:  []*pb.Matcher{m},
+			StartsAt:  now.Add(-time.Hour),
+			EndsAt:    now.Add(-time.Minute),
+			UpdatedAt: now.Add(-time.Hour),
+		}},
+	}
+
+	err = s.ExpireAll([]string{"active", "expired"}, "")
+	require.Error(t, err)
+	require.Equal(t, types.SilenceStateActive, State(s.st["active"].Silence, now))
+
+	err = s.ExpireAll([]string{"active", "unknown"}, "")
+	require.Equal(t, ErrNotFound, errors.Cause(err))
+	require.Equal(t, types.SilenceStateActive, State(s.st["active"].Silence, now))
+
+	now = now.Add(time.Second)
+	require.NoError(t, s.ExpireAll([]string{"active", "pending", "active"}, "alice"))
+	require.Equal(t, types.SilenceStateExpired, State(s.st["active"].Silence, now.Add(time.Nanosecond)))
+	require.Equal(t, types.SilenceStateExpired, State(s.st["pending"].Silence, now.Add(time.Nanosecond)))
+	require.Equal(t, "alice", s.st["active"].Silence.History[0].Editor)
+	require.Equal(t, "alice", s.st["pending"].Silence.History[0].Editor)
+}
+
 func TestSilencesSetFail(t *testing.T) {
 	s, err := New(Options{})
 	require.NoError(t, err)
@@ -385,7 +773,7 @@ func TestSilencesSetFail(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		_, err := s.Set(c.s)
+		_, err := s.Set(c.s, "")
 		if err == nil {
 			if c.err != "" {
 				t.Errorf("expected error containing %q but got none", c.err)
@@ -434,13 +822,37 @@ func TestQState(t *testing.T) {
 			states: []types.SilenceState{types.SilenceStateExpired, types.SilenceStatePending},
 			keep:   true,
 		},
+		{
+			sil: &pb.Silence{
+				StartsAt: now.Add(-time.Hour),
+				EndsAt:   now.Add(time.Hour),
+				Recurrence: &pb.Recurrence{
+					Schedule: fmt.Sprintf("%d %d * * *", now.Add(-10*time.Minute).Minute(), now.Add(-10*time.Minute).Hour()),
+					Duration: 30 * time.Minute,
+				},
+			},
+			states: []types.SilenceState{types.SilenceStateActive},
+			keep:   true,
+		},
+		{
+			sil: &pb.Silence{
+				StartsAt: now.Add(-time.Hour),
+				EndsAt:   now.Add(time.Hour),
+				Recurrence: &pb.Recurrence{
+					Schedule: fmt.Sprintf("%d %d * * *", now.Add(-40*time.Minute).Minute(), now.Add(-40*time.Minute).Hour()),
+					Duration: 30 * time.Minute,
+				},
+			},
+			states: []types.SilenceState{types.SilenceStatePending},
+			keep:   true,
+		},
 	}
 	for i, c := range cases {
 		q := &query{}
 		QState(c.states...)(q)
 		f := q.filters[0]
 
-		keep, err := f(c.sil, nil, now)
+		keep, err := f(c.sil, &Silences{rc: recurrenceCache{}}, now)
 		require.NoError(t, err)
 		require.Equal(t, c.keep, keep, "unexpected filter result for case %d", i)
 	}
@@ -496,6 +908,39 @@ func TestQMatches(t *testing.T) {
 			},
 			drop: false,
 		},
+		{
+			sil: &pb.Silence{
+				Matchers: []*pb.Matcher{
+					{Name: "job", Pattern: "test", Type: pb.Matcher_EQUAL},
+					{Name: "method", Pattern: "POST", Type: pb.Matcher_NOT_EQUAL},
+				},
+			},
+			drop: true,
+		},
+		{
+			sil: &pb.Silence{
+				Matchers: []*pb.Matcher{
+					{Name: "job", Pattern: "test", Type: pb.Matcher_NOT_EQUAL},
+				},
+			},
+			drop: false,
+		},
+		{
+			sil: &pb.Silence{
+				Matchers: []*pb.Matcher{
+					{Name: "path", Pattern: "/nothing/.+", Type: pb.Matcher_NOT_REGEXP},
+				},
+			},
+			drop: true,
+		},
+		{
+			sil: &pb.Silence{
+				Matchers: []*pb.Matcher{
+					{Name: "path", Pattern: "/user/.+", Type: pb.Matcher_NOT_REGEXP},
+				},
+			},
+			drop: false,
+		},
 	}
 	for _, c := range cases {
 		drop, err := f(c.sil, &Silences{mc: matcherCache{}, st: state{}}, time.Time{})
@@ -504,6 +949,57 @@ func TestQMatches(t *testing.T) {
 	}
 }
 
+func TestSilencesPreviewUpdate(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.st = state{
+		"1": &pb.MeshSilence{Silence: &pb.Silence{
+			Id:        "1",
+			Matchers:  []*pb.Matcher{{Type: pb.Matcher_EQUAL, Name: "job", Pattern: "api"}},
+			StartsAt:  now.Add(-time.Minute),
+			EndsAt:    now.Add(time.Hour),
+			UpdatedAt: now.Add(-time.Hour),
+		}},
+	}
+
+	upd := &pb.Silence{
+		Id:       "1",
+		Matchers: []*pb.Matcher{{Type: pb.Matcher_REGEXP, Name: "job", Pattern: "api|db"}, {Type: pb.Matcher_NOT_EQUAL, Name: "env", Pattern: "prod"}},
+		StartsAt: now.Add(-time.Minute),
+		EndsAt:   now.Add(time.Hour),
+	}
+	silenced, err := s.PreviewUpdate(upd, now)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		lset          model.LabelSet
+		before, after bool
+	}{
+		{lset: model.LabelSet{"job": "api"}, before: true, after: true},
+		{lset: model.LabelSet{"job": "api", "env": "prod"}, before: true, after: false},
+		{lset: model.LabelSet{"job": "db"}, before: false, after: true},
+		{lset: model.LabelSet{"job": "web"}, before: false, after: false},
+	} {
+		before, after := silenced(tc.lset)
+		require.Equal(t, tc.before, before, "%v", tc.lset)
+		require.Equal(t, tc.after, after, "%v", tc.lset)
+	}
+
+	// Moving the silence into the future stops silencing all alerts.
+	upd.StartsAt = now.Add(time.Minute)
+	silenced, err = s.PreviewUpdate(upd, now)
+	require.NoError(t, err)
+	before, after := silenced(model.LabelSet{"job": "api"})
+	require.True(t, before)
+	require.False(t, after)
+
+	upd.Id = "2"
+	_, err = s.PreviewUpdate(upd, now)
+	require.Equal(t, ErrNotFound, err)
+}
+
 func TestSilencesQuery(t *testing.T) {
 	s, err := New(Options{})
 	require.NoError(t, err)
@@ -714,6 +1210,118 @@ func TestSilenceCanUpdate(t *testing.T) {
 	}
 }
 
+func TestSilenceExtend(t *testing.T) {
+	s, err := New(Options{Retention: time.Hour})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.now = func() time.Time { return now }
+
+	id, err := s.Set(&pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+	}, "")
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, s.Extend(id, 2*time.Hour, "alice"))
+
+	sil := s.st[id]
+	require.Equal(t, now.Add(-time.Minute).Add(3*time.Hour), sil.Silence.EndsAt)
+	require.Equal(t, sil.Silence.EndsAt.Add(time.Hour), sil.ExpiresAt)
+	require.Len(t, sil.Silence.History, 1)
+	require.Equal(t, "alice", sil.Silence.History[0].Editor)
+	require.Equal(t, now, sil.Silence.History[0].Timestamp)
+
+	require.Error(t, s.Extend(id, 0, "alice"))
+	require.Equal(t, ErrNotFound, s.Extend("unknown", time.Hour, "alice"))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, s.Expire(id, ""))
+
+	now = now.Add(time.Minute)
+	err = s.Extend(id, time.Hour, "alice")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already expired")
+}
+
+func TestSilenceDurationPolicies(t *testing.T) {
+	s, err := New(Options{DefaultDuration: time.Hour, MaxDuration: 2 * time.Hour})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s.now = func() time.Time { return now }
+
+	// Silences without end time get the default duration.
+	id, err := s.Set(&pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now.Add(-time.Minute),
+	}, "")
+	require.NoError(t, err)
+	require.Equal(t, now.Add(time.Hour), s.st[id].Silence.EndsAt)
+
+	// Silences longer than the maximum are rejected.
+	_, err = s.Set(&pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now.Add(time.Hour),
+		EndsAt:   now.Add(4 * time.Hour),
+	}, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds the maximum")
+
+	_, err = s.SetAll([]*pb.Silence{{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now,
+		EndsAt:   now.Add(3 * time.Hour),
+	}}, "")
+	require.Error(t, err)
+
+	// Extensions count towards the maximum as well.
+	now = now.Add(time.Minute)
+	require.NoError(t, s.Extend(id, 30*time.Minute, ""))
+	require.Error(t, s.Extend(id, time.Hour, ""))
+	require.Equal(t, now.Add(89*time.Minute), s.st[id].Silence.EndsAt)
+}
+
+func TestSilenceDelete(t *testing.T) {
+	s1, err := New(Options{Retention: time.Hour})
+	require.NoError(t, err)
+	s2, err := New(Options{Retention: time.Hour})
+	require.NoError(t, err)
+
+	now := utcNow()
+	s1.now = func() time.Time { return now }
+	s2.now = func() time.Time { return now }
+
+	// Replicate all changes of s1 to s2.
+	s1.SetBroadcast(func(b []byte) {
+		require.NoError(t, s2.Merge(b))
+	})
+
+	id, err := s1.Set(&pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+	}, "")
+	require.NoError(t, err)
+	require.Contains(t, s2.st, id)
+
+	now = now.Add(time.Minute)
+	err = s1.Delete(id)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be expired")
+
+	require.NoError(t, s1.Expire(id, ""))
+	now = now.Add(time.Minute)
+	t.Logf("%+v %v", s1.st[id].Silence, now)
+	require.NoError(t, s1.Delete(id))
+
+	require.NotContains(t, s1.st, id)
+	require.NotContains(t, s2.st, id)
+	require.Equal(t, ErrNotFound, s1.Delete(id))
+}
+
 func TestSilenceExpire(t *testing.T) {
 	s, err := New(Options{})
 	require.NoError(t, err)
@@ -751,10 +1359,13 @@ func TestSilenceExpire(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, count)
 
-	require.NoError(t, s.expire("pending"))
-	require.NoError(t, s.expire("active"))
+	pending := s.st["pending"].Silence
+	active := s.st["active"].Silence
 
-	err = s.expire("expired")
+	require.NoError(t, s.expire("pending", ""))
+	require.NoError(t, s.expire("active", ""))
+
+	err = s.expire("expired", "")
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "already expired")
 
@@ -766,6 +1377,7 @@ func TestSilenceExpire(t *testing.T) {
 		StartsAt:  now,
 		EndsAt:    now,
 		UpdatedAt: now,
+		History:   []*pb.Edit{{Timestamp: now, Previous: pending}},
 	}, sil)
 
 	count, err = s.CountState(types.SilenceStatePending)
@@ -785,6 +1397,7 @@ func TestSilenceExpire(t *testing.T) {
 		StartsAt:  now.Add(-time.Minute),
 		EndsAt:    now,
 		UpdatedAt: now,
+		History:   []*pb.Edit{{Timestamp: now, Previous: active}},
 	}, sil)
 
 	sil, err = s.QueryOne(QIDs("expired"))
@@ -824,6 +1437,20 @@ func TestValidateMatcher(t *testing.T) {
 				Type:    pb.Matcher_REGEXP,
 			},
 			err: "invalid regular expression",
+		}, {
+			m: &pb.Matcher{
+				Name:    "a",
+				Pattern: "b",
+				Type:    pb.Matcher_NOT_EQUAL,
+			},
+			err: "",
+		}, {
+			m: &pb.Matcher{
+				Name:    "a",
+				Pattern: "((",
+				Type:    pb.Matcher_NOT_REGEXP,
+			},
+			err: "invalid regular expression",
 		}, {
 			m: &pb.Matcher{
 				Name:    "a",
@@ -964,6 +1591,80 @@ func TestValidateSilence(t *testing.T) {
 			},
 			err: "invalid zero update timestamp",
 		},
+		{
+			s: &pb.Silence{
+				Id: "some_id",
+				Matchers: []*pb.Matcher{
+					&pb.Matcher{Name: "a", Pattern: "b"},
+				},
+				StartsAt:  validTimestamp,
+				EndsAt:    validTimestamp,
+				UpdatedAt: validTimestamp,
+				Recurrence: &pb.Recurrence{
+					Schedule: "0 2 * *",
+					Duration: time.Hour,
+				},
+			},
+			err: "invalid schedule",
+		},
+		{
+			s: &pb.Silence{
+				Id: "some_id",
+				Matchers: []*pb.Matcher{
+					&pb.Matcher{Name: "a", Pattern: "b"},
+				},
+				StartsAt:  validTimestamp,
+				EndsAt:    validTimestamp,
+				UpdatedAt: validTimestamp,
+				Recurrence: &pb.Recurrence{
+					Schedule: "0 2 * * *",
+				},
+			},
+			err: "duration must be positive",
+		},
+		{
+			s: &pb.Silence{
+				Id: "some_id",
+				Matchers: []*pb.Matcher{
+					&pb.Matcher{Name: "a", Pattern: "b"},
+				},
+				StartsAt:  validTimestamp,
+				EndsAt:    validTimestamp,
+				UpdatedAt: validTimestamp,
+				Recurrence: &pb.Recurrence{
+					Schedule: "0 2 * * *",
+					Duration: time.Hour,
+					Timezone: "Mars/Olympus_Mons",
+				},
+			},
+			err: "invalid timezone",
+		},
+		{
+			s: &pb.Silence{
+				Id: "some_id",
+				Matchers: []*pb.Matcher{
+					&pb.Matcher{Name: "a", Pattern: "b"},
+				},
+				StartsAt:  validTimestamp,
+				EndsAt:    validTimestamp,
+				UpdatedAt: validTimestamp,
+				TicketUrl: "https://tickets.example.com/INC-42",
+			},
+			err: "",
+		},
+		{
+			s: &pb.Silence{
+				Id: "some_id",
+				Matchers: []*pb.Matcher{
+					&pb.Matcher{Name: "a", Pattern: "b"},
+				},
+				StartsAt:  validTimestamp,
+				EndsAt:    validTimestamp,
+				UpdatedAt: validTimestamp,
+				TicketUrl: "INC-42",
+			},
+			err: "invalid ticket URL",
+		},
 	}
 	for _, c := range cases {
 		err := validateSilence(c.s)
@@ -1061,6 +1762,23 @@ func TestStateCoding(t *testing.T) {
 					},
 					ExpiresAt: now.Add(24 * time.Hour),
 				},
+				{
+					Silence: &pb.Silence{
+						Id: "0f2d4b6e-61b8-4fd3-a9be-4f4d5a1b6c2e",
+						Matchers: []*pb.Matcher{
+							{Name: "job", Pattern: "batch", Type: pb.Matcher_EQUAL},
+						},
+						StartsAt:  now,
+						EndsAt:    now.Add(24 * time.Hour),
+						UpdatedAt: now,
+						Recurrence: &pb.Recurrence{
+							Schedule: "0 2 * * *",
+							Duration: 30 * time.Minute,
+							Timezone: "Europe/Berlin",
+						},
+					},
+					ExpiresAt: now.Add(48 * time.Hour),
+				},
 			},
 		},
 	}
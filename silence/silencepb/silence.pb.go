@@ -12,6 +12,8 @@
 		Comment
 		Silence
 		MeshSilence
+		Recurrence
+		Edit
 */
 package silencepb
 
@@ -44,17 +46,23 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type Matcher_Type int32
 
 const (
-	Matcher_EQUAL  Matcher_Type = 0
-	Matcher_REGEXP Matcher_Type = 1
+	Matcher_EQUAL      Matcher_Type = 0
+	Matcher_REGEXP     Matcher_Type = 1
+	Matcher_NOT_EQUAL  Matcher_Type = 2
+	Matcher_NOT_REGEXP Matcher_Type = 3
 )
 
 var Matcher_Type_name = map[int32]string{
 	0: "EQUAL",
 	1: "REGEXP",
+	2: "NOT_EQUAL",
+	3: "NOT_REGEXP",
 }
 var Matcher_Type_value = map[string]int32{
-	"EQUAL":  0,
-	"REGEXP": 1,
+	"EQUAL":      0,
+	"REGEXP":     1,
+	"NOT_EQUAL":  2,
+	"NOT_REGEXP": 3,
 }
 
 func (x Matcher_Type) String() string {
@@ -107,6 +115,17 @@ type Silence struct {
 	// Comment for the silence.
 	CreatedBy string `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
 	Comment   string `protobuf:"bytes,9,opt,name=comment,proto3" json:"comment,omitempty"`
+	// An optional recurrence restricting the silence to repeating
+	// windows within its time range.
+	Recurrence *Recurrence `protobuf:"bytes,10,opt,name=recurrence" json:"recurrence,omitempty"`
+	// The modifications made to the silence, oldest first.
+	History []*Edit `protobuf:"bytes,11,rep,name=history" json:"history,omitempty"`
+	// The authenticated user who created the silence. Only the owner
+	// may modify the silence if ownership is enforced.
+	Owner string `protobuf:"bytes,12,opt,name=owner,proto3" json:"owner,omitempty"`
+	// URL of the ticket justifying the silence, e.g. an incident or
+	// change request.
+	TicketUrl string `protobuf:"bytes,13,opt,name=ticket_url,json=ticketUrl,proto3" json:"ticket_url,omitempty"`
 }
 
 func (m *Silence) Reset()                    { *m = Silence{} }
@@ -126,11 +145,42 @@ func (m *MeshSilence) String() string            { return proto.CompactTextStrin
 func (*MeshSilence) ProtoMessage()               {}
 func (*MeshSilence) Descriptor() ([]byte, []int) { return fileDescriptorSilence, []int{3} }
 
+// Recurrence defines repeating time windows in which a silence is in effect.
+type Recurrence struct {
+	// A cron expression in the standard five-field format defining the
+	// start of each window.
+	Schedule string `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// The length of each window.
+	Duration time.Duration `protobuf:"bytes,2,opt,name=duration,stdduration" json:"duration"`
+	// The IANA time zone the schedule is evaluated in. Defaults to UTC.
+	Timezone string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+}
+
+func (m *Recurrence) Reset()                    { *m = Recurrence{} }
+func (m *Recurrence) String() string            { return proto.CompactTextString(m) }
+func (*Recurrence) ProtoMessage()               {}
+func (*Recurrence) Descriptor() ([]byte, []int) { return fileDescriptorSilence, []int{4} }
+
+// Edit records a modification of a silence.
+type Edit struct {
+	Timestamp time.Time `protobuf:"bytes,1,opt,name=timestamp,stdtime" json:"timestamp"`
+	Editor    string    `protobuf:"bytes,2,opt,name=editor,proto3" json:"editor,omitempty"`
+	// The silence as it was before the modification, without its history.
+	Previous *Silence `protobuf:"bytes,3,opt,name=previous" json:"previous,omitempty"`
+}
+
+func (m *Edit) Reset()                    { *m = Edit{} }
+func (m *Edit) String() string            { return proto.CompactTextString(m) }
+func (*Edit) ProtoMessage()               {}
+func (*Edit) Descriptor() ([]byte, []int) { return fileDescriptorSilence, []int{5} }
+
 func init() {
 	proto.RegisterType((*Matcher)(nil), "silencepb.Matcher")
 	proto.RegisterType((*Comment)(nil), "silencepb.Comment")
 	proto.RegisterType((*Silence)(nil), "silencepb.Silence")
 	proto.RegisterType((*MeshSilence)(nil), "silencepb.MeshSilence")
+	proto.RegisterType((*Recurrence)(nil), "silencepb.Recurrence")
+	proto.RegisterType((*Edit)(nil), "silencepb.Edit")
 	proto.RegisterEnum("silencepb.Matcher_Type", Matcher_Type_name, Matcher_Type_value)
 }
 func (m *Matcher) Marshal() (dAtA []byte, err error) {
@@ -287,6 +337,40 @@ func (m *Silence) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintSilence(dAtA, i, uint64(len(m.Comment)))
 		i += copy(dAtA[i:], m.Comment)
 	}
+	if m.Recurrence != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintSilence(dAtA, i, uint64(m.Recurrence.Size()))
+		n5, err := m.Recurrence.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if len(m.History) > 0 {
+		for _, msg := range m.History {
+			dAtA[i] = 0x5a
+			i++
+			i = encodeVarintSilence(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Owner) > 0 {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintSilence(dAtA, i, uint64(len(m.Owner)))
+		i += copy(dAtA[i:], m.Owner)
+	}
+	if len(m.TicketUrl) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintSilence(dAtA, i, uint64(len(m.TicketUrl)))
+		i += copy(dAtA[i:], m.TicketUrl)
+	}
 	return i, nil
 }
 
@@ -309,20 +393,100 @@ func (m *MeshSilence) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSilence(dAtA, i, uint64(m.Silence.Size()))
-		n5, err := m.Silence.MarshalTo(dAtA[i:])
+		n6, err := m.Silence.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n6
 	}
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintSilence(dAtA, i, uint64(types.SizeOfStdTime(m.ExpiresAt)))
-	n6, err := types.StdTimeMarshalTo(m.ExpiresAt, dAtA[i:])
+	n7, err := types.StdTimeMarshalTo(m.ExpiresAt, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n7
+	return i, nil
+}
+
+func (m *Recurrence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Recurrence) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Schedule) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSilence(dAtA, i, uint64(len(m.Schedule)))
+		i += copy(dAtA[i:], m.Schedule)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintSilence(dAtA, i, uint64(types.SizeOfStdDuration(m.Duration)))
+	n8, err := types.StdDurationMarshalTo(m.Duration, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n8
+	if len(m.Timezone) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSilence(dAtA, i, uint64(len(m.Timezone)))
+		i += copy(dAtA[i:], m.Timezone)
+	}
+	return i, nil
+}
+
+func (m *Edit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Edit) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintSilence(dAtA, i, uint64(types.SizeOfStdTime(m.Timestamp)))
+	n9, err := types.StdTimeMarshalTo(m.Timestamp, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n9
+	if len(m.Editor) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSilence(dAtA, i, uint64(len(m.Editor)))
+		i += copy(dAtA[i:], m.Editor)
+	}
+	if m.Previous != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSilence(dAtA, i, uint64(m.Previous.Size()))
+		n10, err := m.Previous.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
 	return i, nil
 }
 
@@ -401,6 +565,24 @@ func (m *Silence) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovSilence(uint64(l))
 	}
+	if m.Recurrence != nil {
+		l = m.Recurrence.Size()
+		n += 1 + l + sovSilence(uint64(l))
+	}
+	if len(m.History) > 0 {
+		for _, e := range m.History {
+			l = e.Size()
+			n += 1 + l + sovSilence(uint64(l))
+		}
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovSilence(uint64(l))
+	}
+	l = len(m.TicketUrl)
+	if l > 0 {
+		n += 1 + l + sovSilence(uint64(l))
+	}
 	return n
 }
 
@@ -416,6 +598,38 @@ func (m *MeshSilence) Size() (n int) {
 	return n
 }
 
+func (m *Recurrence) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Schedule)
+	if l > 0 {
+		n += 1 + l + sovSilence(uint64(l))
+	}
+	l = types.SizeOfStdDuration(m.Duration)
+	n += 1 + l + sovSilence(uint64(l))
+	l = len(m.Timezone)
+	if l > 0 {
+		n += 1 + l + sovSilence(uint64(l))
+	}
+	return n
+}
+
+func (m *Edit) Size() (n int) {
+	var l int
+	_ = l
+	l = types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovSilence(uint64(l))
+	l = len(m.Editor)
+	if l > 0 {
+		n += 1 + l + sovSilence(uint64(l))
+	}
+	if m.Previous != nil {
+		l = m.Previous.Size()
+		n += 1 + l + sovSilence(uint64(l))
+	}
+	return n
+}
+
 func sovSilence(x uint64) (n int) {
 	for {
 		n++
@@ -962,6 +1176,128 @@ func (m *Silence) Unmarshal(dAtA []byte) error {
 			}
 			m.Comment = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recurrence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Recurrence == nil {
+				m.Recurrence = &Recurrence{}
+			}
+			if err := m.Recurrence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field History", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.History = append(m.History, &Edit{})
+			if err := m.History[len(m.History)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TicketUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TicketUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSilence(dAtA[iNdEx:])
@@ -1096,6 +1432,286 @@ func (m *MeshSilence) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Recurrence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSilence
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Recurrence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Recurrence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schedule", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Schedule = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := types.StdDurationUnmarshal(&m.Duration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timezone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timezone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSilence(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSilence
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Edit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSilence
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Edit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Edit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Editor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Editor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Previous", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSilence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Previous == nil {
+				m.Previous = &Silence{}
+			}
+			if err := m.Previous.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSilence(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSilence
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipSilence(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1204,33 +1820,45 @@ var (
 func init() { proto.RegisterFile("silence.proto", fileDescriptorSilence) }
 
 var fileDescriptorSilence = []byte{
-	// 444 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x51, 0x4d, 0x6b, 0xdb, 0x40,
-	0x10, 0xf5, 0x2a, 0x8e, 0x65, 0x8d, 0x69, 0x30, 0x43, 0x69, 0x85, 0x21, 0xb6, 0xd1, 0xc9, 0xd0,
-	0x22, 0x83, 0x7b, 0xee, 0x41, 0x0e, 0xa6, 0x97, 0x06, 0x5a, 0x35, 0x85, 0xde, 0xca, 0xda, 0x9a,
-	0xda, 0x82, 0x48, 0xbb, 0x48, 0x63, 0xa8, 0x4f, 0x2d, 0xf4, 0x0f, 0xf4, 0x67, 0xf9, 0xd8, 0x5f,
-	0xd0, 0x0f, 0xff, 0x8b, 0xde, 0x8a, 0x56, 0x2b, 0x37, 0x21, 0x27, 0xdf, 0x66, 0x66, 0xdf, 0x9b,
-	0xb7, 0xef, 0x0d, 0x3c, 0x2a, 0xd3, 0x5b, 0xca, 0x57, 0x14, 0xea, 0x42, 0xb1, 0x42, 0xcf, 0xb6,
-	0x7a, 0x39, 0x18, 0xad, 0x95, 0x5a, 0xdf, 0xd2, 0xd4, 0x3c, 0x2c, 0xb7, 0x9f, 0xa6, 0x9c, 0x66,
-	0x54, 0xb2, 0xcc, 0x74, 0x8d, 0x1d, 0x3c, 0x5e, 0xab, 0xb5, 0x32, 0xe5, 0xb4, 0xaa, 0xea, 0x69,
-	0xf0, 0x4d, 0x80, 0x7b, 0x2d, 0x79, 0xb5, 0xa1, 0x02, 0x9f, 0x41, 0x9b, 0x77, 0x9a, 0x7c, 0x31,
-	0x16, 0x93, 0x8b, 0xd9, 0xd3, 0xf0, 0xb8, 0x3c, 0xb4, 0x88, 0xf0, 0x66, 0xa7, 0x29, 0x36, 0x20,
-	0x44, 0x68, 0xe7, 0x32, 0x23, 0xdf, 0x19, 0x8b, 0x89, 0x17, 0x9b, 0x1a, 0x7d, 0x70, 0xb5, 0x64,
-	0xa6, 0x22, 0xf7, 0xcf, 0xcc, 0xb8, 0x69, 0x83, 0x4b, 0x68, 0x57, 0x5c, 0xf4, 0xe0, 0x7c, 0xf1,
-	0xf6, 0x7d, 0xf4, 0xba, 0xdf, 0x42, 0x80, 0x4e, 0xbc, 0x78, 0xb5, 0xf8, 0xf0, 0xa6, 0x2f, 0x82,
-	0x2f, 0xe0, 0x5e, 0xa9, 0x2c, 0xa3, 0x9c, 0xf1, 0x09, 0x74, 0xe4, 0x96, 0x37, 0xaa, 0x30, 0xdf,
-	0xf0, 0x62, 0xdb, 0x55, 0xbb, 0x57, 0x35, 0xc4, 0x4a, 0x36, 0x2d, 0xce, 0xc1, 0x3b, 0x7a, 0x35,
-	0xba, 0xbd, 0xd9, 0x20, 0xac, 0xd3, 0x08, 0x9b, 0x34, 0xc2, 0x9b, 0x06, 0x31, 0xef, 0xee, 0x7f,
-	0x8e, 0x5a, 0xdf, 0x7f, 0x8d, 0x44, 0xfc, 0x9f, 0x16, 0xfc, 0x75, 0xc0, 0x7d, 0x57, 0xdb, 0xc5,
-	0x0b, 0x70, 0xd2, 0xc4, 0xaa, 0x3b, 0x69, 0x82, 0x21, 0x74, 0xb3, 0xda, 0x7f, 0xe9, 0x3b, 0xe3,
-	0xb3, 0x49, 0x6f, 0x86, 0x0f, 0xa3, 0x89, 0x8f, 0x18, 0x8c, 0xc0, 0x2b, 0x59, 0x16, 0x5c, 0x7e,
-	0x94, 0x7c, 0xd2, 0x7f, 0xba, 0x35, 0x2d, 0x62, 0x7c, 0x09, 0x2e, 0xe5, 0x89, 0x59, 0xd0, 0x3e,
-	0x61, 0x41, 0xa7, 0x22, 0x45, 0x8c, 0x57, 0x00, 0x5b, 0x9d, 0x48, 0xa6, 0xa4, 0xda, 0x70, 0x7e,
-	0x4a, 0x24, 0x96, 0x17, 0x71, 0x65, 0xdb, 0x26, 0x5c, 0xfa, 0xee, 0x03, 0xdb, 0xf6, 0x5c, 0xf1,
-	0x11, 0x83, 0x97, 0x00, 0xab, 0x82, 0x8c, 0xe8, 0x72, 0xe7, 0x77, 0x4d, 0x7c, 0x9e, 0x9d, 0xcc,
-	0x77, 0x77, 0xef, 0xe7, 0xdd, 0xbb, 0x5f, 0xf0, 0x55, 0x40, 0xef, 0x9a, 0xca, 0x4d, 0x93, 0xff,
-	0x73, 0x70, 0xad, 0x8e, 0x39, 0xc2, 0x7d, 0x5d, 0x0b, 0x8a, 0x1b, 0x48, 0xe5, 0x95, 0x3e, 0xeb,
-	0xb4, 0x20, 0x93, 0x96, 0x73, 0x8a, 0x57, 0xcb, 0x8b, 0x78, 0xde, 0xdf, 0xff, 0x19, 0xb6, 0xf6,
-	0x87, 0xa1, 0xf8, 0x71, 0x18, 0x8a, 0xdf, 0x87, 0xa1, 0x58, 0x76, 0x0c, 0xf5, 0xc5, 0xbf, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xde, 0x36, 0xea, 0xdd, 0x71, 0x03, 0x00, 0x00,
+	// 632 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x4d, 0x6f, 0xd3, 0x4c,
+	0x10, 0xee, 0x26, 0x69, 0x1c, 0x4f, 0xde, 0xf6, 0xad, 0x56, 0x05, 0x96, 0x48, 0xa4, 0x95, 0x4f,
+	0x45, 0x20, 0x57, 0x2a, 0xe2, 0x06, 0x42, 0x49, 0x89, 0xb8, 0x50, 0x3e, 0x4c, 0x2b, 0x71, 0xab,
+	0x1c, 0x7b, 0x48, 0x2c, 0x62, 0xaf, 0xb5, 0x5e, 0x03, 0xe1, 0x02, 0x17, 0xee, 0x15, 0x27, 0xce,
+	0xfc, 0x9a, 0x1e, 0xf9, 0x05, 0x7c, 0xf4, 0x97, 0xa0, 0xfd, 0xb0, 0x9b, 0x52, 0x71, 0x08, 0x37,
+	0xcf, 0xce, 0x33, 0xf3, 0x3c, 0xfb, 0xcc, 0x8e, 0x61, 0xad, 0x48, 0x66, 0x98, 0x45, 0xe8, 0xe7,
+	0x82, 0x4b, 0x4e, 0x5d, 0x1b, 0xe6, 0xe3, 0xde, 0xd6, 0x84, 0xf3, 0xc9, 0x0c, 0x77, 0x75, 0x62,
+	0x5c, 0xbe, 0xda, 0x95, 0x49, 0x8a, 0x85, 0x0c, 0xd3, 0xdc, 0x60, 0x7b, 0xfd, 0x3f, 0x01, 0x71,
+	0x29, 0x42, 0x99, 0xf0, 0xcc, 0xe6, 0x37, 0x27, 0x7c, 0xc2, 0xf5, 0xe7, 0xae, 0xfa, 0x32, 0xa7,
+	0xde, 0x57, 0x02, 0xce, 0x41, 0x28, 0xa3, 0x29, 0x0a, 0x7a, 0x0b, 0x5a, 0x72, 0x9e, 0x23, 0x23,
+	0xdb, 0x64, 0x67, 0x7d, 0xef, 0x9a, 0x5f, 0x93, 0xfb, 0x16, 0xe1, 0x1f, 0xce, 0x73, 0x0c, 0x34,
+	0x88, 0x52, 0x68, 0x65, 0x61, 0x8a, 0xac, 0xb1, 0x4d, 0x76, 0xdc, 0x40, 0x7f, 0x53, 0x06, 0x4e,
+	0x1e, 0x4a, 0x89, 0x22, 0x63, 0x4d, 0x7d, 0x5c, 0x85, 0xde, 0x3d, 0x68, 0xa9, 0x5a, 0xea, 0xc2,
+	0xea, 0xe8, 0xf9, 0xd1, 0xe0, 0xf1, 0xc6, 0x0a, 0x05, 0x68, 0x07, 0xa3, 0x47, 0xa3, 0x97, 0xcf,
+	0x36, 0x08, 0x5d, 0x03, 0xf7, 0xc9, 0xd3, 0xc3, 0x63, 0x93, 0x6a, 0xd0, 0x75, 0x00, 0x15, 0xda,
+	0x74, 0xd3, 0xfb, 0x00, 0xce, 0x3e, 0x4f, 0x53, 0xcc, 0x24, 0xbd, 0x0a, 0xed, 0xb0, 0x94, 0x53,
+	0x2e, 0xb4, 0x4a, 0x37, 0xb0, 0x91, 0xa2, 0x8e, 0x0c, 0xc4, 0x2a, 0xaa, 0x42, 0x3a, 0x04, 0xb7,
+	0xb6, 0x4a, 0xcb, 0xea, 0xee, 0xf5, 0x7c, 0xe3, 0x95, 0x5f, 0x79, 0xe5, 0x1f, 0x56, 0x88, 0x61,
+	0xe7, 0xf4, 0xfb, 0xd6, 0xca, 0xc9, 0x8f, 0x2d, 0x12, 0x9c, 0x97, 0x79, 0x27, 0x2d, 0x70, 0x5e,
+	0x18, 0x37, 0xe8, 0x3a, 0x34, 0x92, 0xd8, 0xb2, 0x37, 0x92, 0x98, 0xfa, 0xd0, 0x49, 0x8d, 0x3d,
+	0x05, 0x6b, 0x6c, 0x37, 0x77, 0xba, 0x7b, 0xf4, 0xb2, 0x73, 0x41, 0x8d, 0xa1, 0x03, 0x70, 0x0b,
+	0x19, 0x0a, 0x59, 0x1c, 0x87, 0x72, 0x29, 0x3d, 0x1d, 0x53, 0x36, 0x90, 0xf4, 0x3e, 0x38, 0x98,
+	0xc5, 0xba, 0x41, 0x6b, 0x89, 0x06, 0x6d, 0x55, 0x34, 0x90, 0x74, 0x1f, 0xa0, 0xcc, 0xe3, 0x50,
+	0x62, 0xac, 0x3a, 0xac, 0x2e, 0x63, 0x89, 0xad, 0x1b, 0x48, 0x75, 0x6d, 0xeb, 0x70, 0xc1, 0x9c,
+	0x4b, 0xd7, 0xb6, 0xe3, 0x0a, 0x6a, 0x0c, 0xbd, 0x01, 0x10, 0x09, 0xd4, 0xa4, 0xe3, 0x39, 0xeb,
+	0x68, 0xfb, 0x5c, 0x7b, 0x32, 0x9c, 0x2f, 0xce, 0xcf, 0xbd, 0x38, 0xbf, 0xbb, 0x00, 0x02, 0xa3,
+	0x52, 0x08, 0xd5, 0x9a, 0x81, 0x56, 0x7b, 0x65, 0x81, 0x2a, 0xa8, 0x93, 0xc1, 0x02, 0x90, 0xde,
+	0x04, 0x67, 0x9a, 0x14, 0x92, 0x8b, 0x39, 0xeb, 0x6a, 0x79, 0xff, 0x2f, 0xd4, 0x8c, 0xe2, 0x44,
+	0x06, 0x55, 0x9e, 0x6e, 0xc2, 0x2a, 0x7f, 0x9b, 0xa1, 0x60, 0xff, 0x69, 0x66, 0x13, 0x28, 0xc1,
+	0x32, 0x89, 0x5e, 0xa3, 0x3c, 0x2e, 0xc5, 0x8c, 0xad, 0x19, 0xc1, 0xe6, 0xe4, 0x48, 0xcc, 0xbc,
+	0x8f, 0x04, 0xba, 0x07, 0x58, 0x4c, 0xab, 0x67, 0x71, 0x1b, 0x1c, 0xdb, 0x5f, 0xbf, 0x8d, 0x8b,
+	0x76, 0x58, 0x50, 0x50, 0x41, 0xd4, 0x08, 0xf0, 0x5d, 0x9e, 0x08, 0xd4, 0x43, 0x6c, 0x2c, 0x33,
+	0x02, 0x5b, 0x37, 0x90, 0xde, 0x27, 0x02, 0x70, 0x7e, 0x7b, 0xda, 0x83, 0x4e, 0x11, 0x4d, 0x31,
+	0x2e, 0x67, 0x68, 0x9f, 0x67, 0x1d, 0xd3, 0x07, 0xd0, 0xa9, 0x7e, 0x07, 0x96, 0xed, 0xfa, 0x25,
+	0xb6, 0x87, 0x16, 0x60, 0xc8, 0xbe, 0xe8, 0x27, 0x57, 0x15, 0xa9, 0xe6, 0x6a, 0x1d, 0xde, 0xf3,
+	0x0c, 0xed, 0x6e, 0xd7, 0xb1, 0xf7, 0x99, 0x40, 0x4b, 0x39, 0x7a, 0x71, 0xd5, 0xc8, 0x3f, 0xad,
+	0x9a, 0x5a, 0x70, 0x8c, 0x13, 0xc9, 0x85, 0xdd, 0x63, 0x1b, 0xa9, 0xf7, 0x96, 0x0b, 0x7c, 0x93,
+	0xf0, 0xb2, 0x60, 0xcd, 0xbf, 0x1a, 0x5c, 0x63, 0x86, 0x1b, 0xa7, 0xbf, 0xfa, 0x2b, 0xa7, 0x67,
+	0x7d, 0xf2, 0xed, 0xac, 0x4f, 0x7e, 0x9e, 0xf5, 0xc9, 0xb8, 0xad, 0x25, 0xdc, 0xf9, 0x3d, 0x00,
+	0x7b, 0x39, 0xa3, 0x79, 0x64, 0x05, 0x00, 0x00,
 }
@@ -68,7 +68,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templateDefaultTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3b\xff\x6f\xdb\xb6\xb3\xbf\xeb\xaf\xb8\x69\x78\x58\x03\xd8\x96\xd3\x6e\xc5\xea\xd8\x79\x70\x1d\xa5\x11\x9e\x63\x07\xb6\xd2\xae\x18\x86\x80\x96\xce\x36\x5b\x89\xd4\x48\x3a\x89\x97\xf9\x7f\x7f\x20\x25\x7f\x91\x2d\x27\x4e\xd1\x25\xfe\x7c\x96\x04\x6d\x24\xea\xbe\xdf\xf1\xee\x28\x52\x77\x77\x10\xe2\x90\x32\x04\xfb\xea\x8a\x44\x28\x54\x4c\x18\x19\xa1\xb0\x61\x36\x6b\xea\xfb\xf3\xf4\xfe\xee\x0e\x90\x85\x30\x9b\x59\x5b\x51\x2e\x7b\x6d\x8d\x75\x77\x07\x15\xf7\x56\xa1\x60\x24\xba\xec\xb5\x61\x36\x73\x7e\x74\x0c\x9c\xfc\x5f\x81\x01\xd2\x6b\x14\x0d\x0d\xd4\xcb\x6e\x52\x9c\x8c\x7a\x9e\xbc\x9c\x0c\xbe\x60\xa0\x34\xd9\xdf\x35\x4a\x5f\x11\x35\x91\xf0\x37\x28\x7e\x99\x24\x73\x54\x3a\x04\xfc\x73\xf1\xd0\x1e\x52\x41\xd9\x48\xe3\xd4\x34\x8e\xd1\x42\x56\x4e\xcd\x28\xfc\x0d\x11\xb2\x55\x8e\x7f\x80\x06\xfa\x20\xf8\x24\x69\x93\x01\x46\xb2\xd2\xe7\x42\x61\x78\x41\xa8\x90\x95\x8f\x24\x9a\xa0\x66\xf8\x85\x53\x06\x36\x68\xaa\x90\xb2\x1c\x29\x78\xa5\x69\x55\x5a\x3c\x8e\x39\x4b\x91\x0f\xb2\xb1\x15\x7a\x07\x30\x9b\xbd\xba\xbb\x83\x1b\xaa\xc6\x79\xe0\x4a\x0f\x63\x7e\x8d\x79\xee\x1d\x12\xa3\xcc\xcc\x58\xc4\x7d\x21\xf8\xc1\xe2\x6a\x8b\x6f\x42\x94\x81\xa0\x89\xa2\x9c\xd9\xf7\xd8\x58\xe1\xad\x4a\xfd\x78\x15\x51\xa9\x32\x50\x41\xd8\x08\xa1\x02\xb3\x59\x2a\x57\xcd\x5a\x0e\x6e\xda\x49\x5b\xa5\x6c\x0c\xa9\xc5\xd7\x77\x0d\x58\x28\x90\x09\x96\x32\x6f\x32\xc6\x15\xd1\x32\xe5\x48\xae\x0c\x7f\x1b\xdd\x3e\x9f\x88\x00\x6b\xa9\x33\x91\xa1\x20\x8a\x8b\x34\xfc\xac\x02\x43\xe5\x6c\x20\x23\x12\x7c\xad\x84\x38\x24\x93\x48\x55\x14\x55\x11\x66\x56\x50\x18\x27\x11\x51\xf9\x58\xac\x6c\x33\x79\x9e\xce\x44\xea\x29\x10\x17\x91\xca\x4f\xb4\x1d\xe9\x0d\x49\x14\x0d\x48\xf0\x75\x83\x5e\xa1\xf8\x9a\x28\xfc\x0d\x0f\x01\x46\x94\x7d\xdd\x59\x82\x44\xa0\x0e\x16\x7b\x37\xe8\x15\xfa\xf7\x1a\xc0\xa4\x8d\x1d\x25\xa0\x01\x67\x18\xf3\x2f\x74\x47\x19\x34\xfc\x44\x44\xbb\x4a\xbc\xbb\x72\x43\xce\x55\x9a\x24\xb7\xc4\xd4\x98\x26\xc1\x98\xa8\x25\x82\xe0\xf1\xb7\x47\xc2\x3a\xb5\x18\xa5\x24\xa3\x47\x44\x69\x4e\xb6\x44\x73\x0b\x27\x6a\xba\xa0\xb7\x99\x2a\x1e\x17\xf9\x9b\x14\x83\x88\x22\x53\xdf\xae\xf1\x36\x8a\xcb\x22\xf3\x6d\xf1\xb4\x49\x97\x32\xa9\x08\x0b\x50\x16\xd0\xdd\xc8\x8d\xf7\x58\x95\x27\x72\x84\x8c\xe2\xb7\x3b\xe9\x3e\x62\x9b\x1e\xca\x4a\xc9\x96\xcc\x59\x58\x3b\xac\xb5\xca\x95\x2b\x8d\x07\x50\x85\xf2\x6c\x66\xa5\x83\x90\x0e\x9a\x1c\x7d\xbf\x45\xf2\xf5\xd5\x30\x29\xaf\x68\x54\xc0\xaf\x87\x92\x47\xd7\x18\xae\x71\x9c\x0f\xef\xce\x73\x8e\xb1\xc1\xb5\xbc\x8b\x49\xa5\x29\x19\x8f\x8f\xa6\x9c\xd7\x6f\xf0\x5b\x26\xa6\xf5\xe2\xbf\x7b\xfc\xd7\x5c\xb5\xbf\x88\x36\xe8\x15\xfa\x67\x8b\xd7\xd7\xfc\x43\x12\x7a\x25\x31\x10\xb8\x3d\xd1\xaf\x61\x28\x7e\xa5\x2b\xf9\x23\xc0\x13\x22\xd4\xf4\x11\xf0\x8a\x8c\x76\x85\x26\x23\x64\xea\x8a\x86\xeb\x85\x67\x15\xe5\x9a\x06\x8a\x0b\x9e\xc8\x65\xa0\x2b\xa2\xf0\x2a\x1f\x9a\x2f\xd1\xf7\xb8\xec\xb1\x69\x55\x64\x8a\xaa\xe9\x55\x48\x65\x12\x91\xe9\xd5\x96\x56\xef\xe1\x54\xbf\x49\x39\xe6\x8c\x2a\xae\x0d\x72\xa5\x38\x8f\x1e\x59\x44\x57\x69\x63\x4c\x68\xb4\x8c\x83\xe5\x6a\xea\xd1\x52\xe6\x29\x8d\x55\x6c\xc4\xb2\xea\x3f\x9c\x74\x5b\xfe\xe7\x0b\x17\xf4\x10\x5c\x5c\xbe\x6f\x7b\x2d\xb0\xcb\x8e\xf3\xe9\x4d\xcb\x71\x4e\xfc\x13\xf8\xed\xcc\x3f\x6f\xc3\x61\xa5\x0a\xbe\x20\x4c\x52\x1d\x6c\x24\x72\x1c\xb7\x63\x83\x3d\x56\x2a\xa9\x39\xce\xcd\xcd\x4d\xe5\xe6\x4d\x85\x8b\x91\xe3\xf7\x9c\x5b\x4d\xeb\x50\x23\x67\x97\x65\xb5\x82\x59\x09\x55\x68\x1f\x5b\xf5\x1f\xca\x65\xab\xaf\xa6\x11\x02\x61\x21\x18\x26\x21\x0a\xaa\x1d\xaa\x9b\x2d\xd0\xa4\x65\xcd\x71\x46\x54\x8d\x27\x83\x4a\xc0\x63\x47\xeb\x30\x9a\x30\xc7\x90\x23\x41\x4a\xaf\x6c\x54\x2b\xcf\xcd\x21\x2d\xcb\xf2\xc7\x08\xe7\x9e\x0f\x6d\x1a\x20\x93\x08\xaf\xce\x3d\xff\xc0\xb2\x5a\x3c\x99\x0a\x3a\x1a\x2b\x78\x15\x1c\xc0\xeb\xea\xe1\xcf\x70\x9e\x52\xb4\xac\x0b\x14\x31\x95\x92\x72\x06\x54\xc2\x18\x05\x0e\xa6\x30\x12\x84\x29\x0c\x4b\x30\x14\x88\xc0\x87\x10\x8c\x89\x18\x61\x09\x14\x07\xc2\xa6\x90\xa0\x90\x9c\x01\x1f\x28\x42\x99\x8e\x7f\x02\x01\x4f\xa6\x16\x1f\x82\x1a\x53\x09\x92\x0f\xd5\x0d\x11\xa9\x86\x44\x4a\x1e\x50\xa2\x30\x84\x90\x07\x93\x18\x59\x3a\x71\x61\x48\x23\x94\xf0\x4a\x8d\x11\xec\x7e\x86\x61\x1f\x18\x26\x21\x92\xc8\xa2\x0c\xf4\xb3\xf9\x23\xb3\x10\xe5\x13\x05\x02\xa5\x12\xd4\x58\xa1\x04\x94\x05\xd1\x24\xd4\x32\xcc\x1f\x47\x34\xa6\x19\x07\x8d\x6e\x14\x97\x96\xe2\x30\x91\x58\x32\x72\x96\x20\xe6\x21\x1d\xea\xbf\x68\xd4\x4a\x26\x83\x88\xca\x71\x09\x42\xaa\x49\x0f\x26\x0a\x4b\x20\xf5\xa0\xb1\x63\x49\xeb\xe1\x70\x01\x12\xa3\xc8\x0a\x78\x42\x51\x82\xd1\x75\x29\x9d\x81\xd1\xa2\x27\xda\xa0\x2a\x33\x91\xd4\x23\x37\x63\x1e\xe7\x35\xa1\xd2\x1a\x4e\x04\xa3\x72\x8c\x06\x27\xe4\x20\xb9\xe1\xa8\xa3\x59\x8f\x68\xf0\x21\x8f\x22\x7e\xa3\x55\x0b\x38\x0b\x69\xb6\xf6\x34\x4e\x26\x03\xbd\xfe\x0e\x16\x7e\x65\x5c\xd1\x20\x35\xb7\x71\x40\xb2\xf4\x6a\xf6\x48\x8e\x49\x14\xc1\x00\x33\x83\x61\x08\x94\x01\x59\x51\x47\x68\xf6\xba\xa3\x54\x94\x44\x90\x70\x61\xf8\xad\xab\x59\xb1\x2c\xff\xcc\x85\x7e\xf7\xd4\xff\xd4\xec\xb9\xe0\xf5\xe1\xa2\xd7\xfd\xe8\x9d\xb8\x27\x60\x37\xfb\xe0\xf5\xed\x12\x7c\xf2\xfc\xb3\xee\xa5\x0f\x9f\x9a\xbd\x5e\xb3\xe3\x7f\x86\xee\x29\x34\x3b\x9f\xe1\xff\xbc\xce\x49\x09\xdc\xdf\x2e\x7a\x6e\xbf\x0f\xdd\x9e\xe5\x9d\x5f\xb4\x3d\xf7\xa4\x04\x5e\xa7\xd5\xbe\x3c\xf1\x3a\x1f\xe0\xfd\xa5\x0f\x9d\xae\x0f\x6d\xef\xdc\xf3\xdd\x13\xf0\xbb\xa0\x19\x66\xa4\x3c\xb7\xaf\x89\x9d\xbb\xbd\xd6\x59\xb3\xe3\x37\xdf\x7b\x6d\xcf\xff\x5c\xb2\x4e\x3d\xbf\xa3\x69\x9e\x76\x7b\xd0\x84\x8b\x66\xcf\xf7\x5a\x97\xed\x66\x0f\x2e\x2e\x7b\x17\xdd\xbe\x0b\xcd\xce\x09\x74\xba\x1d\xaf\x73\xda\xf3\x3a\x1f\xdc\x73\xb7\xe3\x57\xc0\xeb\x40\xa7\x0b\xee\x47\xb7\xe3\x43\xff\xac\xd9\x6e\x6b\x56\x56\xf3\xd2\x3f\xeb\xf6\xb4\x7c\xd0\xea\x5e\x7c\xee\x79\x1f\xce\x7c\x38\xeb\xb6\x4f\xdc\x5e\x1f\xde\xbb\xd0\xf6\x9a\xef\xdb\x6e\xca\xaa\xf3\x19\x5a\xed\xa6\x77\x5e\x82\x93\xe6\x79\xf3\x83\x6b\xb0\xba\xfe\x99\xdb\xb3\x34\x58\x2a\x1d\x7c\x3a\x73\xf5\x90\xe6\xd7\xec\x40\xb3\xe5\x7b\xdd\x8e\x56\xa3\xd5\xed\xf8\xbd\x66\xcb\x2f\x81\xdf\xed\xf9\x0b\xd4\x4f\x5e\xdf\x2d\x41\xb3\xe7\xf5\xb5\x41\x4e\x7b\xdd\xf3\x92\xa5\xcd\xd9\x3d\xd5\x20\x5e\x47\xe3\x75\xdc\x94\x8a\x36\x35\xe4\x3c\xd2\xed\x99\xfb\xcb\xbe\xbb\x20\x08\x27\x6e\xb3\xed\x75\x3e\xf4\x35\xb2\x56\x71\x0e\x5c\xb1\xca\xe5\x63\xab\x6e\x52\xe0\x6d\x1c\x31\xd9\x28\x48\x6c\x87\xef\xde\xbd\x4b\xf3\x99\xbd\x1b\x90\xd4\xc9\xad\x61\x0f\x39\x53\xe5\x21\x89\x69\x34\xad\xc1\x4f\x67\x18\x5d\xa3\xa2\x01\x81\x0e\x4e\xf0\xa7\x12\x2c\x06\x4a\xd0\x14\x94\x44\x25\x90\x84\xc9\xb2\x44\x41\x87\x47\x30\xe0\xb7\x65\x49\xff\xd2\xb5\x18\x06\x5c\x84\x28\xca\x03\x7e\x7b\x04\x86\xa8\xa4\x7f\x61\x0d\x0e\x7f\x4e\x6e\x8f\x20\x26\x62\x44\x59\x0d\xaa\x47\x3a\xb7\x8e\x91\x84\xcf\xc9\x3f\x46\x45\x40\x57\xd4\x86\x7d\x4d\xf1\x46\xcf\x22\x5b\xcf\x5e\x85\x4c\x35\xec\x1b\x1a\xaa\x71\x23\xc4\x6b\x1a\x60\xd9\xdc\x3c\x9f\xb1\xc0\x99\x8b\xab\x9d\x59\xc6\x3f\x27\xf4\xba\x61\xb7\x52\x51\xcb\xfe\x34\xc1\x15\xc1\x75\x2b\xe2\x68\xe7\x1e\x99\x4a\x20\x51\x35\x2e\xfd\xd3\xf2\xaf\xcf\x2c\xbe\x79\x53\xf3\x7c\xee\xbe\xaf\x17\xa9\x3b\x46\xb8\x63\xcb\xaa\x3b\x3a\x28\xf5\xc5\x80\x87\x53\xa0\x0a\x63\x19\xf0\x04\x1b\xb6\x6d\x6e\xd4\x54\x5f\x67\x33\x4a\x06\x63\x8c\x89\x99\x51\xae\xae\xee\xe7\xf3\xde\xf7\x49\x95\x2c\xdf\xe0\xe0\x2b\x55\xe5\xf4\x41\xcc\xb9\x1a\x1b\xa4\xb4\x36\x50\x22\x31\x5c\x02\xe9\xd8\x30\xd8\x65\x12\x7e\x99\x48\x55\x03\xc6\x19\x1e\xc1\x18\x75\x65\xaa\xc1\x61\xb5\xfa\x3f\x47\x10\x51\x86\xe5\xc5\x50\xe5\x2d\xc6\x47\x60\x66\x40\x0a\x00\x3f\xd0\x58\x4f\x16\xc2\xd4\x11\x0c\x48\xf0\x75\x24\xf8\x84\x85\xe5\x80\x47\x5c\xd4\xe0\xc7\xe1\x5b\xfd\xbb\x6a\x7e\x48\x48\x18\x1a\xa9\x74\x34\x0c\x46\x06\xb2\x61\x67\x90\xb6\xb6\xb7\x22\x83\xa7\x0e\x8f\x15\x95\x76\xd4\xa3\x50\x76\x80\xba\x12\xcf\x98\xc7\x00\xb4\x04\x4f\x9c\x49\xaf\x51\x68\x22\x51\x99\x44\x74\xc4\x6a\xa0\x78\x92\x37\xd4\xb5\x79\xd0\xb0\x15\x4f\xec\xe3\xba\xa3\xc2\xa5\xa0\x69\x66\xb5\xdf\x56\xab\x4f\x3c\x55\x0a\x85\xce\x96\x56\x35\x18\x44\x3c\xf8\x9a\x8b\xed\x98\xdc\x96\xb3\x20\x79\x5b\xad\x26\xb7\xb9\x87\x41\x84\x44\x68\x86\x6a\x9c\x1b\xdf\x36\x51\x16\xc6\x01\x32\x51\x7c\x6d\x4a\xe4\xac\x65\x0c\x05\x50\x0f\xe9\xf5\x53\x87\x55\x5e\xdf\x75\xe3\xdc\xaf\xc4\x5c\x6e\xed\x64\x33\x99\x33\x3f\x6b\x4b\xd8\x10\x60\x14\x65\xd0\x0d\xbb\x9a\xde\xcb\x84\x04\xf3\xfb\x27\x55\x34\x7b\x28\x48\x48\x27\xb2\x06\x6f\xcc\x58\x41\x02\x18\x0e\x73\x59\x2c\x45\xab\xc1\x61\x72\x0b\x92\x47\x34\x84\x1f\xf1\x9d\xfe\xcd\x27\x86\xe1\x70\xc5\x16\xfb\x90\x1d\x96\x92\x3c\x5d\x96\x78\xbb\x75\xc2\xe5\xac\x6b\x50\x6e\xb2\x52\xf3\x4b\xb5\x7a\x04\xa6\x44\x65\xf0\x01\x32\x85\xa2\xc8\x5f\xe6\x5f\xd5\x38\x65\xd3\x6f\xee\xdb\x5f\x5e\xbf\x6e\x15\x17\xa0\xd7\x3a\xae\x6d\xc8\xe6\x5b\xca\x60\xd5\x7b\x29\x6e\xf1\x8c\x9c\xff\x2c\x37\x7c\x17\x3b\xbd\x60\x5e\x96\x14\xbe\x4b\x3a\x80\x43\x98\xcd\xe4\xe2\x85\x07\x0c\xb9\x80\xe5\xa6\xe4\x96\x4d\x61\x98\xcd\xd6\xb8\xc2\xea\x16\x65\x23\xb7\x41\xb9\x01\x96\xbd\x5a\xc9\x39\x7f\x91\x83\x17\xf7\xe2\x25\x4c\x77\x29\x66\xcb\xe0\x39\x4c\x83\xe7\xbe\xd8\xd8\xfb\xdc\xb7\xd5\xec\xfb\x15\x04\xfb\x1e\x0a\x55\xa8\xce\x73\xc9\x7d\xe1\x90\xa9\x41\x60\x2c\x70\xd8\xb0\x77\xd9\x63\x78\xe2\x78\x98\x27\xcd\xd3\xd3\xd3\x2c\xf9\x86\x18\x70\x61\xde\xc9\xcd\x97\x07\xb9\x05\xc1\x6b\xbd\x1c\xc8\xe5\xed\x01\x8f\xc2\xe2\xc4\x1d\x4c\x84\xd4\xd4\x13\x4e\xd3\x81\x45\x43\x41\x99\x21\x9a\xf5\x15\x6b\x09\xfe\x17\x2d\x98\xa1\x67\x5e\xa2\x0e\xb9\x88\x6b\x10\x90\x84\x2a\x12\xd1\xbf\xb0\x30\xe9\xbf\xf9\xf9\x57\x0c\x49\x41\xbd\xde\x80\xc8\x86\x8d\x95\x6b\x69\x21\x5f\x0c\x2e\xba\xb7\xe4\x36\x73\xef\xf1\x47\x8a\x37\x40\x19\x3c\xf8\x76\xbc\xee\x90\xc2\x18\x5e\x4b\xbc\xc5\xe9\x37\xfd\x79\x68\xf3\xa3\xa0\x28\xbc\x4c\xd9\x7f\x66\xca\x4a\x25\x38\x1b\x3d\x9f\x69\x7f\xdf\x7e\xac\xec\x8f\x6c\xe7\xab\xee\xa4\x42\x7e\x87\xa8\x2b\x68\x18\xb2\x27\xf3\xb3\x53\xeb\x5b\x68\x2f\x71\xf8\xef\x88\xc3\xb4\x35\x5d\x84\x5a\x7d\xf0\x7c\x6e\x06\xa7\xd8\x46\x0f\x1c\x1a\xdc\x7e\xb2\xef\x99\x95\xd9\x3e\xef\xa0\xa0\x16\x2c\x37\xd1\xd3\x4a\xf0\xec\x91\xb1\x22\xd1\xbe\x84\xc7\x83\x16\x7d\xf0\x24\xe8\x7f\x68\xb0\xac\x76\x98\xeb\x47\x53\x9f\xa9\xa1\x9c\xb7\x5b\x1b\x3d\xe5\x84\x85\x28\x74\xf7\x97\x0f\xa7\xf4\x70\xad\x6e\xa2\xf6\x2f\xc7\x7c\x5b\x35\xdd\xb1\xbd\x5b\x3d\x6b\x52\xe8\xde\x97\xae\x70\x6f\xaa\xf1\xde\x45\x26\x40\x7d\xbc\x87\x32\xed\x9d\x9d\x1e\x33\x83\xef\xeb\x88\x5f\x26\xd6\x7f\x67\x9b\xbb\xba\xdc\x5a\x9c\xd9\x5b\x2e\xb8\xe6\x43\xcf\xb0\xe4\x5a\x3d\x41\xf8\x12\x8d\xff\x8e\x68\x7c\x59\x74\xbd\x2c\xba\x5e\x16\x5d\xfb\x1e\x2c\x2f\x8b\xae\xbd\x69\xd9\xb6\x39\xaa\xee\x98\xfd\xb8\xe3\x47\x6c\x85\x2e\x50\x96\x23\x4f\x7e\x12\x23\x77\x34\x69\xe5\xa4\xc9\xd2\xd1\xef\xde\xbd\xbb\x6f\x83\x3b\xbf\xb3\xbb\xb9\x25\xb9\x1f\x4d\xc3\x3e\xb5\x2f\x4f\xd9\xba\xbc\xde\xda\xba\x14\x6e\xa2\x3d\xe4\xf2\x95\xde\x66\xed\x5c\x43\xfe\x14\xd6\x6a\xba\xca\x7f\x3c\xff\x74\x01\xf1\x7a\x35\x5b\x19\x8d\x76\x4e\x55\xc8\x14\x0c\xa6\xbb\xed\xc3\x6d\xe6\x8e\x8d\xf3\x0e\xeb\x99\xa1\xee\x84\xf4\xfa\x38\xfd\xdf\xca\xa7\x89\x7d\x6b\x6b\xb7\x1c\xaf\x4b\x55\x5c\xe6\xaf\xba\x33\xe0\xe1\x54\x8f\x8c\x55\x1c\x1d\x5b\x56\xf1\xf7\x3b\xc9\x44\x8e\xf9\x35\x8a\xef\xf0\x71\xfa\x06\xa9\x7f\xfe\x7b\xb0\xef\xf3\x39\xd8\xee\x5f\x83\x7d\xbf\x8f\xc1\x56\x78\xee\x60\xc9\xe5\x17\xe6\x8f\xf8\x8a\xf4\xff\x03\x00\x00\xff\xff\x99\xde\x39\x7b\x7a\x43\x00\x00")
+var _templateDefaultTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xed\x1c\x6b\x77\xda\x38\xf6\xbb\x7f\x85\xc6\x3d\x7b\xa6\xe9\x62\x20\xe9\x63\x27\x29\xc9\x1e\x4a\x48\xc3\x59\x02\x39\x40\xda\xe9\x76\xbb\x8c\xb0\x05\xa8\xb5\x2d\x8f\x24\x42\x68\x67\xfe\xfb\x5e\xc9\x06\x6c\x30\xc4\xd0\x4c\x92\x99\xa5\xe9\x4c\x63\x59\xf7\xa1\xfb\xd6\xc3\xfa\xf6\x0d\x39\xa4\x4f\x7d\x82\xcc\x6e\x17\xbb\x84\x4b\x0f\xfb\x78\x40\xb8\x89\x7e\xff\xbd\xac\x9e\x2f\xc2\xe7\x6f\xdf\x10\xf1\x1d\x68\x34\xbe\xad\x02\xb9\x6a\xd5\x15\x14\xbc\xcf\x57\x6f\x24\xe1\x3e\x76\xa1\x09\x5a\x0a\x4f\x0a\xba\x9f\xf8\x27\x27\x36\xa1\xd7\x84\x1f\xab\x4e\xad\xe8\x21\x84\x89\xb0\x27\xd1\x8b\x51\xef\x33\xb1\xa5\x42\xfb\x51\x81\xb4\x25\x96\x23\x81\x7e\x43\x92\x5d\x05\xc1\x14\x94\xf6\x11\xf9\x75\xf6\xd2\xec\x53\x4e\xfd\x81\x82\x39\x52\x30\x7a\x14\x22\x7f\xa6\x5b\x01\xd4\x25\x7e\x9c\xe2\x27\xa4\x3a\xbd\xe5\x6c\x14\xd4\x71\x8f\xb8\x22\xdf\x66\x5c\x12\xe7\x12\x53\x2e\xf2\xef\xb0\x3b\x22\x8a\xe0\x67\x46\x7d\x64\x22\x85\x15\x85\x24\x07\x12\x3d\x55\xb8\xf2\x15\xe6\x79\xcc\x0f\x81\xf7\xa2\xb6\x18\xbe\x3d\x00\x79\x0a\x20\x63\x2a\x87\xc9\xce\x20\x01\x8f\x5d\x93\x24\xf5\x06\xf6\x80\x60\x28\xc6\x34\xea\x33\xc6\xf7\x66\xbf\xcd\xf8\xc9\x77\xf8\xc8\xb7\x31\x70\x1f\x0e\x1a\x15\x15\xbb\x18\x3a\x29\x6c\x8b\x2f\xe1\x95\xc7\x38\x89\xa3\x49\x53\xb1\x43\x84\xcd\x69\x20\x29\xf3\xcd\x35\xaa\x92\xe4\x46\x86\xe6\xd0\x75\xa9\x90\x51\x57\x8e\xfd\x01\x0c\x10\x1e\xc2\xe1\x1d\x19\xf3\xc6\x65\x71\x2b\x96\x2c\xcd\xab\x92\x82\x7a\x3a\x46\x33\x39\x44\x8c\x85\xc4\xcb\xbe\xcf\x40\xdd\xc0\x53\x02\x65\xac\x79\x3b\xbc\x6d\x36\xe2\x36\x39\x0a\x6d\x82\xf8\x84\x63\xc9\x78\x68\xc5\x46\x8a\xa0\x12\x32\x10\x2e\xb6\xbf\xe4\xe1\x09\x8f\x5c\x99\x97\x54\xba\x24\x92\x82\x24\x5e\xe0\x82\xe4\x13\x26\x9d\x5f\x25\xf2\x24\x9e\x91\x50\x9e\xe4\xa5\xa1\x4a\xfa\x6b\x46\x7c\x7d\xec\xba\x3d\x68\x58\xc2\x97\xca\xbe\x42\x0a\xf6\x77\x5b\x47\x97\xfa\x5f\x32\x73\x10\x70\xa2\x8c\xc5\xcc\xd6\x3b\x86\x7f\xad\x00\x74\xf4\xc9\xc8\x01\xb5\x99\x0f\xae\xf7\x99\x9a\xd9\xfb\x8f\xb8\x9b\x95\xe3\xec\x83\xeb\x33\x26\xc3\x58\xbb\xc2\xa6\x86\x34\xb0\x87\x58\xce\x01\x38\xf3\xb6\xb7\x84\x45\x6c\x10\x69\x04\x80\x64\xb7\xd2\x04\x6f\x81\xa2\xe6\x8c\xe4\x64\x86\x6f\x39\x54\x6c\x66\xf9\xcb\x18\x6d\x97\x12\x5f\x6e\x3f\xe2\x55\x18\xe7\xb9\x6a\x3b\x7b\x5a\xc6\x4b\x7d\x21\xb1\x6f\x13\x91\x82\x77\x29\x36\xae\x91\x2a\x0b\xc4\x80\xf8\x94\x6c\xaf\xa4\x75\xc8\x96\x35\x14\x65\xa4\x15\x91\x33\x35\x05\x19\x0b\x09\x30\x91\x61\xf7\x20\xe9\x58\xd0\x27\x4a\x32\x61\xa3\x8e\xd1\xeb\x25\x92\x4c\xd3\x9a\x88\x15\x1b\x51\x0a\xbd\x16\x11\xcc\xbd\x26\xce\x02\xc5\x69\x73\x76\x9a\x53\x88\x25\xaa\x56\x16\x91\x0a\x9d\x32\x36\xb7\xa6\x84\xd6\xc7\x64\x1b\xc7\x34\x76\xfa\x5b\xa3\xbf\x72\x5c\xfe\xdc\x5d\xc2\x97\xaa\x9f\x15\x5a\x5f\xd0\x0f\x0e\x68\x57\x10\x1b\x12\xd9\xca\x40\xbf\x00\x21\x59\x57\x65\xf2\x0d\xba\x07\x98\xcb\xc9\x06\xfd\x25\x1e\x64\xed\x0d\x23\xf6\x65\x97\x3a\x8b\x89\x27\x0e\x72\x4d\x6d\x28\x7d\xc0\xda\xe7\x86\x0e\xf6\x45\xba\x49\xd3\xdc\x59\xdf\x66\xd1\x63\x59\xaa\xa0\x09\x2a\x27\x5d\x87\x0a\x20\x35\xe9\xae\x28\xf5\x6e\x0f\xf5\xcb\x98\x41\x2f\x14\x9a\x40\x20\x5d\xc9\x98\xbb\x61\x12\x8d\xe3\x26\x1e\xa6\xee\xdc\x0e\xe6\x93\xb2\x8d\xb9\x4c\x62\x1a\x4a\x4f\xb3\x65\x94\x7e\x38\x6d\x56\x3a\x1f\x2e\xab\x48\x35\xa1\xcb\xab\x37\xf5\x5a\x05\x99\x56\xa1\xf0\xfe\x79\xa5\x50\x38\xed\x9c\xa2\x9f\xcf\x3b\x17\x75\xb4\x9f\x2f\xa2\x0e\x14\xfb\x82\x2a\x63\xc3\x6e\xa1\x50\x6d\x80\x59\x0d\xa5\x0c\x8e\x0a\x85\xf1\x78\x9c\x1f\x3f\xcf\x33\x3e\x28\x74\x5a\x85\x1b\x85\x6b\x5f\x01\x47\xbf\x5a\x32\x06\x99\x77\xa4\x63\x9e\x00\x65\xcb\x32\xda\x72\xe2\x12\x3d\x4f\xd2\x44\x1c\xc2\xa9\x52\xa8\x2a\xb6\x90\x42\x2d\x00\xf7\x00\xa6\x6f\xa3\x5e\xde\x66\x5e\x41\x8d\x61\x30\xf2\x0b\x1a\x1d\xb6\x43\x7c\x96\x1e\x9a\x35\x15\x87\x00\x6f\xea\x0c\x09\xba\xa8\x75\x50\x9d\xda\xc4\x17\x04\x3d\x85\x87\x3d\xc3\xa8\xb0\x60\xc2\xe9\x60\x08\x06\x69\xef\xa1\x83\xe2\xfe\x0b\x74\x11\x62\x34\x8c\x4b\xc2\x3d\x2a\x04\x60\x44\x54\xa0\x21\xe1\xa4\x37\x41\x03\xa0\x03\x2e\x95\x03\x86\x08\x41\xac\x8f\xc0\x99\xf9\x80\xe4\x60\x16\x0c\x4c\x4f\x10\x4c\x84\x05\x00\xb0\x9e\xc4\xd4\x57\xf6\x8f\x91\x0d\x34\x0c\xe8\x29\x87\x80\x46\xb0\xbe\x1c\x63\x1e\x8e\x10\x0b\xc1\x6c\xaa\xa6\x81\xc8\x61\xf6\xc8\x03\xfb\xd3\x8e\x8b\xfa\xd4\x05\x57\x7d\x2a\x81\x69\xb3\x1d\x41\x98\x7b\x9a\x88\x43\xb0\x6b\x80\x03\xab\x77\xd3\x57\x7a\x3e\xcb\x46\x12\x71\x22\x24\xa7\x5a\x0a\x39\x44\x7d\xdb\x1d\x39\x8a\x87\xe9\x6b\x97\x7a\x34\xa2\xa0\xc0\xf5\xc0\x85\x01\x48\x21\x1c\xe6\x34\x9f\x39\x98\x86\x3a\xb4\xaf\xfe\x25\x7a\x58\xc1\xa8\x07\x2e\x36\xcc\x21\x70\x0a\x40\xdd\x1b\x49\x68\x14\xaa\x51\xcb\x31\xa7\xc6\x51\x60\x1c\x09\xe2\xba\x06\x60\xa0\xc0\xb7\x1e\xeb\x9c\x3b\xdd\x47\xb1\x1e\x28\x81\xca\x48\x44\x42\xb5\x8c\x87\xa0\xd5\xc4\x48\xa8\x30\xfa\x23\xee\x03\x49\xa2\x61\x1c\x06\x22\xd3\x14\x95\x35\xab\x16\xd5\xbd\xcf\x5c\x97\x8d\xd5\xd0\x60\x36\xe0\xd0\x68\xee\xa9\x95\x8c\x7b\x6a\x1a\x6f\xcf\xf4\x0a\xc1\x10\x58\x0d\x59\x50\x0a\x08\xe6\x5a\x8d\x5e\x89\x21\x4c\xc3\x50\x8f\x44\x02\x03\xba\x20\x5e\x1c\x1b\x0e\x57\xe4\x55\x45\x29\x29\x76\x51\x00\x31\x55\xd1\x5b\x1c\x66\x1e\xe8\x9f\x57\x51\xbb\x79\xd6\x79\x5f\x6e\x55\x51\xad\x8d\x2e\x5b\xcd\x77\xb5\xd3\xea\x29\x32\xcb\x6d\x78\x36\x73\xe8\x7d\xad\x73\xde\xbc\xea\x20\xe8\xd1\x2a\x37\x3a\x1f\x50\xf3\x0c\x95\x1b\x1f\xd0\xbf\x6a\x8d\xd3\x1c\xaa\xfe\x7c\xd9\xaa\xb6\xdb\xa8\xd9\x32\x6a\x17\x97\xf5\x5a\x15\xda\x6a\x8d\x4a\xfd\xea\xb4\xd6\x78\x8b\xde\x00\x5c\xa3\x09\x26\x5c\x03\xdb\x05\xa4\x9d\x26\x52\x04\x23\x54\xb5\x6a\x5b\x21\xbb\xa8\xb6\x2a\xe7\xf0\x58\x7e\x53\xab\xd7\x3a\x1f\x72\xc6\x59\xad\xd3\x50\x38\xcf\x9a\x2d\x54\x46\x97\xe5\x56\xa7\x56\xb9\xaa\x97\x5b\xe0\xd8\xad\xcb\x66\xbb\x0a\xe4\x4f\x01\x6d\xa3\xd6\x38\x6b\x01\x95\xea\x45\xb5\xd1\xc9\x03\x55\x68\x43\xd5\x77\xf0\x80\xda\xe7\xe5\x7a\x5d\x91\x32\xca\x57\xc0\x7d\x4b\xf1\x87\x2a\xcd\xcb\x0f\xad\xda\xdb\xf3\x0e\x3a\x6f\xd6\x4f\xab\xd0\xf8\xa6\x0a\x9c\x95\xdf\xd4\xab\x21\x29\x18\x54\xa5\x5e\xae\x5d\xe4\xd0\x69\xf9\xa2\xfc\xb6\xaa\xa1\x9a\x80\xa5\x65\xa8\x6e\x21\x77\xe8\xfd\x79\x55\x35\x29\x7a\x65\xf8\x5b\xe9\xd4\x9a\x0d\x35\x8c\x4a\xb3\xd1\x69\xc1\x63\x0e\x46\xd9\xea\xcc\x40\xdf\xd7\xda\xd5\x1c\x2a\xb7\x6a\x6d\x25\x90\xb3\x56\xf3\x22\x67\x28\x71\x02\x44\x53\x23\x01\xb8\x46\x35\xc4\xa2\x44\x8d\x12\x1a\x81\x2e\xea\xf9\xaa\x5d\x9d\x21\x44\xa7\xd5\x72\x1d\x70\xb5\x15\xb0\x1a\xe2\xb4\x73\xde\xb0\x2c\x88\x48\x3a\x04\xde\x78\xae\x2f\x8e\x53\x02\xdb\xfe\xe1\xe1\x61\x18\xcf\xcc\x6c\x9d\x84\x0a\x6e\xc7\x66\x9f\xf9\xd2\xea\x63\x8f\xba\x93\x23\xf4\xe3\x39\x81\x94\x05\x96\x88\x51\x83\x8c\xc8\x8f\x39\x34\x6b\x80\xa1\x72\x30\x39\x30\x7f\x08\x6e\x16\x94\x2c\xb4\xff\x1a\xf5\xd8\x8d\x25\xe8\x57\x95\x8b\xe1\x77\x0e\x01\xd2\x82\xa6\xd7\x48\x23\x85\x17\xe4\x08\xed\xbf\x08\xa0\xc1\x83\xc0\x44\xfd\x23\x54\x7c\xad\x62\xeb\x90\x60\xe7\x21\xe9\x7b\x44\x62\xa4\x32\xea\x31\xa4\x47\x32\x56\x5e\x64\x2a\xef\x95\x10\xf4\x8e\xcd\x31\x75\xe4\xf0\xd8\x21\x90\x39\x89\xa5\x1f\x1e\x4e\x58\xa8\x30\x65\x57\x29\xd3\x22\xbf\x8e\xe8\xf5\xb1\x59\x09\x59\xb5\x3a\x93\x80\xc4\x18\x57\xa5\x48\x41\x29\xf7\xb5\xce\x04\x82\xc8\xe3\xab\xce\x99\xf5\xd3\x03\xb3\xaf\x57\x6a\x1e\x4e\xdd\xeb\x6a\x91\x52\x41\x33\x77\x62\x18\xa5\x82\x32\x4a\xf5\x4b\x8f\x39\x13\x44\x01\x44\x40\xcc\x05\x8e\x4d\xfd\x20\x27\xea\xf7\xc8\xa3\x84\x3d\x84\xac\xae\x3d\xaa\xaa\xb2\xfb\xc5\xb4\xf6\xbd\xd7\x41\x5a\x63\xd2\xfb\x42\x81\x90\x7e\xe1\x31\x06\x39\x45\x01\x85\xb9\x81\x62\x41\x9c\x79\x27\x65\x1b\x1a\xda\xc2\xce\xe7\x91\x90\x47\x90\x71\x7c\xf2\x1a\x4a\x09\x95\x99\x00\x65\xb1\xf8\xb7\xd7\x90\x94\x7d\x62\xcd\x9a\xf2\xaf\x88\xf7\x1a\x69\x0f\x08\x3b\xa0\x1f\xa8\xa7\x9c\x05\x28\x00\x9f\xd8\xfe\x32\xe0\x6c\xe4\x3b\x96\xcd\x5c\xc6\x8f\xd0\x93\xfe\x2b\xf5\x13\x17\x3f\x0a\xb0\xe3\x68\xae\x94\x35\xf4\x06\xba\xe7\xb1\x19\xf5\x34\x95\xbc\x25\xee\xdd\xb7\x79\xc4\x86\x94\x71\x1c\xa9\xbc\x23\x54\x92\xfc\x01\xe3\x18\x42\x8a\x83\x7b\x8e\xa4\xd7\x30\x35\x00\x24\xae\x05\x26\x36\x00\x4e\x24\x0b\x92\x82\xba\xd6\x2f\x20\x1a\xb1\xc0\x3c\x01\x07\x73\xe6\x8c\x86\x91\xd5\x7c\x55\x2c\x9a\x8f\x80\xe9\x68\x6a\x05\xa0\x2e\xb3\xbf\x24\x6c\xdb\xc3\x37\x56\x64\x24\xc0\x6c\x70\x93\x78\x69\xbb\x04\x73\x45\x50\x0e\x13\xed\xab\x1c\x65\x26\x1c\x84\x47\x92\x2d\xb8\x44\x42\x5a\x5a\x50\x20\x2a\x87\x5e\xdf\xb7\x59\x25\xc7\xbb\x28\x9c\xf5\x83\x98\xf2\xad\x94\xac\x9d\x39\xd2\xb3\x92\x04\xa4\x27\xa8\xc6\xa3\xde\xc7\x66\x31\x7c\x16\x01\xb6\xa7\xcf\xf7\x3a\xd0\xe8\x25\xc7\x0e\x1d\x89\x23\xf4\x5c\xb7\xa5\x04\x80\x7e\x3f\x11\xc5\x42\x30\x40\x02\xa6\x00\xb3\x7a\xea\xa0\x27\xe4\x50\xfd\x24\x03\x43\xbf\x1f\x93\xc5\x63\x88\x0e\x73\x4e\xee\x2f\x4a\xbc\x5a\xe9\x70\x09\xe9\x6a\x90\x71\x94\x6a\x5e\x16\x41\xc8\x3a\x45\x45\xfd\x61\x42\x27\x09\x4f\xd3\x97\xfe\xaf\xa8\x95\xb2\xac\xb7\xea\xab\x97\x07\x07\x95\xf4\x04\x74\xa0\xec\xda\x44\x91\xbf\x85\x04\xe2\xda\x0b\x61\xd3\x3d\x72\xfa\x67\xbe\x6f\x3c\xdb\x30\x46\x7a\xb1\x24\x75\x2d\x69\x0f\xed\x43\x07\x31\x5b\xf0\x80\x31\x73\x34\xdf\x94\x5c\xb1\xb7\xac\xd6\x3d\x10\x5a\xa6\x1b\x6d\x51\x1e\x27\x36\x28\x97\xba\x45\x4b\x2b\x09\xe5\xcf\x62\xf0\xec\x99\xef\xcc\x34\x4b\x32\x9b\x1b\xcf\x7e\x68\x3c\xeb\x6c\xe3\xd1\xc7\xbe\x95\x62\x7f\x5c\x46\xf0\xd8\x4d\x01\x62\xcf\x34\x96\xac\x33\x87\x68\x18\x30\x71\xe3\xa4\x7f\x6c\x66\xd9\x63\xb8\x67\x7b\x98\x06\xcd\xb3\xb3\xb3\x28\xf8\x3a\xc4\x66\x5c\xaf\xc9\x4d\xa7\x07\x89\x09\xc1\x81\x9a\x0e\x24\xe2\x76\x8f\xb9\x4e\x7a\xe0\xb6\x47\x5c\x28\xec\x01\xa3\x61\xc3\xac\xa0\xa0\xbe\x46\x1a\xd5\x15\x0b\x01\xfe\xa5\x62\x4c\xe3\xd3\x8b\xa8\x10\x30\x3d\xc0\x89\x03\x2a\x01\xff\x57\x92\x1a\xf4\x9f\xbf\xf8\x89\x38\x38\x25\x5f\x2f\xf5\x88\x9a\xb5\x94\x8f\xc2\x44\x3e\x6b\x9c\x55\x6f\x90\x5e\x42\xf5\x9e\xbc\xa3\x64\xac\xd6\xdf\x6e\x5d\x1d\x2f\x15\x70\xaa\x0d\x2f\x04\xde\xf4\xf0\x3b\x0b\xdd\x6b\x37\x3f\x52\x92\xc2\xce\x65\xff\x18\x97\x15\x92\x33\x7f\xf0\x70\xa2\xfd\xb8\xfa\x74\xda\xa7\x68\xe7\xab\x54\x08\x99\xbc\x03\xab\x4b\x29\x18\xa2\x37\xd3\xb3\x53\x8b\x5b\x68\x3b\x3b\xfc\xff\xb0\xc3\xb0\x34\x9d\x99\x5a\xa9\xc7\x1f\x74\x1d\x31\x4d\x46\xb7\x1c\x1a\x5c\x7d\xb2\xef\x81\x07\xb3\xda\xef\xd2\x72\xc1\x7c\x13\x3d\xcc\x04\x0f\x6e\x19\x31\x8e\x1e\x8b\x79\xdc\x2a\xd1\x5b\x4f\x82\xfe\x49\x8d\x25\x5e\x61\x2e\x1e\x4d\x7d\xa0\x82\x72\x5a\x6e\x2d\xd5\x94\x50\xb5\x11\xae\xaa\xbf\xa4\x39\x85\x87\x6b\x55\x11\xf5\xf8\x62\xcc\x76\xd9\x34\x63\x79\x17\x3f\x6b\x92\xaa\xde\x5d\x55\xf8\x68\xb2\xf1\x23\xcc\x7e\xa5\xe1\x23\xe4\xe9\x4f\xed\xc1\xeb\x2a\xe2\x9d\x63\xfd\xf5\xa7\x5b\xb3\x33\x7b\xf3\x09\xd7\xb4\xe9\x01\xa6\x5c\xf1\x13\x84\x3b\x6b\xdc\x4d\xba\x76\x93\xae\xdd\xa4\x6b\x37\xe9\xda\x4d\xba\x76\x93\xae\x0c\xf9\x14\x7a\xab\xfd\xb8\x93\x0d\xb6\x42\x67\x20\xf3\x96\x7b\x3f\x89\x91\x38\x9a\x14\x3b\x69\x32\x57\xf4\xe1\xe1\xe1\xba\x0d\xee\xe4\xce\xee\xf2\x96\xe4\x63\xd9\xe9\x7d\x3c\xe5\xcb\x7d\x96\x2e\x07\x2b\x4b\x97\xd4\x4d\xb4\xdb\x54\x1e\xab\x6d\x16\xce\x35\x24\x4f\x61\xc5\xc3\x55\xf2\x1b\x7c\xf3\x7e\x87\x9e\x18\x51\xe6\x50\x05\x63\x42\xbd\x49\xb6\x7d\xb8\xe5\xd8\xb1\x74\xde\x61\x31\x32\x94\x0a\xe0\xe6\x27\xe1\xff\x8d\x64\x98\xf8\x93\x1c\xaf\x0b\x87\x38\x8f\x5f\xa5\x82\x3a\xc5\xaa\x5a\xd4\x71\xe0\x13\xc3\x48\xff\x7e\x27\x18\x89\x21\x03\x8a\x77\xf0\x71\xfa\x12\xaa\x3f\xfe\x7b\xb0\xbb\xf9\x1c\x2c\xfb\xd7\x60\x77\xf7\x31\x58\x8c\x66\x06\x49\xce\xbf\x30\xdf\xe4\x2b\xd2\x18\x46\x4f\x48\x82\x3d\x71\x07\x5a\x5e\xc4\x24\x46\x1e\xd8\xe6\xe4\x4e\x70\xc5\x3e\x8f\xdf\x59\x4b\x66\x6b\x59\x92\xe2\x90\x78\xa4\xab\xc3\xac\x99\x7a\x0b\x0a\x8f\x70\xab\xb7\x07\xa7\x95\x7f\x1c\x9c\x2a\xbc\xae\x20\xd3\x8e\x89\x9b\x48\x04\x01\x4b\xa4\x72\x82\x4c\x1b\xfe\x51\x81\x49\xc1\xfd\x54\xd9\x2f\xef\x97\xb3\xc1\x8d\x31\xf7\xa3\x6b\x57\xce\xce\xca\x2f\x8b\xc5\x29\x18\xa0\x29\xaa\x9f\xb4\xeb\x33\x62\x03\x94\xc4\x25\x03\x8e\xbd\xc7\xf2\x0d\xf4\x5f\xc9\x8e\x12\x17\x50\xf8\xe2\x4e\xbe\xe4\x8c\xe3\xd9\xe5\x80\x6d\xb5\x21\xc7\xd4\xa5\x6c\x8b\xbb\x1e\xe2\xb7\x09\xc5\x25\x1d\x45\xea\xf9\x8d\x37\x71\xfd\xa5\xf3\xe0\x50\x01\xa5\x99\x73\x07\x69\x63\x11\xd3\xce\x2e\xb6\xb5\x0b\x0f\x4b\x4e\x6f\x76\xb1\xf0\x0f\xcd\xa9\xdd\x6e\x28\xe6\x85\x6b\xab\x4a\x23\xf7\x24\x71\x75\x55\xc9\xa5\x27\xd9\x17\x43\x8f\x4b\x36\x73\xc8\x49\x62\x8d\xab\xa0\x9b\x50\xdc\x11\x43\x07\x8e\xab\x29\x76\x37\x4b\xb8\x2e\xa6\x57\xa7\x12\xce\x56\x2a\x84\xac\x4c\x9f\x42\x4e\x53\xaa\x85\xa4\xf9\xa8\xc9\x41\xfc\xe2\x86\x68\x8d\xf3\x96\x4f\xe6\xa6\xfb\x20\xb7\x5b\x47\x29\x38\x49\xda\x47\xa9\x10\x2c\x22\x4f\x11\xf5\x92\x91\x6c\x66\x23\x73\xaa\x33\x2b\xd9\x8c\x6e\xcc\x54\x6e\xf3\x47\xca\xed\x4d\x9d\x11\x6d\x5f\xc8\xdf\x78\x41\xb0\x8d\xef\xff\xf5\x9d\x78\x4b\x81\x76\xbb\x63\xd2\x23\x37\xeb\xee\xa7\xb3\x36\xd8\xef\x38\xfe\x25\xee\xdd\xbf\x6c\xe4\xd7\x06\x5a\x4a\xcb\xc6\xfa\x2b\xf8\x34\xeb\x69\xe6\xf0\xec\xd9\x3a\x83\x78\xf6\xec\xfb\x4d\x62\x59\x6a\x6b\x7d\xd6\xba\x03\x9b\x58\x4d\x32\xd5\x5d\x3f\x4e\x0f\x6f\xc7\x6f\xfb\xf9\xf4\x34\x8b\xa9\xec\xa5\xdb\x0a\x67\xf6\x17\x22\x93\xf7\xe5\xa8\xaf\x6c\xb7\xbf\x84\x2c\x05\xe3\xfa\xdb\xe7\xd2\x58\xb8\xc6\x12\xf3\x4d\x20\xb6\x2f\xe3\x56\x21\xfb\xee\x5b\xf8\xd2\x10\xcf\x57\x03\x12\x9b\xe2\xab\x9c\x69\x5e\xdf\x46\x5e\x94\xe2\x3c\xe9\x7a\x1d\x30\x36\x70\xc9\x1d\x89\x28\x05\x19\x00\xc5\xf1\xdd\x5e\xa0\xaf\xae\xce\x6f\xe1\x7a\x2e\xb2\x2c\x57\xf6\x2d\xdf\xd2\xa9\xbe\xab\x08\xba\xdf\x7b\x15\xdc\x7f\x7c\x73\x97\x76\xd6\x9a\xfb\x67\xca\xf1\x9d\x2c\xa1\x25\x10\xad\xbf\x64\x71\x95\x82\xb3\xe1\x0e\x38\x65\x6a\x39\x67\xf5\x45\xaf\x82\x70\x75\x5f\x86\xcf\xc6\xf3\x91\x0d\xc1\x48\xba\xdf\x7b\xf7\x63\x0a\xe2\x3b\x1e\x69\x0a\x85\x11\x1f\x10\xdf\x9e\x24\x57\xd0\x32\x2c\x8c\xed\x6f\xbc\x26\x76\x30\x5f\x0e\x7b\xbe\xde\xe3\x53\xd8\xa4\x5e\x80\x53\x96\x69\xd6\x0d\x68\x13\x29\xd8\x2e\x13\xa4\x0b\x4e\x1f\x5e\x5e\x39\xf3\x82\x2c\x5b\x31\x47\xdf\x51\xe6\x7e\x85\x36\x22\xbe\xdc\xc9\x72\xd4\x22\x2e\x1b\x34\x92\x7e\x73\xe8\xc6\x76\xb3\x88\x7a\xc1\x49\xb2\x5b\x8d\x56\x8e\xdc\xd8\x74\x86\x74\x30\x9c\x5b\x8f\xcf\xb8\x87\xdd\xf5\x26\xb4\xc8\xb1\xc4\x03\xad\xd9\xb8\x6e\x32\x7d\xa0\x1c\xab\x79\xbb\xf3\x92\xf7\x37\xc4\x49\x8b\x80\x40\x6d\x52\x76\x5d\x64\x7e\xfc\x2f\xb6\xbe\x96\xad\x7f\x17\xad\xc3\xee\xa7\xbf\x9b\x20\xe4\xe9\x35\xd9\x6b\xf3\x71\x78\xa1\xda\xf7\xe7\xe2\x6e\x37\x44\xb5\xae\xba\x37\x9e\x3d\x5c\x79\x3f\x83\x5a\x38\x40\x82\x9e\x7e\x0c\xef\x0e\xd5\xe5\xaa\xae\x48\xf7\xd6\x15\x33\xab\x65\xa7\x36\x01\x37\x2d\x3b\x8c\xe5\xeb\xc3\x57\x67\xf0\x27\x4f\x9e\xa0\x44\x16\x5f\x4c\x8a\x29\x1a\x58\x93\xc6\x33\x65\xf1\x18\xcd\xe9\x9b\x0d\xa8\xa6\xa7\xf2\xbb\x99\x2d\xfc\x0f\x3f\xe2\xc0\xaf\x35\x5f\x00\x00")
 
 func templateDefaultTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -83,7 +83,7 @@ func templateDefaultTmpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "template/default.tmpl", size: 17274, mode: os.FileMode(420), modTime: time.Unix(1, 0)}
+	info := bindataFileInfo{name: "template/default.tmpl", size: 24373, mode: os.FileMode(420), modTime: time.Unix(1, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -15,6 +15,7 @@ package template
 
 import (
 	"bytes"
+	"encoding/json"
 	"net/url"
 	"path/filepath"
 	"regexp"
@@ -136,6 +137,12 @@ var DefaultFuncs = FuncMap{
 		re := regexp.MustCompile(pattern)
 		return re.ReplaceAllString(text, repl)
 	},
+	// toJson encodes the value as JSON, for example to quote strings in
+	// JSON payloads.
+	"toJson": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
 }
 
 // Pair is a key/value string pair.
@@ -230,6 +237,10 @@ type Data struct {
 	CommonAnnotations KV `json:"commonAnnotations"`
 
 	ExternalURL string `json:"externalURL"`
+
+	// TruncatedAlerts is the number of alerts left out of the notification
+	// because of the alert limit of the receiver.
+	TruncatedAlerts int `json:"truncatedAlerts"`
 }
 
 // Alert holds one alert for notification templates.
@@ -240,6 +251,7 @@ type Alert struct {
 	StartsAt     time.Time `json:"startsAt"`
 	EndsAt       time.Time `json:"endsAt"`
 	GeneratorURL string    `json:"generatorURL"`
+	Fingerprint  string    `json:"fingerprint"`
 }
 
 // Alerts is a list of Alert objects.
@@ -289,6 +301,7 @@ func (t *Template) Data(recv string, groupLabels model.LabelSet, alerts ...*type
 			StartsAt:     a.StartsAt,
 			EndsAt:       a.EndsAt,
 			GeneratorURL: a.GeneratorURL,
+			Fingerprint:  a.Fingerprint().String(),
 		}
 		for k, v := range a.Labels {
 			alert.Labels[string(k)] = string(v)
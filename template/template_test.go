@@ -14,10 +14,13 @@
 package template
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/types"
 )
 
 func TestPairNames(t *testing.T) {
@@ -118,3 +121,16 @@ func TestAlertsResolved(t *testing.T) {
 		}
 	}
 }
+
+func TestDataAlertFingerprint(t *testing.T) {
+	tmpl := &Template{ExternalURL: &url.URL{}}
+	a := &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{"alertname": "test", "job": "api"},
+		},
+	}
+
+	data := tmpl.Data("receiver", model.LabelSet{}, a)
+	require.Len(t, data.Alerts, 1)
+	require.Equal(t, a.Fingerprint().String(), data.Alerts[0].Fingerprint)
+}
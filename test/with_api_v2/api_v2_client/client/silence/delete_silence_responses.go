@@ -30,6 +30,13 @@ func (o *DeleteSilenceReader) ReadResponse(response runtime.ClientResponse, cons
 		}
 		return result, nil
 
+	case 403:
+		result := NewDeleteSilenceForbidden()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
 	case 500:
 		result := NewDeleteSilenceInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -63,6 +70,33 @@ func (o *DeleteSilenceOK) readResponse(response runtime.ClientResponse, consumer
 	return nil
 }
 
+// NewDeleteSilenceForbidden creates a DeleteSilenceForbidden with default headers values
+func NewDeleteSilenceForbidden() *DeleteSilenceForbidden {
+	return &DeleteSilenceForbidden{}
+}
+
+/*DeleteSilenceForbidden handles this case with default header values.
+
+Not allowed to modify the silence
+*/
+type DeleteSilenceForbidden struct {
+	Payload string
+}
+
+func (o *DeleteSilenceForbidden) Error() string {
+	return fmt.Sprintf("[DELETE /silence/{silenceID}][%d] deleteSilenceForbidden  %+v", 403, o.Payload)
+}
+
+func (o *DeleteSilenceForbidden) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewDeleteSilenceInternalServerError creates a DeleteSilenceInternalServerError with default headers values
 func NewDeleteSilenceInternalServerError() *DeleteSilenceInternalServerError {
 	return &DeleteSilenceInternalServerError{}
@@ -38,6 +38,13 @@ func (o *PostSilencesReader) ReadResponse(response runtime.ClientResponse, consu
 		}
 		return nil, result
 
+	case 403:
+		result := NewPostSilencesForbidden()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
 	default:
 		return nil, runtime.NewAPIError("unknown error", response, response.Code())
 	}
@@ -99,6 +106,33 @@ func (o *PostSilencesBadRequest) readResponse(response runtime.ClientResponse, c
 	return nil
 }
 
+// NewPostSilencesForbidden creates a PostSilencesForbidden with default headers values
+func NewPostSilencesForbidden() *PostSilencesForbidden {
+	return &PostSilencesForbidden{}
+}
+
+/*PostSilencesForbidden handles this case with default header values.
+
+Not allowed to modify the silence
+*/
+type PostSilencesForbidden struct {
+	Payload string
+}
+
+func (o *PostSilencesForbidden) Error() string {
+	return fmt.Sprintf("[POST /silences][%d] postSilencesForbidden  %+v", 403, o.Payload)
+}
+
+func (o *PostSilencesForbidden) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 /*PostSilencesOKBody post silences o k body
 swagger:model PostSilencesOKBody
 */
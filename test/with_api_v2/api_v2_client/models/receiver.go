@@ -15,6 +15,9 @@ import (
 // swagger:model receiver
 type Receiver struct {
 
+	// integrations
+	Integrations []string `json:"integrations"`
+
 	// name
 	Name string `json:"name,omitempty"`
 }
@@ -36,6 +36,13 @@ type Silence struct {
 	// Required: true
 	Matchers Matchers `json:"matchers"`
 
+	// owner
+	// Read Only: true
+	Owner string `json:"owner,omitempty"`
+
+	// recurrence
+	Recurrence *SilenceRecurrence `json:"recurrence,omitempty"`
+
 	// starts at
 	// Format: date-time
 	StartsAt strfmt.DateTime `json:"startsAt,omitempty"`
@@ -43,6 +50,9 @@ type Silence struct {
 	// status
 	Status *SilenceStatus `json:"status,omitempty"`
 
+	// ticket URL
+	TicketURL string `json:"ticketURL,omitempty"`
+
 	// updated at
 	// Format: date-time
 	UpdatedAt strfmt.DateTime `json:"updatedAt,omitempty"`
@@ -60,6 +70,10 @@ func (m *Silence) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateRecurrence(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateStartsAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -107,6 +121,24 @@ func (m *Silence) validateMatchers(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Silence) validateRecurrence(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Recurrence) { // not required
+		return nil
+	}
+
+	if m.Recurrence != nil {
+		if err := m.Recurrence.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("recurrence")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Silence) validateStartsAt(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.StartsAt) { // not required
@@ -169,6 +201,43 @@ func (m *Silence) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// SilenceRecurrence silence recurrence
+// swagger:model SilenceRecurrence
+type SilenceRecurrence struct {
+
+	// duration
+	Duration string `json:"duration,omitempty"`
+
+	// schedule
+	Schedule string `json:"schedule,omitempty"`
+
+	// timezone
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Validate validates this silence recurrence
+func (m *SilenceRecurrence) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SilenceRecurrence) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SilenceRecurrence) UnmarshalBinary(b []byte) error {
+	var res SilenceRecurrence
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
 // SilenceStatus silence status
 // swagger:model SilenceStatus
 type SilenceStatus struct {
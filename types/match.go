@@ -24,10 +24,13 @@ import (
 )
 
 // Matcher defines a matching rule for the value of a given label.
+// A negative matcher matches all label values the positive form of
+// the matcher does not.
 type Matcher struct {
-	Name    string `json:"name"`
-	Value   string `json:"value"`
-	IsRegex bool   `json:"isRegex"`
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	IsRegex    bool   `json:"isRegex"`
+	IsNegative bool   `json:"isNegative,omitempty"`
 
 	regex *regexp.Regexp
 }
@@ -45,10 +48,18 @@ func (m *Matcher) Init() error {
 }
 
 func (m *Matcher) String() string {
-	if m.IsRegex {
-		return fmt.Sprintf("%s=~%q", m.Name, m.Value)
-	}
-	return fmt.Sprintf("%s=%q", m.Name, m.Value)
+	var op string
+	switch {
+	case m.IsRegex && m.IsNegative:
+		op = "!~"
+	case m.IsRegex:
+		op = "=~"
+	case m.IsNegative:
+		op = "!="
+	default:
+		op = "="
+	}
+	return fmt.Sprintf("%s%s%q", m.Name, op, m.Value)
 }
 
 // Validate returns true iff all fields of the matcher have valid values.
@@ -60,7 +71,7 @@ func (m *Matcher) Validate() error {
 		if _, err := regexp.Compile(m.Value); err != nil {
 			return fmt.Errorf("invalid regular expression %q", m.Value)
 		}
-	} else if !model.LabelValue(m.Value).IsValid() || len(m.Value) == 0 {
+	} else if !model.LabelValue(m.Value).IsValid() || (len(m.Value) == 0 && !m.IsNegative) {
 		return fmt.Errorf("invalid value %q", m.Value)
 	}
 	return nil
@@ -74,10 +85,13 @@ func (m *Matcher) Match(lset model.LabelSet) bool {
 	// for the comparison below.
 	v := lset[model.LabelName(m.Name)]
 
+	var match bool
 	if m.IsRegex {
-		return m.regex.MatchString(string(v))
+		match = m.regex.MatchString(string(v))
+	} else {
+		match = string(v) == m.Value
 	}
-	return string(v) == m.Value
+	return match != m.IsNegative
 }
 
 // NewMatcher returns a new matcher that compares against equality of
@@ -130,7 +144,10 @@ func (ms Matchers) Less(i, j int) bool {
 	if ms[i].Value < ms[j].Value {
 		return true
 	}
-	return !ms[i].IsRegex && ms[j].IsRegex
+	if ms[i].IsRegex != ms[j].IsRegex {
+		return !ms[i].IsRegex
+	}
+	return !ms[i].IsNegative && ms[j].IsNegative
 }
 
 // Equal returns whether both Matchers are equal.
@@ -46,6 +46,10 @@ func TestMatcherValidate(t *testing.T) {
 			matcher: Matcher{Name: validLabelName, Value: validRegexValue, IsRegex: true},
 			valid:   true,
 		},
+		{
+			matcher: Matcher{Name: validLabelName, Value: invalidStringValue, IsNegative: true},
+			valid:   true,
+		},
 		// invalid tests
 		{
 			matcher:  Matcher{Name: invalidLabelName, Value: validStringValue},
@@ -94,6 +98,10 @@ func TestMatcherMatch(t *testing.T) {
 		{matcher: Matcher{Name: "label", Value: "val"}, expected: false},
 		{matcher: Matcher{Name: "label", Value: "val.*", IsRegex: true}, expected: true},
 		{matcher: Matcher{Name: "label", Value: "diffval.*", IsRegex: true}, expected: false},
+		{matcher: Matcher{Name: "label", Value: "value", IsNegative: true}, expected: false},
+		{matcher: Matcher{Name: "label", Value: "val", IsNegative: true}, expected: true},
+		{matcher: Matcher{Name: "label", Value: "val.*", IsRegex: true, IsNegative: true}, expected: false},
+		{matcher: Matcher{Name: "label", Value: "diffval.*", IsRegex: true, IsNegative: true}, expected: true},
 		//unset label
 		{matcher: Matcher{Name: "difflabel", Value: "value"}, expected: false},
 	}
@@ -124,6 +132,18 @@ func TestMatcherString(t *testing.T) {
 	if m.String() != "foo=~\".*\"" {
 		t.Errorf("unexpected matcher string %#v", m.String())
 	}
+
+	m = &Matcher{Name: "foo", Value: "bar", IsNegative: true}
+
+	if m.String() != "foo!=\"bar\"" {
+		t.Errorf("unexpected matcher string %#v", m.String())
+	}
+
+	m = &Matcher{Name: "foo", Value: ".*", IsRegex: true, IsNegative: true}
+
+	if m.String() != "foo!~\".*\"" {
+		t.Errorf("unexpected matcher string %#v", m.String())
+	}
 }
 
 func TestMatchersString(t *testing.T) {
@@ -363,10 +363,23 @@ type Silence struct {
 
 	// The last time the silence was updated.
 	UpdatedAt time.Time `json:"updatedAt"`
+	// The time the silence was created. It is set by the server.
+	CreatedAt time.Time `json:"createdAt"`
 
 	// Information about who created the silence for which reason.
+	// The comment may contain Markdown.
 	CreatedBy string `json:"createdBy"`
 	Comment   string `json:"comment,omitempty"`
+	// Optional link to the incident or change ticket justifying the silence.
+	TicketURL string `json:"ticketURL,omitempty"`
+
+	// The authenticated user who created the silence. It is set by the
+	// server and cannot be changed.
+	Owner string `json:"owner,omitempty"`
+
+	// Optional recurrence restricting the silence to repeating windows
+	// within its time range.
+	Recurrence *SilenceRecurrence `json:"recurrence,omitempty"`
 
 	// timeFunc provides the time against which to evaluate
 	// the silence. Used for test injection.
@@ -381,6 +394,18 @@ func (s *Silence) Expired() bool {
 	return s.StartsAt.Equal(s.EndsAt)
 }
 
+// SilenceRecurrence defines repeating windows in which a silence is in effect.
+type SilenceRecurrence struct {
+	// A cron expression in the standard five-field format defining the
+	// start of each window.
+	Schedule string `json:"schedule"`
+	// The length of each window, e.g. "30m".
+	Duration string `json:"duration"`
+	// The IANA time zone the schedule is evaluated in, e.g. "Europe/Berlin".
+	// Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
 type SilenceStatus struct {
 	State SilenceState `json:"state"`
 }